@@ -0,0 +1,69 @@
+package apperrors
+
+import (
+	"time"
+)
+
+// Categoria de um erro de domínio, que define o status HTTP da resposta
+type Kind int
+
+const (
+	Internal        Kind = iota // Falha inesperada (500), os detalhes não são expostos
+	Validation                  // Entrada inválida (400)
+	Unauthorized                // Falta de autenticação ou credenciais inválidas (401)
+	Forbidden                   // Autenticado, mas sem permissão (403)
+	NotFound                    // Recurso inexistente (404)
+	Conflict                    // Conflito com o estado atual (409)
+	TooManyRequests             // Limite de tentativas atingido (429)
+	Unavailable                 // Serviço externo indisponível (502)
+	TooLarge                    // Arquivo ou corpo da requisição grande demais (413)
+)
+
+// Erro de domínio com um código estável que o frontend pode usar
+type Error struct {
+	Kind    Kind
+	Code    string            // Código legível por máquina, ex: "event_not_found"
+	Message string            // Mensagem para pessoas, em inglês
+	Fields  map[string]string // Detalhes por campo, nos erros de validação
+
+	RetryAfter time.Duration // Quando o cliente pode tentar novamente (TooManyRequests)
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Função para criar um erro de domínio
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NewValidation(code, message string) *Error {
+	return New(Validation, code, message)
+}
+
+func NewUnauthorized(code, message string) *Error {
+	return New(Unauthorized, code, message)
+}
+
+func NewForbidden(code, message string) *Error {
+	return New(Forbidden, code, message)
+}
+
+func NewNotFound(code, message string) *Error {
+	return New(NotFound, code, message)
+}
+
+func NewConflict(code, message string) *Error {
+	return New(Conflict, code, message)
+}
+
+// Função para criar um erro de validação com os detalhes de cada campo
+func InvalidFields(fields map[string]string) *Error {
+	return &Error{
+		Kind:    Validation,
+		Code:    "validation_failed",
+		Message: "request validation failed",
+		Fields:  fields,
+	}
+}
@@ -0,0 +1,76 @@
+package apperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// Corpo JSON das respostas de erro: {"error": {"code", "message", "fields"}}
+type Response struct {
+	Error Body `json:"error"`
+}
+
+type Body struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// Função para obter o status HTTP de cada categoria de erro
+func Status(kind Kind) int {
+	switch kind {
+	case Validation:
+		return http.StatusBadRequest
+	case Unauthorized:
+		return http.StatusUnauthorized
+	case Forbidden:
+		return http.StatusForbidden
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case TooManyRequests:
+		return http.StatusTooManyRequests
+	case Unavailable:
+		return http.StatusBadGateway
+	case TooLarge:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Função para escrever qualquer erro como resposta JSON; erros que não são de
+// domínio viram 500 sem expor a mensagem original (ex: erros de SQL)
+func Write(w http.ResponseWriter, err error) {
+	var appErr *Error
+	if !errors.As(err, &appErr) || appErr.Kind == Internal {
+		log.Println("Erro interno:", err)
+		writeBody(w, http.StatusInternalServerError, Body{
+			Code:    "internal_error",
+			Message: "internal server error",
+		})
+		return
+	}
+
+	if appErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(appErr.RetryAfter.Seconds())))
+	}
+
+	// A mensagem inclui o contexto acrescentado com fmt.Errorf("%w: ...")
+	writeBody(w, Status(appErr.Kind), Body{
+		Code:    appErr.Code,
+		Message: err.Error(),
+		Fields:  appErr.Fields,
+	})
+}
+
+func writeBody(w http.ResponseWriter, status int, body Body) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Response{Error: body})
+}
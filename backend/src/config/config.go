@@ -0,0 +1,199 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Fusos embutidos, para imagens sem o banco de fusos do sistema
+
+	"github.com/joho/godotenv"
+)
+
+// Configuração da aplicação, lida das variáveis de ambiente (e de um .env opcional)
+type Config struct {
+	Port              string
+	CORSOrigins       []string
+	TrustProxyHeaders bool
+
+	Database       DatabaseConfig
+	MigrateOnStart bool // Aplica as migrações pendentes ao iniciar, em vez de recusar
+
+	JWTSecret    string // Assina os tokens de sessão dos usuários
+	TicketSecret string // Assina os tokens dos tickets (QR Code)
+	MpesaAPIKey  string
+
+	TOTPRequiredRoles []string
+	OIDCProviders     []OIDCProvider
+
+	TimeZone *time.Location // Fuso dos dias dos eventos (entradas diárias dos passes)
+
+	MediaDir     string // Diretório das imagens enviadas (armazenamento local)
+	MediaBaseURL string // Prefixo dos endereços públicos das imagens
+
+	SMTP   SMTPConfig // Envio dos emails; sem SMTP_HOST, as mensagens vão para o log
+	AppURL string     // Endereço do aplicativo, usado nos links enviados por email
+}
+
+// Configuração do servidor SMTP
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string // Remetente dos emails, ex: "Ticketing <no-reply@exemplo.com>"
+}
+
+// Configuração da conexão com o PostgreSQL
+type DatabaseConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+}
+
+// Configuração de um provedor OpenID Connect
+type OIDCProvider struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// Emissores conhecidos, usados quando OIDC_<NOME>_ISSUER não é definido
+var defaultOIDCIssuers = map[string]string{
+	"google": "https://accounts.google.com",
+}
+
+// Monta a string de conexão do PostgreSQL
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		d.Host, d.User, d.Password, d.Name, d.Port, d.SSLMode)
+}
+
+// Função para carregar e validar a configuração
+func Load() (*Config, error) {
+	// O .env é opcional; as variáveis já definidas no ambiente têm prioridade
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read .env file: %w", err)
+	}
+
+	var problems []string
+	require := func(name string) string {
+		value := strings.TrimSpace(os.Getenv(name))
+		if value == "" {
+			problems = append(problems, name+" is required")
+		}
+		return value
+	}
+
+	cfg := &Config{
+		Port:        getEnv("PORT", "8080"),
+		CORSOrigins: splitList(getEnv("CORS_ORIGINS", "http://localhost:8081")),
+		Database: DatabaseConfig{
+			Host:     require("DB_HOST"),
+			Port:     getEnv("DB_PORT", "5432"),
+			User:     require("DB_USER"),
+			Password: require("DB_PASSWORD"),
+			Name:     require("DB_NAME"),
+			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+		},
+		JWTSecret:         require("JWT_SECRET"),
+		TicketSecret:      require("TICKET_SECRET"),
+		MpesaAPIKey:       os.Getenv("MPESA_API_KEY"),
+		TOTPRequiredRoles: splitList(os.Getenv("TOTP_REQUIRED_ROLES")),
+		MediaDir:          getEnv("MEDIA_DIR", "uploads"),
+		MediaBaseURL:      getEnv("MEDIA_BASE_URL", "/media"),
+		SMTP: SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		},
+		AppURL: strings.TrimSuffix(getEnv("APP_URL", "http://localhost:8081"), "/"),
+	}
+
+	if _, err := strconv.Atoi(cfg.Port); err != nil {
+		problems = append(problems, "PORT must be a number, got "+strconv.Quote(cfg.Port))
+	}
+	if _, err := strconv.Atoi(cfg.Database.Port); err != nil {
+		problems = append(problems, "DB_PORT must be a number, got "+strconv.Quote(cfg.Database.Port))
+	}
+
+	if cfg.SMTP.Host != "" && cfg.SMTP.From == "" {
+		problems = append(problems, "SMTP_FROM is required when SMTP_HOST is set")
+	}
+	if _, err := strconv.Atoi(cfg.SMTP.Port); err != nil {
+		problems = append(problems, "SMTP_PORT must be a number, got "+strconv.Quote(cfg.SMTP.Port))
+	}
+
+	trustProxy, err := strconv.ParseBool(getEnv("TRUST_PROXY_HEADERS", "false"))
+	if err != nil {
+		problems = append(problems, "TRUST_PROXY_HEADERS must be true or false")
+	}
+	cfg.TrustProxyHeaders = trustProxy
+
+	migrateOnStart, err := strconv.ParseBool(getEnv("MIGRATE_ON_START", "false"))
+	if err != nil {
+		problems = append(problems, "MIGRATE_ON_START must be true or false")
+	}
+	cfg.MigrateOnStart = migrateOnStart
+
+	timeZone, err := time.LoadLocation(getEnv("TIMEZONE", "Africa/Maputo"))
+	if err != nil {
+		problems = append(problems, "TIMEZONE must be an IANA time zone like Africa/Maputo")
+	}
+	cfg.TimeZone = timeZone
+
+	for _, role := range cfg.TOTPRequiredRoles {
+		if role != "buyer" && role != "organizer" && role != "admin" {
+			problems = append(problems, "TOTP_REQUIRED_ROLES contains unknown role "+strconv.Quote(role))
+		}
+	}
+
+	// Provedores de login social: OIDC_PROVIDERS=google e OIDC_GOOGLE_CLIENT_ID, ...
+	for _, name := range splitList(strings.ToLower(os.Getenv("OIDC_PROVIDERS"))) {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProvider{
+			Name:         name,
+			IssuerURL:    getEnv(prefix+"ISSUER", defaultOIDCIssuers[name]),
+			ClientID:     require(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  require(prefix + "REDIRECT_URL"),
+		}
+		if provider.IssuerURL == "" {
+			problems = append(problems, prefix+"ISSUER is required")
+		}
+		cfg.OIDCProviders = append(cfg.OIDCProviders, provider)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	return cfg, nil
+}
+
+func getEnv(name, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Divide uma lista separada por vírgulas, ignorando itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"net/http"
+	"src/apperrors"
+	"src/services"
+)
+
+// Função para listar as tentativas de login falhadas (apenas administradores)
+func (h *Handler) GetLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	if user.Role != "admin" {
+		apperrors.Write(w, errAdminOnly)
+		return
+	}
+
+	// Filtros e paginação opcionais: ?email=&ip=&limit=&cursor=
+	query := newQueryParams(r)
+	filter := services.LoginAttemptFilter{
+		Email: query.text("email"),
+		IP:    query.text("ip"),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	page, err := h.svc.Auth.GetFailedLoginAttempts(filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a página de tentativas, das mais recentes para as mais antigas
+	writePage(w, page.Items, page.Total, page.NextCursor)
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/database"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Função para criar uma chave de API (a chave só é exibida nesta resposta)
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Parse do corpo da requisição
+	var keyRequest struct {
+		Name   string   `json:"name" validate:"notblank,max=100"`
+		Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=events:read tickets:validate"`
+	}
+	if err := decodeRequest(r, &keyRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de serviço para criar a chave
+	apiKey, plainKey, err := h.svc.APIKeys.CreateAPIKey(user.ID, keyRequest.Name, keyRequest.Scopes)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a chave criada
+	response := struct {
+		database.APIKey
+		Key string `json:"key"`
+	}{
+		APIKey: *apiKey,
+		Key:    plainKey,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Função para listar as chaves de API do organizador
+func (h *Handler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Chama a função de serviço para listar as chaves
+	apiKeys, err := h.svc.APIKeys.GetAPIKeys(user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a lista de chaves (sem o segredo)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(apiKeys)
+}
+
+// Função para revogar uma chave de API
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai o ID da chave da URL
+	keyID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("api_key_id"))
+		return
+	}
+
+	// Chama a função de serviço para revogar a chave
+	err = h.svc.APIKeys.RevokeAPIKey(keyID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
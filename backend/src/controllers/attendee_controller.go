@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"src/apperrors"
+	"src/database"
+	"src/dto"
+	"src/services"
+	"src/spreadsheet"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Função para ler o evento da URL e os filtros da lista de participantes: ?search=&status=
+func attendeeQuery(r *http.Request) (uuid.UUID, *queryParams, services.TicketFilter, error) {
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		return uuid.Nil, nil, services.TicketFilter{}, invalidID("event_id")
+	}
+
+	query := newQueryParams(r)
+	filter := services.TicketFilter{
+		Search: strings.TrimSpace(query.values.Get("search")),
+		Status: query.oneOf("status", "valido", "usado", "cancelado"),
+	}
+	return eventID, query, filter, nil
+}
+
+// Função para listar os participantes do evento (organizador e co-organizador),
+// com busca pelo nome ou email e paginação: ?search=&status=&limit=&cursor=
+func (h *Handler) GetEventAttendees(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, query, filter, err := attendeeQuery(r)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	page, err := h.svc.Attendees.GetEventAttendees(eventID, user.ID, filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	attendees := make([]dto.Attendee, 0, len(page.Items))
+	for _, attendee := range page.Items {
+		attendees = append(attendees, dto.NewAttendee(attendee.Ticket, attendee.OrderReference))
+	}
+
+	// Retorna a página de participantes; a paginação vai nos cabeçalhos
+	writePage(w, attendees, page.Total, page.NextCursor)
+}
+
+// Função para exportar todos os participantes do evento em CSV ou XLSX
+// (?format=csv|xlsx, padrão csv, com os mesmos filtros da listagem); o arquivo
+// é enviado aos poucos, sem montar a lista inteira em memória
+func (h *Handler) ExportEventAttendees(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, query, filter, err := attendeeQuery(r)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+	format := query.oneOf("format", "csv", "xlsx")
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+	if format == "" {
+		format = "csv"
+	}
+
+	// Os cabeçalhos só são enviados com o primeiro lote, depois da verificação
+	// de permissão; até lá, os erros ainda podem ser respondidos em JSON
+	var sheet spreadsheet.Writer
+	flusher, _ := w.(http.Flusher)
+	err = h.svc.Attendees.ExportEventAttendees(eventID, user.ID, filter, func(questions []database.EventQuestion, attendees []services.Attendee) error {
+		if sheet == nil {
+			filename := "attendees-" + eventID.String() + "." + format
+			w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+			w.Header().Set("Cache-Control", "no-store")
+			if format == "xlsx" {
+				w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+				xlsx, err := spreadsheet.NewXLSX(w, "Attendees")
+				if err != nil {
+					return err
+				}
+				sheet = xlsx
+			} else {
+				w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+				sheet = spreadsheet.NewCSV(w)
+			}
+			if err := sheet.WriteRow(dto.AttendeeHeader(questions)); err != nil {
+				return err
+			}
+		}
+
+		for _, attendee := range attendees {
+			if err := sheet.WriteRow(dto.AttendeeRow(dto.NewAttendee(attendee.Ticket, attendee.OrderReference), questions)); err != nil {
+				return err
+			}
+		}
+		if err := sheet.Flush(); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+
+	switch {
+	case err != nil && sheet == nil:
+		apperrors.Write(w, err)
+	case err != nil:
+		// O arquivo já começou a ser enviado; a conexão é encerrada com o arquivo incompleto
+		log.Println("Erro ao exportar os participantes do evento", eventID.String()+":", err)
+	default:
+		if err := sheet.Close(); err != nil {
+			log.Println("Erro ao exportar os participantes do evento", eventID.String()+":", err)
+		}
+	}
+}
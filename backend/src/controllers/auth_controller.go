@@ -4,78 +4,88 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"src/apperrors"
 	"src/database"
+	"src/dto"
 	"src/services"
 )
 
 // Função para registrar um novo usuário
-func RegisterUser(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	var requestBody struct {
-		Name     string `json:"name"`
-		Email    string `json:"email"`
-		Password string `json:"password"`
-		Role     string `json:"role"`
+		Name     string `json:"name" validate:"notblank,max=100"`
+		Email    string `json:"email" validate:"required,email,max=255"`
+		Password string `json:"password" validate:"required,password,max=72"`
+		Role     string `json:"role" validate:"required,oneof=buyer organizer"`
 	}
 
 	// Decodifica o corpo da requisição
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+	if err := decodeRequest(r, &requestBody); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Registra o usuário utilizando o serviço
-	user, err := services.RegisterUser(requestBody.Name, requestBody.Email, requestBody.Password, requestBody.Role)
+	user, err := h.svc.Auth.RegisterUser(requestBody.Name, requestBody.Email, requestBody.Password, requestBody.Role)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o usuário registrado
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(dto.NewUser(*user))
 }
 
 // Função para autenticar um usuário e gerar o token
-func LoginUser(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	var requestBody struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email    string `json:"email" validate:"required,email"`
+		Password string `json:"password" validate:"required"`
+		Code     string `json:"code"` // Código TOTP ou de recuperação, se o 2FA estiver ativo
 	}
 
 	// Decodifica o corpo da requisição
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+	if err := decodeRequest(r, &requestBody); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Realiza o login e gera o token
-	token, user, err := services.LoginUser(requestBody.Email, requestBody.Password)
+	token, user, err := h.svc.Auth.LoginUser(requestBody.Email, requestBody.Password, requestBody.Code, h.svc.Auth.NewLoginClient(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		// Com o código "two_factor_required" o cliente deve pedir o 2FA e repetir o login;
+		// com "login_throttled" o cabeçalho Retry-After indica quando tentar novamente
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o token e os dados do usuário
+	writeLoginResponse(w, h.svc, token, user)
+}
+
+// Resposta dos logins com email e senha e com o provedor OIDC: o token, o usuário
+// e se ele ainda precisa ativar o 2FA exigido para o seu perfil
+func writeLoginResponse(w http.ResponseWriter, svc *services.Services, token string, user *database.User) {
 	response := struct {
-		Token string        `json:"token"`
-		User  database.User `json:"user"`
+		Token                  string   `json:"token"`
+		User                   dto.User `json:"user"`
+		TwoFactorSetupRequired bool     `json:"two_factor_setup_required"`
 	}{
-		Token: token,
-		User:  *user,
+		Token:                  token,
+		User:                   dto.NewUser(*user),
+		TwoFactorSetupRequired: svc.Auth.TwoFactorRequired(user.Role) && !user.TOTPEnabled,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
 
-
-func HelloHandler(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) HelloHandler(w http.ResponseWriter, r *http.Request) {
 	// Verifica o token e recupera o usuário
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
@@ -83,4 +93,4 @@ func HelloHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	fmt.Fprintf(w, `{"message": "Olá, %s!"}`, user.Name)
-}
\ No newline at end of file
+}
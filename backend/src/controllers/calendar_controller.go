@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+	"src/services"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Tipo dos arquivos do iCalendar
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// Função para baixar o evento no formato iCalendar (.ics), para adicioná-lo ao calendário
+func (h *Handler) GetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.APIKeys.VerifyTokenOrAPIKey(w, r, services.ScopeEventsRead)
+	if err != nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	event, calendar, err := h.svc.Calendars.EventCalendar(eventID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o arquivo do evento
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="evento-`+event.ID.String()+`.ics"`)
+	w.Write(calendar)
+}
+
+// Função para ativar (ou trocar) a assinatura do calendário do usuário;
+// a URL anterior deixa de funcionar
+func (h *Handler) EnableCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	token, err := h.svc.Calendars.EnableCalendarFeed(user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Monta os endereços a partir do host da requisição
+	address := r.Host + "/calendar/" + token + ".ics"
+	scheme := "http://"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https://"
+	}
+
+	// Retorna os endereços da assinatura
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.CalendarFeed{URL: scheme + address, WebcalURL: "webcal://" + address})
+}
+
+// Função para desativar a assinatura do calendário do usuário
+func (h *Handler) DisableCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	if err := h.svc.Calendars.DisableCalendarFeed(user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Função para servir a assinatura do calendário; o token na URL substitui a
+// autenticação, pois os aplicativos de calendário não enviam cabeçalhos
+func (h *Handler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	calendar, err := h.svc.Calendars.FeedCalendar(mux.Vars(r)["token"])
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o calendário sempre atualizado, sem cache intermediário
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Write(calendar)
+}
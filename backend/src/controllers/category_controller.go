@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Corpo das requisições de criação e atualização de categorias
+type categoryRequest struct {
+	Name string `json:"name" validate:"notblank,max=60"`
+	Slug string `json:"slug" validate:"omitempty,slug,max=60"`
+}
+
+// Função para listar as categorias com a quantidade de eventos futuros de cada uma
+func (h *Handler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	_, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	categories, err := h.svc.Categories.ListCategories()
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	response := make([]dto.CategoryCount, 0, len(categories))
+	for _, category := range categories {
+		response = append(response, dto.NewCategoryCount(category.Category, category.Events))
+	}
+
+	// Retorna a lista de categorias
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Função para criar uma categoria (apenas administradores)
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	if user.Role != "admin" {
+		apperrors.Write(w, errAdminOnly)
+		return
+	}
+
+	var request categoryRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	category, err := h.svc.Categories.CreateCategory(request.Name, request.Slug)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a categoria criada
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewCategory(*category))
+}
+
+// Função para atualizar uma categoria (apenas administradores)
+func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	if user.Role != "admin" {
+		apperrors.Write(w, errAdminOnly)
+		return
+	}
+
+	categoryID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("category_id"))
+		return
+	}
+
+	var request categoryRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	category, err := h.svc.Categories.UpdateCategory(categoryID, request.Name, request.Slug)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a categoria atualizada
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewCategory(*category))
+}
+
+// Função para remover uma categoria (apenas administradores)
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	if user.Role != "admin" {
+		apperrors.Write(w, errAdminOnly)
+		return
+	}
+
+	categoryID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("category_id"))
+		return
+	}
+
+	if err := h.svc.Categories.DeleteCategory(categoryID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
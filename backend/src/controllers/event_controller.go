@@ -3,99 +3,233 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"src/apperrors"
+	"src/dto"
 	"src/services"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
+// Corpo das requisições de criação e atualização de eventos
+type eventRequest struct {
+	Name        string     `json:"name" validate:"notblank,max=200"`
+	Description string     `json:"description" validate:"max=5000"`
+	Location    string     `json:"location" validate:"required_without=VenueID,omitempty,notblank,max=200"`
+	Date        time.Time  `json:"date" validate:"required"` // No futuro na criação e sempre que mudar (ver validateSchedule)
+	EndDate     *time.Time `json:"end_date"`
+	CategoryID  *uuid.UUID `json:"category_id"`
+	Tags        []string   `json:"tags" validate:"max=10,dive,notblank,max=30"`
+	VenueID     *uuid.UUID `json:"venue_id"`
+	Capacity    int        `json:"capacity" validate:"min=0,max=1000000"`
+
+	// Agendamentos opcionais: publicação do rascunho e janela de vendas
+	PublishAt    *time.Time `json:"publish_at"`
+	SalesStartAt *time.Time `json:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at"`
+
+	// Tickets nominais: até quantas horas antes do início o titular pode ser trocado e conferência na portaria
+	HolderCutoffHours int  `json:"holder_cutoff_hours" validate:"min=0,max=720"`
+	CheckHolderAtGate bool `json:"check_holder_at_gate"`
+}
+
+// Corpo da requisição de mudança de estado do evento
+type eventStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=draft published sales_open sales_closed cancelled finished"`
+}
+
+func (request eventRequest) input() services.EventInput {
+	return services.EventInput{
+		Name:        request.Name,
+		Description: request.Description,
+		Location:    request.Location,
+		Date:        request.Date,
+		EndDate:     request.EndDate,
+		CategoryID:  request.CategoryID,
+		Tags:        request.Tags,
+		VenueID:     request.VenueID,
+		Capacity:    request.Capacity,
+
+		PublishAt:    request.PublishAt,
+		SalesStartAt: request.SalesStartAt,
+		SalesEndAt:   request.SalesEndAt,
+
+		HolderCutoffHours: request.HolderCutoffHours,
+		CheckHolderAtGate: request.CheckHolderAtGate,
+	}
+}
+
 // Função para criar um evento
-func CreateEvent(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
 
 	// Parse do corpo da requisição
-	var eventRequest struct {
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Location    string    `json:"location"`
-		Date        time.Time `json:"date"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&eventRequest); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var eventRequest eventRequest
+	if err := decodeRequest(r, &eventRequest); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Chama a função de service para criar o evento
-	event, err := services.CreateEvent(eventRequest.Name, eventRequest.Description, eventRequest.Location, eventRequest.Date, user.ID)
+	event, err := h.svc.Events.CreateEvent(eventRequest.input(), user.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o evento criado
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	json.NewEncoder(w).Encode(dto.NewEvent(*event))
 }
 
 // Função para listar eventos de um organizador
-func GetEvents(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetEvents(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.APIKeys.VerifyTokenOrAPIKey(w, r, services.ScopeEventsRead)
 	if err != nil {
 		return
 	}
 
+	// Filtros e paginação opcionais: ?date_from=&date_to=&location=&category=&tag=&limit=&cursor=&sort=
+	query := newQueryParams(r)
+	filter := services.EventFilter{
+		DateFrom: query.date("date_from", false),
+		DateTo:   query.date("date_to", true),
+		Location: query.text("location"),
+		Category: query.text("category"),
+		Tag:      strings.ToLower(query.text("tag")),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
 	// Chama a função de service para listar os eventos
-	events, err := services.GetEvents(user.ID)
+	page, err := h.svc.Events.GetEvents(user.ID, filter, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
-	// Retorna a lista de eventos
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(events)
+	// Retorna a página de eventos
+	writePage(w, dto.NewEvents(page.Items), page.Total, page.NextCursor)
+}
+
+// Função para buscar eventos futuros pelo texto (nome, descrição e local)
+func (h *Handler) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	_, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Busca em ?q=, com os filtros e a paginação das listagens de eventos
+	query := newQueryParams(r)
+	text := query.requiredText("q", maxSearchLength)
+	filter := services.EventFilter{
+		OrganizerID: query.uuid("organizer"),
+		DateFrom:    query.date("date_from", false),
+		DateTo:      query.date("date_to", true),
+		Location:    query.text("location"),
+		Category:    query.text("category"),
+		Tag:         strings.ToLower(query.text("tag")),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de service para buscar os eventos
+	page, facets, err := h.svc.Events.SearchEvents(text, filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna os resultados com as facetas; a paginação vai nos cabeçalhos
+	writePage(w, dto.NewEventSearch(page.Items, *facets), page.Total, page.NextCursor)
+}
+
+// Função para buscar os eventos futuros próximos a um ponto
+func (h *Handler) GetNearbyEvents(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	_, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Ponto em ?lat=&lng=, raio em km em ?radius=, com os filtros e a paginação das listagens de eventos
+	query := newQueryParams(r)
+	latitude := query.requiredNumber("lat", -90, 90)
+	longitude := query.requiredNumber("lng", -180, 180)
+	radius := defaultNearbyRadius
+	if value := query.number("radius", minNearbyRadius, maxNearbyRadius); value != nil {
+		radius = *value
+	}
+	filter := services.EventFilter{
+		OrganizerID: query.uuid("organizer"),
+		DateFrom:    query.date("date_from", false),
+		DateTo:      query.date("date_to", true),
+		Category:    query.text("category"),
+		Tag:         strings.ToLower(query.text("tag")),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de service para buscar os eventos próximos
+	page, err := h.svc.Events.GetNearbyEvents(latitude, longitude, radius, filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna os eventos com a distância; a paginação vai nos cabeçalhos
+	writePage(w, dto.NewEventDistances(page.Items), page.Total, page.NextCursor)
 }
 
 // Função para buscar um evento específico
-func GetEvent(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.APIKeys.VerifyTokenOrAPIKey(w, r, services.ScopeEventsRead)
 	if err != nil {
 		return
 	}
 
-	if(user.Email != ""){}
 	// Extrai o ID do evento da URL
 	vars := mux.Vars(r)
 	eventID, err := uuid.Parse(vars["eventID"])
 	if err != nil {
-		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		apperrors.Write(w, invalidID("event_id"))
 		return
 	}
 
 	// Chama a função de serviço para obter o evento
-	event, err := services.GetEvent(eventID)
+	event, err := h.svc.Events.GetEvent(eventID, user.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o evento encontrado
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	json.NewEncoder(w).Encode(dto.NewEvent(*event))
 }
 
-
 // Função para atualizar um evento
-func UpdateEvent(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
@@ -104,38 +238,67 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventID, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		apperrors.Write(w, invalidID("event_id"))
 		return
 	}
 
 	// Parse do corpo da requisição
-	var eventRequest struct {
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Location    string    `json:"location"`
-		Date        time.Time `json:"date"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&eventRequest); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var eventRequest eventRequest
+	if err := decodeRequest(r, &eventRequest); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Chama a função de service para atualizar o evento
-	event, err := services.UpdateEvent(eventID, eventRequest.Name, eventRequest.Description, eventRequest.Location, eventRequest.Date, user.ID)
+	event, err := h.svc.Events.UpdateEvent(eventID, eventRequest.input(), user.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o evento atualizado
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	json.NewEncoder(w).Encode(dto.NewEvent(*event))
+}
+
+// Função para mudar o estado de um evento (publicar, abrir e fechar vendas, cancelar, encerrar)
+func (h *Handler) ChangeEventStatus(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai o ID do evento da URL
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	// Parse do corpo da requisição
+	var request eventStatusRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de service para mudar o estado
+	event, err := h.svc.Events.ChangeEventStatus(eventID, request.Status, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o evento com o novo estado
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewEvent(*event))
 }
 
 // Função para deletar um evento
-func DeleteEvent(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
@@ -144,13 +307,14 @@ func DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventID, err := uuid.Parse(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		apperrors.Write(w, invalidID("event_id"))
 		return
 	}
 
 	// Chama a função de service para deletar o evento
-	if err := services.DeleteEvent(eventID, user.ID); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	err = h.svc.Events.DeleteEvent(eventID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
@@ -159,21 +323,36 @@ func DeleteEvent(w http.ResponseWriter, r *http.Request) {
 }
 
 // Função para listar todos os eventos futuros
-func GetFutureEvents(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetFutureEvents(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	_, err := services.VerifyToken(w, r)
+	_, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
 
+	// Filtros e paginação opcionais: ?date_from=&date_to=&location=&category=&tag=&organizer=&limit=&cursor=&sort=
+	query := newQueryParams(r)
+	filter := services.EventFilter{
+		OrganizerID: query.uuid("organizer"),
+		DateFrom:    query.date("date_from", false),
+		DateTo:      query.date("date_to", true),
+		Location:    query.text("location"),
+		Category:    query.text("category"),
+		Tag:         strings.ToLower(query.text("tag")),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
 	// Chama a função de service para listar os eventos futuros
-	events, err := services.GetFutureEvents()
+	page, err := h.svc.Events.GetFutureEvents(filter, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
-	// Retorna a lista de eventos futuros
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(events)
+	// Retorna a página de eventos futuros
+	writePage(w, dto.NewEvents(page.Items), page.Total, page.NextCursor)
 }
@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Função para convidar um membro para a equipe do evento
+func (h *Handler) InviteEventMember(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai o ID do evento da URL
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	// Parse do corpo da requisição
+	var inviteRequest struct {
+		Email string `json:"email" validate:"required,email,max=255"`
+		Role  string `json:"role" validate:"required,oneof=co-organizer finance scanner"`
+	}
+	if err := decodeRequest(r, &inviteRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de serviço para criar o convite
+	member, err := h.svc.Teams.InviteEventMember(eventID, user.ID, inviteRequest.Email, inviteRequest.Role)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o convite criado
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewEventMember(*member))
+}
+
+// Função para listar a equipe de um evento
+func (h *Handler) GetEventMembers(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai o ID do evento da URL
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	// Chama a função de serviço para listar a equipe
+	members, err := h.svc.Teams.GetEventMembers(eventID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a equipe
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewEventMembers(members))
+}
+
+// Função para remover um membro da equipe do evento
+func (h *Handler) RemoveEventMember(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai os IDs do evento e do membro da URL
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+	memberID, err := uuid.Parse(vars["memberID"])
+	if err != nil {
+		apperrors.Write(w, invalidID("member_id"))
+		return
+	}
+
+	// Chama a função de serviço para remover o membro
+	if err := h.svc.Teams.RemoveEventMember(eventID, memberID, user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Função para listar os convites pendentes do usuário autenticado
+func (h *Handler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Chama a função de serviço para listar os convites
+	invitations, err := h.svc.Teams.GetPendingInvitations(user)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna os convites
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewEventMembers(invitations))
+}
+
+// Função para aceitar um convite para a equipe de um evento
+func (h *Handler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai o ID do convite da URL
+	invitationID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("invitation_id"))
+		return
+	}
+
+	// Chama a função de serviço para aceitar o convite
+	member, err := h.svc.Teams.AcceptInvitation(invitationID, user)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o membro atualizado
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewEventMember(*member))
+}
+
+// Função para obter o resumo de vendas de um evento
+func (h *Handler) GetEventSummary(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Extrai o ID do evento da URL
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	// Chama a função de serviço para calcular o resumo
+	summary, err := h.svc.Teams.GetEventSummary(eventID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o resumo
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
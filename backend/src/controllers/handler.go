@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"src/apperrors"
+	"src/services"
+)
+
+// Handler agrupa os controllers HTTP e os serviços de que dependem
+type Handler struct {
+	svc *services.Services
+}
+
+// Função para criar os controllers a partir dos serviços
+func New(svc *services.Services) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Erros comuns a vários controllers
+var (
+	errInvalidRequestBody = apperrors.NewValidation("invalid_request_body", "invalid request body")
+	errAdminOnly          = apperrors.NewForbidden("admin_only", "only administrators can access this resource")
+)
+
+// Função para criar o erro de um ID inválido na URL ou no corpo da requisição
+func invalidID(field string) error {
+	return &apperrors.Error{
+		Kind:    apperrors.Validation,
+		Code:    "invalid_id",
+		Message: "invalid " + field,
+		Fields:  map[string]string{field: "must be a valid UUID"},
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"src/apperrors"
+	"src/database"
+	"src/dto"
+	"src/services"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Folga para os cabeçalhos e os demais campos do formulário multipart
+const multipartOverhead = 64 << 10
+
+// Função para enviar a capa ou uma imagem da galeria do evento (multipart/form-data,
+// com o arquivo no campo "image" e o tipo no campo "kind": cover ou gallery)
+func (h *Handler) UploadEventImage(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	// Limita o corpo antes de ler, para não aceitar envios gigantes
+	r.Body = http.MaxBytesReader(w, r.Body, services.MaxImageSize+multipartOverhead)
+	if err := r.ParseMultipartForm(services.MaxImageSize + multipartOverhead); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apperrors.Write(w, services.ErrImageTooLarge)
+			return
+		}
+		apperrors.Write(w, errInvalidRequestBody)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	kind := r.FormValue("kind")
+	if kind == "" {
+		kind = database.ImageGallery
+	}
+	if kind != database.ImageCover && kind != database.ImageGallery {
+		apperrors.Write(w, apperrors.InvalidFields(map[string]string{"kind": "must be one of: cover gallery"}))
+		return
+	}
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		apperrors.Write(w, apperrors.InvalidFields(map[string]string{"image": "is required"}))
+		return
+	}
+	defer file.Close()
+
+	// Lê um byte além do limite para o serviço recusar os arquivos maiores
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxImageSize+1))
+	if err != nil {
+		apperrors.Write(w, errInvalidRequestBody)
+		return
+	}
+
+	image, err := h.svc.Images.UploadEventImage(eventID, kind, data, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a imagem enviada com os endereços das miniaturas
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewImage(*image))
+}
+
+// Função para remover uma imagem do evento
+func (h *Handler) DeleteEventImage(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+	imageID, err := uuid.Parse(vars["imageID"])
+	if err != nil {
+		apperrors.Write(w, invalidID("image_id"))
+		return
+	}
+
+	if err := h.svc.Images.DeleteEventImage(eventID, imageID, user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Função para servir os arquivos do armazenamento local (rota pública, como as
+// imagens seriam num armazenamento externo)
+func (h *Handler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	file, err := h.svc.Images.OpenMedia(key)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+	defer file.Close()
+
+	// As chaves nunca são reaproveitadas, então o arquivo pode ficar em cache
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	io.Copy(w, file)
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"src/apperrors"
+	"src/services"
+
+	"github.com/gorilla/mux"
+)
+
+// Função para listar os provedores de login social configurados
+func (h *Handler) GetOIDCProviders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"providers": h.svc.OIDC.ProviderNames()})
+}
+
+// Função para iniciar o login social: redireciona para o provedor
+func (h *Handler) StartOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+
+	authURL, err := h.svc.OIDC.StartLogin(provider)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Função de retorno do provedor: conclui o login e retorna o nosso token
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+	query := r.URL.Query()
+
+	// O provedor pode devolver um erro (ex: usuário recusou o consentimento)
+	if providerError := query.Get("error"); providerError != "" {
+		log.Printf("OIDC: o provedor %s devolveu o erro %q", provider, providerError)
+		apperrors.Write(w, services.ErrOIDCLoginFailed)
+		return
+	}
+
+	login, err := h.svc.OIDC.FinishLogin(r.Context(), provider, query.Get("state"), query.Get("code"))
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// O email pertence a uma conta local não verificada: o cliente pede a senha
+	// dessa conta e confirma a ligação em POST /auth/oidc/link
+	if login.LinkToken != "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]any{"link_required": true, "link_token": login.LinkToken})
+		return
+	}
+
+	writeLoginResponse(w, h.svc, login.Token, login.User)
+}
+
+// Função para confirmar com a senha a ligação do login social a uma conta local
+func (h *Handler) ConfirmOIDCLink(w http.ResponseWriter, r *http.Request) {
+	var linkRequest struct {
+		LinkToken string `json:"link_token" validate:"required"`
+		Password  string `json:"password" validate:"required"`
+		Code      string `json:"code"` // Código TOTP ou de recuperação, se o 2FA estiver ativo
+	}
+	if err := decodeRequest(r, &linkRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	token, user, err := h.svc.OIDC.ConfirmLink(linkRequest.LinkToken, linkRequest.Password, linkRequest.Code, h.svc.Auth.NewLoginClient(r))
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	writeLoginResponse(w, h.svc, token, user)
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"src/apperrors"
+	"src/services"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cabeçalhos com a paginação das listagens (o corpo continua sendo um array)
+const (
+	headerTotalCount = "X-Total-Count"
+	headerNextCursor = "X-Next-Cursor"
+)
+
+// Formato das datas sem hora aceitas nos filtros
+const dateOnly = "2006-01-02"
+
+// Tamanho máximo do texto buscado
+const maxSearchLength = 200
+
+// Raio da busca por proximidade, em quilômetros
+const (
+	defaultNearbyRadius = 10.0
+	minNearbyRadius     = 0.1
+	maxNearbyRadius     = 500.0
+)
+
+// Leitor dos parâmetros da query, que acumula os erros de cada campo
+type queryParams struct {
+	values url.Values
+	fields map[string]string
+}
+
+func newQueryParams(r *http.Request) *queryParams {
+	return &queryParams{values: r.URL.Query(), fields: map[string]string{}}
+}
+
+// Função para ler a paginação: ?limit=&cursor=&sort=
+func (q *queryParams) page() services.PageRequest {
+	request := services.PageRequest{
+		Cursor: q.values.Get("cursor"),
+		Sort:   q.values.Get("sort"),
+	}
+	if limit := q.values.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			q.fields["limit"] = "must be a number"
+		}
+		request.Limit = value
+	}
+	return request
+}
+
+// Função para ler uma data em RFC3339 ou YYYY-MM-DD; com endOfDay, uma data
+// sem hora vira o início do dia seguinte, incluindo o dia inteiro no intervalo
+func (q *queryParams) date(name string, endOfDay bool) *time.Time {
+	value := q.values.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return &date
+	}
+	date, err := time.Parse(dateOnly, value)
+	if err != nil {
+		q.fields[name] = "must be a date (YYYY-MM-DD) or RFC 3339 timestamp"
+		return nil
+	}
+	if endOfDay {
+		date = date.AddDate(0, 0, 1)
+	}
+	return &date
+}
+
+// Função para ler um UUID opcional
+func (q *queryParams) uuid(name string) *uuid.UUID {
+	value := q.values.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		q.fields[name] = "must be a valid UUID"
+		return nil
+	}
+	return &id
+}
+
+// Função para ler um número opcional entre min e max
+func (q *queryParams) number(name string, min, max float64) *float64 {
+	value := q.values.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || number < min || number > max {
+		q.fields[name] = fmt.Sprintf("must be a number between %g and %g", min, max)
+		return nil
+	}
+	return &number
+}
+
+// Função para ler um número obrigatório entre min e max
+func (q *queryParams) requiredNumber(name string, min, max float64) float64 {
+	number := q.number(name, min, max)
+	if number == nil {
+		if _, invalid := q.fields[name]; !invalid {
+			q.fields[name] = "is required"
+		}
+		return 0
+	}
+	return *number
+}
+
+// Função para ler um valor opcional entre os permitidos
+func (q *queryParams) oneOf(name string, allowed ...string) string {
+	value := q.values.Get(name)
+	if value == "" {
+		return ""
+	}
+
+	for _, candidate := range allowed {
+		if value == candidate {
+			return value
+		}
+	}
+	q.fields[name] = "must be one of: " + strings.Join(allowed, ", ")
+	return ""
+}
+
+// Função para ler um texto obrigatório com até max caracteres
+func (q *queryParams) requiredText(name string, max int) string {
+	value := q.text(name)
+	switch {
+	case value == "":
+		q.fields[name] = "is required"
+	case len([]rune(value)) > max:
+		q.fields[name] = fmt.Sprintf("must have at most %d characters", max)
+	}
+	return value
+}
+
+// Função para ler um texto opcional
+func (q *queryParams) text(name string) string {
+	return strings.TrimSpace(q.values.Get(name))
+}
+
+// Erro com todos os parâmetros inválidos, ou nil
+func (q *queryParams) err() error {
+	if len(q.fields) == 0 {
+		return nil
+	}
+	return apperrors.InvalidFields(q.fields)
+}
+
+// Função para escrever uma página: os itens no corpo e a paginação nos cabeçalhos
+func writePage(w http.ResponseWriter, items any, total int64, nextCursor string) {
+	w.Header().Set(headerTotalCount, strconv.FormatInt(total, 10))
+	if nextCursor != "" {
+		w.Header().Set(headerNextCursor, nextCursor)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+	"src/services"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Corpo das requisições de criação e atualização das perguntas do evento
+type questionRequest struct {
+	Label    string   `json:"label" validate:"notblank,max=200"`
+	Kind     string   `json:"kind" validate:"required,oneof=text single_choice multi_choice"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options" validate:"max=50,dive,notblank,max=100"` // Só nas perguntas de escolha
+	Position *int     `json:"position" validate:"omitempty,min=0"`
+}
+
+func (request questionRequest) input() services.QuestionInput {
+	return services.QuestionInput{
+		Label:    request.Label,
+		Kind:     request.Kind,
+		Required: request.Required,
+		Options:  request.Options,
+		Position: request.Position,
+	}
+}
+
+// Função para ler o evento e a pergunta da URL
+func questionIDs(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidID("event_id")
+	}
+	questionID, err := uuid.Parse(vars["questionID"])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, invalidID("question_id")
+	}
+	return eventID, questionID, nil
+}
+
+// Função para listar as perguntas do cadastro dos participantes, respondidas na compra do ticket
+func (h *Handler) GetEventQuestions(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	questions, err := h.svc.Questions.GetEventQuestions(eventID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna as perguntas na ordem do formulário
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewQuestions(questions))
+}
+
+// Função para criar uma pergunta no evento (organizador ou equipe com permissão para editar)
+func (h *Handler) CreateEventQuestion(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	var request questionRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	question, err := h.svc.Questions.CreateQuestion(eventID, request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a pergunta criada
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewQuestion(*question))
+}
+
+// Função para alterar uma pergunta do evento
+func (h *Handler) UpdateEventQuestion(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, questionID, err := questionIDs(r)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	var request questionRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	question, err := h.svc.Questions.UpdateQuestion(eventID, questionID, request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a pergunta alterada
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewQuestion(*question))
+}
+
+// Função para remover uma pergunta do evento, com as respostas já dadas a ela
+func (h *Handler) DeleteEventQuestion(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, questionID, err := questionIDs(r)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	if err := h.svc.Questions.DeleteQuestion(eventID, questionID, user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+	"src/services"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Corpo da requisição de criação de uma série: os dados comuns às ocorrências e
+// as datas, pela regra de recorrência a partir de date ou pela lista de datas avulsas
+type seriesRequest struct {
+	Name        string      `json:"name" validate:"notblank,max=200"`
+	Description string      `json:"description" validate:"max=5000"`
+	Location    string      `json:"location" validate:"required_without=VenueID,omitempty,notblank,max=200"`
+	CategoryID  *uuid.UUID  `json:"category_id"`
+	Tags        []string    `json:"tags" validate:"max=10,dive,notblank,max=30"`
+	VenueID     *uuid.UUID  `json:"venue_id"`
+	Capacity    int         `json:"capacity" validate:"min=0,max=1000000"`
+	Recurrence  string      `json:"recurrence" validate:"max=500"`
+	Date        time.Time   `json:"date" validate:"omitempty,future"`
+	Dates       []time.Time `json:"dates" validate:"max=100,dive,future"`
+}
+
+// Corpo da requisição de alteração das ocorrências futuras; os campos omitidos ficam como estão
+type seriesUpdateRequest struct {
+	From        *time.Time `json:"from"`
+	Name        *string    `json:"name" validate:"omitempty,notblank,max=200"`
+	Description *string    `json:"description" validate:"omitempty,max=5000"`
+	Location    *string    `json:"location" validate:"omitempty,notblank,max=200"`
+	CategoryID  *uuid.UUID `json:"category_id"`
+	VenueID     *uuid.UUID `json:"venue_id"`
+	Tags        []string   `json:"tags" validate:"omitempty,max=10,dive,notblank,max=30"`
+	Capacity    *int       `json:"capacity" validate:"omitempty,min=0,max=1000000"`
+	Status      string     `json:"status" validate:"omitempty,oneof=draft published sales_open sales_closed cancelled"`
+}
+
+func (request seriesRequest) input() services.SeriesInput {
+	return services.SeriesInput{
+		Event: services.EventInput{
+			Name:        request.Name,
+			Description: request.Description,
+			Location:    request.Location,
+			Date:        request.Date,
+			CategoryID:  request.CategoryID,
+			Tags:        request.Tags,
+			VenueID:     request.VenueID,
+			Capacity:    request.Capacity,
+		},
+		Recurrence: request.Recurrence,
+		Dates:      request.Dates,
+	}
+}
+
+func (request seriesUpdateRequest) input() services.SeriesUpdateInput {
+	return services.SeriesUpdateInput{
+		From:        request.From,
+		Name:        request.Name,
+		Description: request.Description,
+		Location:    request.Location,
+		CategoryID:  request.CategoryID,
+		VenueID:     request.VenueID,
+		Tags:        request.Tags,
+		Capacity:    request.Capacity,
+		Status:      request.Status,
+	}
+}
+
+// Função para criar uma série de eventos recorrentes com todas as ocorrências
+func (h *Handler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	var request seriesRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	series, err := h.svc.Series.CreateSeries(request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a série criada com as ocorrências
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewSeries(series.Series, series.Occurrences, series.Sold))
+}
+
+// Função para buscar uma série com as ocorrências e os lugares restantes de cada uma
+func (h *Handler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	seriesID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("series_id"))
+		return
+	}
+
+	series, err := h.svc.Series.GetSeries(seriesID, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a série encontrada
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewSeries(series.Series, series.Occurrences, series.Sold))
+}
+
+// Função para alterar de uma vez as ocorrências futuras da série (organizador da série)
+func (h *Handler) UpdateSeriesOccurrences(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	seriesID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("series_id"))
+		return
+	}
+
+	var request seriesUpdateRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	events, err := h.svc.Series.UpdateFutureOccurrences(seriesID, request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna as ocorrências alteradas
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewEvents(events))
+}
+
+// Função para deletar uma série com todas as ocorrências (organizador da série)
+func (h *Handler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	seriesID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("series_id"))
+		return
+	}
+
+	if err := h.svc.Series.DeleteSeries(seriesID, user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
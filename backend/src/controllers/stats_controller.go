@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Função para o painel de vendas do evento (organizador, co-organizador e financeiro):
+// vendas por tipo, receita, entradas, cancelamentos, lugares restantes e vendas por período
+func (h *Handler) GetEventStats(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("event_id"))
+		return
+	}
+
+	// Período do agrupamento das vendas: ?interval=hour|day|week|month (padrão: day)
+	query := newQueryParams(r)
+	interval := query.oneOf("interval", "hour", "day", "week", "month")
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	stats, err := h.svc.Stats.GetEventStats(eventID, user.ID, interval)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o painel do evento
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewEventStats(stats.Event, stats.Tickets, stats.Bucket, stats.Sales))
+}
@@ -3,80 +3,204 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"src/apperrors"
+	"src/dto"
 	"src/services"
 
 	"github.com/google/uuid"
-	// "github.com/gorilla/mux"
+	"github.com/gorilla/mux"
 )
 
+// Resposta a uma pergunta do evento no corpo da compra: `value` nas perguntas
+// livres e de escolha única, `values` nas de múltipla escolha
+type answerRequest struct {
+	QuestionID uuid.UUID `json:"question_id" validate:"required"`
+	Value      string    `json:"value" validate:"max=1000"`
+	Values     []string  `json:"values" validate:"max=50,dive,max=100"`
+}
+
+// Função para juntar os valores informados da resposta
+func (request answerRequest) answer() services.QuestionAnswer {
+	values := request.Values
+	if request.Value != "" {
+		values = append([]string{request.Value}, values...)
+	}
+	return services.QuestionAnswer{QuestionID: request.QuestionID, Values: values}
+}
+
+// Titular do ticket no corpo da requisição; sem nome, é o próprio comprador
+type holderRequest struct {
+	HolderName  string `json:"holder_name" validate:"max=100"`
+	HolderEmail string `json:"holder_email" validate:"omitempty,email,max=254"`
+	HolderCopy  bool   `json:"holder_copy"` // Compartilha o ticket com a conta do titular
+}
+
+func (request holderRequest) input() services.HolderInput {
+	return services.HolderInput{Name: request.HolderName, Email: request.HolderEmail, Copy: request.HolderCopy}
+}
+
 // Função para criar um ticket
-func CreateTicket(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
-		http.Error(w, "Usuário não autenticado", http.StatusUnauthorized)
 		return
 	}
 
 	// Parse do corpo da requisição
 	var ticketRequest struct {
-		EventID uuid.UUID `json:"event_id" binding:"required"`
+		EventID uuid.UUID       `json:"event_id" validate:"required"`
+		Type    string          `json:"type" validate:"omitempty,oneof=single pass"` // Padrão: single
+		Answers []answerRequest `json:"answers" validate:"max=30,dive"`              // Respostas às perguntas do evento
+		// Titular, quando não é o comprador
+		holderRequest
 	}
-	if err := json.NewDecoder(r.Body).Decode(&ticketRequest); err != nil {
-		http.Error(w, "Corpo da requisição inválido", http.StatusBadRequest)
+	if err := decodeRequest(r, &ticketRequest); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
+	answers := make([]services.QuestionAnswer, 0, len(ticketRequest.Answers))
+	for _, answer := range ticketRequest.Answers {
+		answers = append(answers, answer.answer())
+	}
+
 	// Chama a função de service para criar o ticket
-	ticket, err := services.CreateTicket(ticketRequest.EventID, user.ID)
+	ticket, err := h.svc.Tickets.CreateTicket(ticketRequest.EventID, ticketRequest.Type, ticketRequest.holderRequest.input(), answers, user.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o ticket criado
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(ticket)
+	json.NewEncoder(w).Encode(dto.NewTicket(*ticket))
 }
 
 // Função para listar tickets de um comprador
-func GetTickets(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetTickets(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
 
+	// Filtros e paginação opcionais: ?status=&event_id=&date_from=&date_to=&limit=&cursor=&sort=
+	query := newQueryParams(r)
+	filter := services.TicketFilter{
+		Status:   query.oneOf("status", "valido", "usado", "cancelado"),
+		EventID:  query.uuid("event_id"),
+		DateFrom: query.date("date_from", false),
+		DateTo:   query.date("date_to", true),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
 	// Chama a função de service para listar os tickets do usuário
-	tickets, err := services.GetTicketsByUser(user.ID)
+	page, err := h.svc.Tickets.GetTicketsByUser(user.ID, filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a página de tickets
+	writePage(w, dto.NewTickets(page.Items), page.Total, page.NextCursor)
+}
+
+// Função para listar os tickets compartilhados com o usuário, em que ele é o titular
+func (h *Handler) GetSharedTickets(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Os mesmos filtros e paginação da listagem dos tickets do comprador
+	query := newQueryParams(r)
+	filter := services.TicketFilter{
+		Status:   query.oneOf("status", "valido", "usado", "cancelado"),
+		EventID:  query.uuid("event_id"),
+		DateFrom: query.date("date_from", false),
+		DateTo:   query.date("date_to", true),
+	}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de service para listar os tickets compartilhados
+	page, err := h.svc.Tickets.GetTicketsSharedWith(user.ID, filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a página de tickets
+	writePage(w, dto.NewTickets(page.Items), page.Total, page.NextCursor)
+}
+
+// Função para trocar o titular de um ticket (só o comprador, até o prazo do evento)
+func (h *Handler) UpdateTicketHolder(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	ticketID, err := uuid.Parse(mux.Vars(r)["id"])
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, invalidID("ticket_id"))
+		return
+	}
+
+	// Sem nome, o titular volta a ser o comprador
+	var request holderRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
-	// Retorna a lista de tickets
+	ticket, err := h.svc.Tickets.ChangeTicketHolder(ticketID, request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o ticket com o novo token
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tickets)
+	json.NewEncoder(w).Encode(dto.NewTicket(*ticket))
 }
 
-// // Função para listar tickets de um evento
-// func GetTicketsByEvent(w http.ResponseWriter, r *http.Request) {
-// 	// Extrai o ID do evento da URL
-// 	vars := mux.Vars(r)
-// 	eventID, err := uuid.Parse(vars["eventID"])
-// 	if err != nil {
-// 		http.Error(w, "Invalid event ID", http.StatusBadRequest)
-// 		return
-// 	}
-
-// 	// Chama a função de serviço para listar os tickets do evento
-// 	tickets, err := services.GetTicketsByEvent(eventID)
-// 	if err != nil {
-// 		http.Error(w, err.Error(), http.StatusInternalServerError)
-// 		return
-// 	}
-
-// 	// Retorna a lista de tickets
-// 	w.Header().Set("Content-Type", "application/json")
-// 	json.NewEncoder(w).Encode(tickets)
-// }
+// Função para validar um ticket na entrada do evento
+func (h *Handler) ValidateTicket(w http.ResponseWriter, r *http.Request) {
+	// Aceita o JWT do organizador ou uma chave de API com o escopo tickets:validate
+	user, err := h.svc.APIKeys.VerifyTokenOrAPIKey(w, r, services.ScopeTicketsValidate)
+	if err != nil {
+		return
+	}
+
+	// Parse do corpo da requisição (token lido do QR Code)
+	var validateRequest struct {
+		Token      string `json:"token" validate:"required"`
+		HolderName string `json:"holder_name" validate:"max=100"` // Nome do documento apresentado, conferido com o titular
+	}
+	if err := decodeRequest(r, &validateRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de service para validar o ticket
+	ticket, err := h.svc.Tickets.ValidateTicket(validateRequest.Token, validateRequest.HolderName, user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o ticket validado
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewTicket(*ticket))
+}
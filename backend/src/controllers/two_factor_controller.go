@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+)
+
+// Função para iniciar a ativação do 2FA (retorna a URI otpauth e o QR Code)
+func (h *Handler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyEnrollmentToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Chama a função de serviço para gerar o segredo
+	enrollment, err := h.svc.Auth.EnrollTwoFactor(user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o segredo, a URI e o QR Code em PNG (data URI)
+	response := struct {
+		Secret     string `json:"secret"`
+		OTPAuthURI string `json:"otpauth_uri"`
+		QRCode     string `json:"qr_code"`
+	}{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.OTPAuthURI,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(enrollment.QRCodePNG),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Função para confirmar a ativação do 2FA com um código da aplicação autenticadora
+func (h *Handler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyEnrollmentToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Parse do corpo da requisição
+	var confirmRequest struct {
+		Code string `json:"code" validate:"required"`
+	}
+	if err := decodeRequest(r, &confirmRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de serviço para ativar o 2FA
+	recoveryCodes, err := h.svc.Auth.ConfirmTwoFactor(user.ID, confirmRequest.Code)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Os códigos de recuperação só são exibidos nesta resposta
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"recovery_codes": recoveryCodes})
+}
+
+// Função para desativar o 2FA (exige a senha e um código válido)
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Parse do corpo da requisição
+	var disableRequest struct {
+		Password string `json:"password" validate:"required"`
+		Code     string `json:"code" validate:"required"`
+	}
+	if err := decodeRequest(r, &disableRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Chama a função de serviço para desativar o 2FA
+	err = h.svc.Auth.DisableTwoFactor(user.ID, disableRequest.Password, disableRequest.Code)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Responde com sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
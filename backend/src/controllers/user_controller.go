@@ -3,63 +3,64 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
-	"src/services"
+	"src/apperrors"
+	"src/dto"
 )
 
 // Função para atualizar as informações do usuário
-func UpdateUser(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
 
 	// Parse do corpo da requisição
 	var userRequest struct {
-		Name  string `json:"name"`
-		Email string `json:"email"`
+		Name  string `json:"name" validate:"notblank,max=100"`
+		Email string `json:"email" validate:"required,email,max=255"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&userRequest); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeRequest(r, &userRequest); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Chama a função de serviço para atualizar o usuário
-	updatedUser, err := services.UpdateUser(user.ID, userRequest.Name, userRequest.Email)
+	updatedUser, err := h.svc.Users.UpdateUser(user.ID, userRequest.Name, userRequest.Email)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Retorna o usuário atualizado
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updatedUser)
+	json.NewEncoder(w).Encode(dto.NewUser(*updatedUser))
 }
 
 
-func ChangePassword(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
 
 	// Parse do corpo da requisição
 	var passwordRequest struct {
-		OldPassword string `json:"old_password"`
-		NewPassword string `json:"new_password"`
+		OldPassword string `json:"old_password" validate:"required"`
+		NewPassword string `json:"new_password" validate:"required,password,max=72"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&passwordRequest); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeRequest(r, &passwordRequest); err != nil {
+		apperrors.Write(w, err)
 		return
 	}
 
 	// Chama a função de serviço para alterar a senha
-	err = services.ChangePassword(user.ID, passwordRequest.OldPassword, passwordRequest.NewPassword)
+	err = h.svc.Users.ChangePassword(user.ID, passwordRequest.OldPassword, passwordRequest.NewPassword)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperrors.Write(w, err)
 		return
 	}
 
@@ -68,15 +69,53 @@ func ChangePassword(w http.ResponseWriter, r *http.Request) {
 }
 
 
+// Função para enviar de novo o link de verificação do email do usuário
+func (h *Handler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	if err := h.svc.Users.ResendEmailVerification(user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Responde com sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Função para confirmar o email pelo token do link enviado (rota pública)
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var verifyRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+	if err := decodeRequest(r, &verifyRequest); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	user, err := h.svc.Users.VerifyEmail(verifyRequest.Token)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o usuário com o email verificado
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewUser(*user))
+}
+
 // Função para obter todas as informações do usuário autenticado
-func GetUserInfo(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 	// Verifica se o usuário está autenticado
-	user, err := services.VerifyToken(w, r)
+	user, err := h.svc.Auth.VerifyToken(w, r)
 	if err != nil {
 		return
 	}
 
 	// Retorna as informações do usuário
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(dto.NewUser(*user))
 }
\ No newline at end of file
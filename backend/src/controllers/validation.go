@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"src/apperrors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Tamanho mínimo das senhas
+const minPasswordLength = 8
+
+// Formato dos slugs das categorias
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Validador das structs de requisição, configurado pelas tags `validate`
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Os erros usam o nome do campo no JSON (ex: "event_id" em vez de "EventID")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	// Senha com o tamanho mínimo e pelo menos uma letra e um número
+	v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		password := fl.Field().String()
+		hasLetter := strings.IndexFunc(password, unicode.IsLetter) >= 0
+		hasDigit := strings.IndexFunc(password, unicode.IsDigit) >= 0
+		return len([]rune(password)) >= minPasswordLength && hasLetter && hasDigit
+	})
+
+	// Data posterior ao momento da requisição
+	v.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+		date, ok := fl.Field().Interface().(time.Time)
+		return ok && date.After(time.Now())
+	})
+
+	// Texto que não seja apenas espaços
+	v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+
+	// Identificador em minúsculas, números e hífens (ex: "musica-ao-vivo")
+	v.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slugPattern.MatchString(fl.Field().String())
+	})
+
+	return v
+}
+
+// Função para decodificar o corpo JSON da requisição e validar os campos
+func decodeRequest(r *http.Request, dst any) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		return errInvalidRequestBody
+	}
+
+	return validateRequest(dst)
+}
+
+// Função para validar uma struct de requisição, devolvendo os erros de cada campo
+func validateRequest(request any) error {
+	err := validate.Struct(request)
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	fields := make(map[string]string, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		// Mantém o caminho dos campos aninhados (ex: "scopes[0]"), sem o nome da struct
+		field := fieldError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		fields[field] = validationMessage(fieldError)
+	}
+
+	return apperrors.InvalidFields(fields)
+}
+
+// Mensagem legível para cada regra de validação
+func validationMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required", "required_without", "notblank":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "password":
+		return fmt.Sprintf("must have at least %d characters, including letters and numbers", minPasswordLength)
+	case "future":
+		return "must be in the future"
+	case "slug":
+		return "must contain only lowercase letters, numbers and hyphens"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fieldError.Param()), ", ")
+	case "min":
+		if fieldError.Kind() == reflect.Slice {
+			return fmt.Sprintf("must have at least %s items", fieldError.Param())
+		}
+		if isNumber(fieldError.Kind()) {
+			return "must be at least " + fieldError.Param()
+		}
+		return fmt.Sprintf("must have at least %s characters", fieldError.Param())
+	case "max":
+		if fieldError.Kind() == reflect.Slice {
+			return fmt.Sprintf("must have at most %s items", fieldError.Param())
+		}
+		if isNumber(fieldError.Kind()) {
+			return "must be at most " + fieldError.Param()
+		}
+		return fmt.Sprintf("must have at most %s characters", fieldError.Param())
+	case "len":
+		return fmt.Sprintf("must have exactly %s characters", fieldError.Param())
+	default:
+		return "is invalid"
+	}
+}
+
+// Verifica se o campo é numérico (min e max limitam o valor, não o tamanho)
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"src/apperrors"
+	"src/dto"
+	"src/services"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Corpo das requisições de criação e atualização de locais
+type venueRequest struct {
+	Name          string   `json:"name" validate:"notblank,max=200"`
+	Address       string   `json:"address" validate:"notblank,max=300"`
+	City          string   `json:"city" validate:"notblank,max=100"`
+	Latitude      *float64 `json:"latitude" validate:"required,min=-90,max=90"`
+	Longitude     *float64 `json:"longitude" validate:"required,min=-180,max=180"`
+	Capacity      int      `json:"capacity" validate:"min=0"`
+	Accessibility string   `json:"accessibility" validate:"max=2000"`
+}
+
+func (request venueRequest) input() services.VenueInput {
+	return services.VenueInput{
+		Name:          request.Name,
+		Address:       request.Address,
+		City:          request.City,
+		Latitude:      *request.Latitude,
+		Longitude:     *request.Longitude,
+		Capacity:      request.Capacity,
+		Accessibility: request.Accessibility,
+	}
+}
+
+// Função para cadastrar um local (organizadores e administradores)
+func (h *Handler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	var request venueRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	venue, err := h.svc.Venues.CreateVenue(request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o local criado
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.NewVenue(*venue))
+}
+
+// Função para listar os locais cadastrados
+func (h *Handler) GetVenues(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	_, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	// Filtro e paginação opcionais: ?city=&limit=&cursor=&sort=
+	query := newQueryParams(r)
+	filter := services.VenueFilter{City: query.text("city")}
+	request := query.page()
+	if err := query.err(); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	page, err := h.svc.Venues.GetVenues(filter, request)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna a página de locais
+	writePage(w, dto.NewVenues(page.Items), page.Total, page.NextCursor)
+}
+
+// Função para buscar um local específico
+func (h *Handler) GetVenue(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	_, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	venueID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("venue_id"))
+		return
+	}
+
+	venue, err := h.svc.Venues.GetVenue(venueID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o local encontrado
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewVenue(*venue))
+}
+
+// Função para atualizar um local (quem o cadastrou ou um administrador)
+func (h *Handler) UpdateVenue(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	venueID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("venue_id"))
+		return
+	}
+
+	var request venueRequest
+	if err := decodeRequest(r, &request); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	venue, err := h.svc.Venues.UpdateVenue(venueID, request.input(), user.ID)
+	if err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna o local atualizado
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewVenue(*venue))
+}
+
+// Função para remover um local (quem o cadastrou ou um administrador)
+func (h *Handler) DeleteVenue(w http.ResponseWriter, r *http.Request) {
+	// Verifica se o usuário está autenticado
+	user, err := h.svc.Auth.VerifyToken(w, r)
+	if err != nil {
+		return
+	}
+
+	venueID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		apperrors.Write(w, invalidID("venue_id"))
+		return
+	}
+
+	if err := h.svc.Venues.DeleteVenue(venueID, user.ID); err != nil {
+		apperrors.Write(w, err)
+		return
+	}
+
+	// Retorna sucesso
+	w.WriteHeader(http.StatusNoContent)
+}
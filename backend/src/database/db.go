@@ -2,29 +2,44 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"src/config"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// Variável global para conexão com o banco
-var DB *gorm.DB
-
-// InitDB inicializa a conexão e roda as migrações
-func InitDB() {
-	dsn := "host=postgres user=admin password=admin dbname=ticketing port=5432 sslmode=disable"
-	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// InitDB abre a conexão com o banco (as migrações são aplicadas à parte)
+func InitDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	// TranslateError converte violações de unicidade em gorm.ErrDuplicatedKey
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{TranslateError: true})
 	if err != nil {
-		log.Fatal("Erro ao conectar ao banco:", err)
+		return nil, fmt.Errorf("erro ao conectar ao banco: %w", err)
 	}
 	fmt.Println("Banco conectado com sucesso!")
+	return db, nil
+}
+
+// EnsureSchemaUpToDate recusa iniciar com o esquema desatualizado, a menos que
+// migrateOnStart esteja ativo, caso em que aplica as migrações pendentes
+func EnsureSchemaUpToDate(db *gorm.DB, migrateOnStart bool) error {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return fmt.Errorf("failed to check schema version: %w", err)
+	}
+	if len(pending) == 0 {
+		fmt.Println("Esquema do banco atualizado!")
+		return nil
+	}
+
+	if !migrateOnStart {
+		return fmt.Errorf("database schema is behind: %d pending migration(s), starting with %04d_%s; run `app migrate up` or set MIGRATE_ON_START=true",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
 
-	// Rodar migrações automaticamente
-	err = DB.AutoMigrate(&User{}, &Event{}, &Ticket{}, &Payment{})
+	applied, err := MigrateUp(db)
 	if err != nil {
-		log.Fatal("Erro ao migrar tabelas:", err)
+		return err
 	}
-	fmt.Println("Migração concluída com sucesso!")
+	fmt.Printf("%d migração(ões) aplicada(s) com sucesso!\n", applied)
+	return nil
 }
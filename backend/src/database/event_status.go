@@ -0,0 +1,77 @@
+package database
+
+import (
+	"slices"
+	"time"
+)
+
+// Estados do ciclo de vida de um evento
+const (
+	EventDraft       = "draft"        // Em preparação, visível apenas para a equipe
+	EventPublished   = "published"    // Visível aos compradores, vendas ainda fechadas
+	EventSalesOpen   = "sales_open"   // Visível e com as vendas abertas
+	EventSalesClosed = "sales_closed" // Visível, vendas encerradas
+	EventCancelled   = "cancelled"
+	EventFinished    = "finished"
+)
+
+// Estados em que o evento aparece para os compradores
+var PublicEventStatuses = []string{EventPublished, EventSalesOpen, EventSalesClosed}
+
+// Função para calcular o estado do evento no momento indicado: o estado
+// definido pela equipe, ajustado pela publicação agendada e pela janela de vendas
+func (event *Event) StatusAt(now time.Time) string {
+	status := event.Status
+	if status == EventDraft && event.PublishAt != nil && !event.PublishAt.After(now) {
+		status = EventPublished
+	}
+
+	salesStarted := event.SalesStartAt == nil || !event.SalesStartAt.After(now)
+	switch {
+	case status == EventPublished && event.SalesStartAt != nil && salesStarted:
+		status = EventSalesOpen
+	case status == EventSalesOpen && !salesStarted:
+		status = EventPublished
+	}
+
+	// Sem fim agendado, as vendas vão até o início do evento (ou até o fim, nos de vários dias)
+	salesEnd := event.Date
+	switch {
+	case event.SalesEndAt != nil:
+		salesEnd = *event.SalesEndAt
+	case event.EndDate != nil:
+		salesEnd = *event.EndDate
+	}
+	if status == EventSalesOpen && !salesEnd.After(now) {
+		status = EventSalesClosed
+	}
+	return status
+}
+
+// Verifica se o evento aparece para os compradores no momento indicado
+func (event *Event) VisibleAt(now time.Time) bool {
+	return slices.Contains(PublicEventStatuses, event.StatusAt(now))
+}
+
+// Função para calcular o dia (no fuso indicado) de um momento, como data à
+// meia-noite UTC, o mesmo formato das colunas date do banco
+func DayIn(moment time.Time, location *time.Location) time.Time {
+	moment = moment.In(location)
+	return time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Função para listar os dias do evento no fuso indicado, do primeiro ao último
+// (um único dia quando o evento não tem data de fim)
+func (event *Event) DaysIn(location *time.Location) []time.Time {
+	day := func(date time.Time) time.Time { return DayIn(date, location) }
+
+	last := day(event.Date)
+	if event.EndDate != nil {
+		last = day(*event.EndDate)
+	}
+	days := []time.Time{}
+	for current := day(event.Date); !current.After(last); current = current.AddDate(0, 0, 1) {
+		days = append(days, current)
+	}
+	return days
+}
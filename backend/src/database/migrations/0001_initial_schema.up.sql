@@ -0,0 +1,47 @@
+-- Esquema inicial (equivalente ao que o AutoMigrate criava). Usa IF NOT EXISTS
+-- para adotar bancos já criados pelo AutoMigrate sem perder dados.
+
+CREATE TABLE IF NOT EXISTS users (
+    id       uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name     text NOT NULL,
+    email    text NOT NULL,
+    password text NOT NULL,
+    role     text NOT NULL,
+    CONSTRAINT uni_users_email UNIQUE (email),
+    CONSTRAINT chk_users_role CHECK (role IN ('buyer', 'organizer'))
+);
+
+CREATE TABLE IF NOT EXISTS events (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name         text NOT NULL,
+    description  text,
+    date         timestamptz NOT NULL,
+    location     text NOT NULL,
+    organizer_id uuid NOT NULL,
+    CONSTRAINT fk_events_organizer FOREIGN KEY (organizer_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tickets (
+    id       uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id uuid NOT NULL,
+    user_id  uuid NOT NULL,
+    token    text NOT NULL,
+    status   text NOT NULL DEFAULT 'valido',
+    CONSTRAINT uni_tickets_token UNIQUE (token),
+    CONSTRAINT chk_tickets_status CHECK (status IN ('valido', 'usado', 'cancelado')),
+    CONSTRAINT fk_tickets_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    CONSTRAINT fk_tickets_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS payments (
+    id                   uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id            uuid NOT NULL,
+    user_id              uuid NOT NULL,
+    amount               numeric NOT NULL,
+    status               text NOT NULL DEFAULT 'pendente',
+    mpesa_transaction_id text,
+    CONSTRAINT uni_payments_mpesa_transaction_id UNIQUE (mpesa_transaction_id),
+    CONSTRAINT chk_payments_status CHECK (status IN ('pendente', 'pago')),
+    CONSTRAINT fk_payments_ticket FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    CONSTRAINT fk_payments_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+    id       uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id  uuid NOT NULL,
+    provider text NOT NULL,
+    subject  text NOT NULL,
+    email    text NOT NULL,
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_identity_provider_subject ON user_identities (provider, subject);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities (user_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS login_attempts;
+
+DELETE FROM users WHERE role = 'admin';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_role;
+ALTER TABLE users ADD CONSTRAINT chk_users_role CHECK (role IN ('buyer', 'organizer'));
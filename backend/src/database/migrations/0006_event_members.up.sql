@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS event_members (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id      uuid NOT NULL,
+    email         text NOT NULL,
+    user_id       uuid,
+    role          text NOT NULL,
+    status        text NOT NULL DEFAULT 'pendente',
+    invited_by_id uuid NOT NULL,
+    created_at    timestamptz,
+    accepted_at   timestamptz,
+    CONSTRAINT chk_event_members_role CHECK (role IN ('co-organizer', 'finance', 'scanner')),
+    CONSTRAINT chk_event_members_status CHECK (status IN ('pendente', 'aceito')),
+    CONSTRAINT fk_event_members_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_members_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_member_email ON event_members (event_id, email);
+CREATE INDEX IF NOT EXISTS idx_event_members_user_id ON event_members (user_id);
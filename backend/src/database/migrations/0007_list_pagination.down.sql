@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_login_attempts_created_at_id;
+DROP INDEX IF EXISTS idx_tickets_event_status;
+DROP INDEX IF EXISTS idx_tickets_user_created_at_id;
+DROP INDEX IF EXISTS idx_events_organizer_date_id;
+DROP INDEX IF EXISTS idx_events_date_id;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,8 @@
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now();
+
+-- Índices da paginação por cursor: a ordenação termina sempre no id
+CREATE INDEX IF NOT EXISTS idx_events_date_id ON events (date, id);
+CREATE INDEX IF NOT EXISTS idx_events_organizer_date_id ON events (organizer_id, date, id);
+CREATE INDEX IF NOT EXISTS idx_tickets_user_created_at_id ON tickets (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tickets_event_status ON tickets (event_id, status);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_created_at_id ON login_attempts (created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_events_name_trgm;
+DROP INDEX IF EXISTS idx_events_search_vector;
+
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
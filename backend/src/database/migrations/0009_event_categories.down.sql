@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS event_tags;
+
+DROP INDEX IF EXISTS idx_events_category_date;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_category;
+ALTER TABLE events DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS categories;
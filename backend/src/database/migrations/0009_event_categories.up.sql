@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug       text NOT NULL,
+    name       text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT uni_categories_slug UNIQUE (slug)
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS category_id uuid;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_category;
+ALTER TABLE events ADD CONSTRAINT fk_events_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_events_category_date ON events (category_id, date);
+
+CREATE TABLE IF NOT EXISTS event_tags (
+    event_id uuid NOT NULL,
+    tag      text NOT NULL,
+    PRIMARY KEY (event_id, tag),
+    CONSTRAINT fk_event_tags_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_tags_tag ON event_tags (tag);
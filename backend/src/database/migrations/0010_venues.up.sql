@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS venues (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          text NOT NULL,
+    address       text NOT NULL,
+    city          text NOT NULL,
+    latitude      double precision NOT NULL,
+    longitude     double precision NOT NULL,
+    capacity      bigint NOT NULL DEFAULT 0,
+    accessibility text,
+    created_by_id uuid NOT NULL,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT chk_venues_latitude CHECK (latitude BETWEEN -90 AND 90),
+    CONSTRAINT chk_venues_longitude CHECK (longitude BETWEEN -180 AND 180),
+    CONSTRAINT chk_venues_capacity CHECK (capacity >= 0),
+    CONSTRAINT fk_venues_created_by FOREIGN KEY (created_by_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_venues_city ON venues (city);
+CREATE INDEX IF NOT EXISTS idx_venues_name_id ON venues (name, id);
+CREATE INDEX IF NOT EXISTS idx_venues_created_by_id ON venues (created_by_id);
+-- Usado pela caixa delimitadora que pré-filtra a busca por proximidade
+CREATE INDEX IF NOT EXISTS idx_venues_coordinates ON venues (latitude, longitude);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id uuid;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_venue;
+ALTER TABLE events ADD CONSTRAINT fk_events_venue FOREIGN KEY (venue_id) REFERENCES venues (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_events_venue_id ON events (venue_id);
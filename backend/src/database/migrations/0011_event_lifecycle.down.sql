@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_events_status_date;
+
+ALTER TABLE events DROP COLUMN IF EXISTS sales_end_at;
+ALTER TABLE events DROP COLUMN IF EXISTS sales_start_at;
+ALTER TABLE events DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_status;
+ALTER TABLE events DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- Os eventos existentes já estavam visíveis e à venda; os novos começam como rascunho
+ALTER TABLE events ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'sales_open';
+ALTER TABLE events ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_status;
+ALTER TABLE events ADD CONSTRAINT chk_events_status CHECK (status IN ('draft', 'published', 'sales_open', 'sales_closed', 'cancelled', 'finished'));
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sales_start_at timestamptz;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sales_end_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_events_status_date ON events (status, date);
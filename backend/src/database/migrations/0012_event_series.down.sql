@@ -0,0 +1,8 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_capacity;
+ALTER TABLE events DROP COLUMN IF EXISTS capacity;
+
+DROP INDEX IF EXISTS idx_events_series_date;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_series;
+ALTER TABLE events DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS event_series;
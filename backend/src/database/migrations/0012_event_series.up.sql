@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS event_series (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name         text NOT NULL,
+    recurrence   text,
+    organizer_id uuid NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_event_series_organizer FOREIGN KEY (organizer_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_series_organizer_id ON event_series (organizer_id);
+
+-- Cada ocorrência é um evento da série, removido junto com ela
+ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id uuid;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_series;
+ALTER TABLE events ADD CONSTRAINT fk_events_series FOREIGN KEY (series_id) REFERENCES event_series (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_events_series_date ON events (series_id, date);
+
+-- Lugares à venda de cada evento (0 = sem limite)
+ALTER TABLE events ADD COLUMN IF NOT EXISTS capacity bigint NOT NULL DEFAULT 0;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_capacity;
+ALTER TABLE events ADD CONSTRAINT chk_events_capacity CHECK (capacity >= 0);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS ticket_check_ins;
+
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS chk_tickets_type;
+ALTER TABLE tickets DROP COLUMN IF EXISTS type;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_end_date;
+ALTER TABLE events DROP COLUMN IF EXISTS end_date;
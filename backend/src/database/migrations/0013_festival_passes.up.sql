@@ -0,0 +1,22 @@
+-- Eventos de vários dias (festivais): a data é o início e end_date o fim
+ALTER TABLE events ADD COLUMN IF NOT EXISTS end_date timestamptz;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_end_date;
+ALTER TABLE events ADD CONSTRAINT chk_events_end_date CHECK (end_date IS NULL OR end_date > date);
+
+-- Tipo do ticket: entrada única ou passe com uma entrada por dia
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS type text NOT NULL DEFAULT 'single';
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS chk_tickets_type;
+ALTER TABLE tickets ADD CONSTRAINT chk_tickets_type CHECK (type IN ('single', 'pass'));
+
+CREATE TABLE IF NOT EXISTS ticket_check_ins (
+    id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id        uuid NOT NULL,
+    day              date NOT NULL,
+    checked_in_by_id uuid,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_ticket_check_ins_ticket FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    CONSTRAINT fk_ticket_check_ins_checked_in_by FOREIGN KEY (checked_in_by_id) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- Um passe só entra uma vez em cada dia
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_check_in_day ON ticket_check_ins (ticket_id, day);
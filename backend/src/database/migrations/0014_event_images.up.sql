@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS event_images (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id      uuid NOT NULL,
+    kind          text NOT NULL,
+    content_type  text NOT NULL,
+    size          bigint NOT NULL,
+    width         bigint NOT NULL,
+    height        bigint NOT NULL,
+    key           text NOT NULL,
+    thumbnail_key text NOT NULL,
+    medium_key    text NOT NULL,
+    url           text NOT NULL,
+    thumbnail_url text NOT NULL,
+    medium_url    text NOT NULL,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_event_images_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    CONSTRAINT chk_event_images_kind CHECK (kind IN ('cover', 'gallery'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_images_event_created ON event_images (event_id, created_at);
+
+-- No máximo uma capa por evento
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_images_cover ON event_images (event_id) WHERE kind = 'cover';
@@ -0,0 +1,3 @@
+-- Assinatura do calendário de cada usuário: guarda apenas o hash do token da URL
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash text;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_calendar_token_hash ON users (calendar_token_hash);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ticket_answers;
+DROP TABLE IF EXISTS event_question_options;
+DROP TABLE IF EXISTS event_questions;
@@ -0,0 +1,35 @@
+-- Perguntas do cadastro dos participantes, definidas pelo organizador de cada evento
+CREATE TABLE IF NOT EXISTS event_questions (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id   uuid NOT NULL,
+    label      text NOT NULL,
+    kind       text NOT NULL,
+    required   boolean NOT NULL DEFAULT false,
+    position   bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_event_questions_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    CONSTRAINT chk_event_questions_kind CHECK (kind IN ('text', 'single_choice', 'multi_choice'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_questions_event_position ON event_questions (event_id, position);
+
+-- Opções das perguntas de escolha, em ordem
+CREATE TABLE IF NOT EXISTS event_question_options (
+    question_id uuid NOT NULL,
+    position    bigint NOT NULL,
+    value       text NOT NULL,
+    PRIMARY KEY (question_id, position),
+    CONSTRAINT fk_event_question_options_question FOREIGN KEY (question_id) REFERENCES event_questions (id) ON DELETE CASCADE
+);
+
+-- Respostas dos tickets; uma linha por opção marcada nas perguntas de múltipla escolha
+CREATE TABLE IF NOT EXISTS ticket_answers (
+    ticket_id   uuid NOT NULL,
+    question_id uuid NOT NULL,
+    value       text NOT NULL,
+    PRIMARY KEY (ticket_id, question_id, value),
+    CONSTRAINT fk_ticket_answers_ticket FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    CONSTRAINT fk_ticket_answers_question FOREIGN KEY (question_id) REFERENCES event_questions (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_answers_question_id ON ticket_answers (question_id);
@@ -0,0 +1,8 @@
+ALTER TABLE events DROP COLUMN IF EXISTS check_holder_at_gate;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_holder_cutoff_hours;
+ALTER TABLE events DROP COLUMN IF EXISTS holder_cutoff_hours;
+
+DROP INDEX IF EXISTS idx_tickets_holder_email;
+ALTER TABLE tickets DROP COLUMN IF EXISTS holder_copy;
+ALTER TABLE tickets DROP COLUMN IF EXISTS holder_email;
+ALTER TABLE tickets DROP COLUMN IF EXISTS holder_name;
@@ -0,0 +1,13 @@
+-- Titular de cada ticket, quando não é o próprio comprador
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS holder_name text NOT NULL DEFAULT '';
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS holder_email text NOT NULL DEFAULT '';
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS holder_copy boolean NOT NULL DEFAULT false;
+
+-- Tickets com cópia para o titular, buscados pelo email da conta dele
+CREATE INDEX IF NOT EXISTS idx_tickets_holder_email ON tickets (holder_email) WHERE holder_copy;
+
+-- Prazo para trocar o titular (horas antes do início) e conferência do titular na portaria
+ALTER TABLE events ADD COLUMN IF NOT EXISTS holder_cutoff_hours bigint NOT NULL DEFAULT 0;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_holder_cutoff_hours;
+ALTER TABLE events ADD CONSTRAINT chk_events_holder_cutoff_hours CHECK (holder_cutoff_hours >= 0);
+ALTER TABLE events ADD COLUMN IF NOT EXISTS check_holder_at_gate boolean NOT NULL DEFAULT false;
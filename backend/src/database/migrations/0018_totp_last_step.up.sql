@@ -0,0 +1,2 @@
+-- Último passo TOTP aceito de cada usuário, para recusar a reutilização dos códigos
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_email_verification_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verification_expires_at;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verification_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
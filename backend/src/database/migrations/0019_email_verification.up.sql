@@ -0,0 +1,12 @@
+-- Verificação do email das contas: guarda apenas o hash do token do link enviado
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified boolean NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_hash text;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_expires_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_verification_hash ON users (email_verification_hash);
+
+-- As contas ligadas a um provedor de login social com o mesmo email já têm o email confirmado
+UPDATE users SET email_verified = true
+WHERE EXISTS (
+    SELECT 1 FROM user_identities
+    WHERE user_identities.user_id = users.id AND lower(user_identities.email) = lower(users.email)
+);
@@ -12,10 +12,33 @@ type User struct {
 	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	Name     string    `gorm:"not null"`
 	Email    string    `gorm:"unique;not null"`
-	Password string    `gorm:"not null"`
-	Role     string    `gorm:"not null;check:role IN ('buyer', 'organizer')"`
+	Password string    `gorm:"not null" json:"-"` // Hash bcrypt, nunca exposto nas respostas
+	Role     string    `gorm:"not null;check:role IN ('buyer', 'organizer', 'admin')"`
+
+	// Email confirmado pelo link enviado a ele ou pelo provedor do login social;
+	// volta a ser falso quando o usuário troca o email
+	EmailVerified              bool       `gorm:"not null;default:false"`
+	EmailVerificationHash      *string    `gorm:"uniqueIndex" json:"-"` // Hash do token do link de verificação
+	EmailVerificationExpiresAt *time.Time `json:"-"`
+
+	// Autenticação de dois fatores (TOTP)
+	TOTPSecret  string `json:"-"`
+	TOTPEnabled bool   `gorm:"not null;default:false"`
+	// Último passo de 30 s aceito, para recusar a reutilização do mesmo código
+	TOTPLastStep int64 `gorm:"not null;default:0" json:"-"`
+
+	// Assinatura do calendário com os eventos dos tickets (hash do token da URL)
+	CalendarTokenHash *string `gorm:"uniqueIndex" json:"-"`
 }
 
+// Modelo de Código de Recuperação do 2FA (guardado apenas como hash)
+type RecoveryCode struct {
+	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	User     User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CodeHash string    `gorm:"not null"`
+	UsedAt   *time.Time
+}
 
 // Função para gerar o hash da senha
 func (user *User) SetPassword(password string) error {
@@ -33,30 +56,242 @@ func (user *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// Modelo de Tentativa de login falhada (consultada pelos administradores)
+type LoginAttempt struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Email     string     `gorm:"not null;index"`
+	UserID    *uuid.UUID `gorm:"type:uuid"`
+	User      *User      `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL"`
+	IP        string     `gorm:"not null;index"`
+	UserAgent string
+	Reason    string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null;index"`
+}
+
+// Modelo de Identidade externa (login social via OpenID Connect)
+type UserIdentity struct {
+	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	User     User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Provider string    `gorm:"not null;uniqueIndex:idx_identity_provider_subject"`
+	Subject  string    `gorm:"not null;uniqueIndex:idx_identity_provider_subject"`
+	Email    string    `gorm:"not null"`
+}
+
+// Modelo de Chave de API de um organizador (integrações servidor a servidor)
+type APIKey struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	User       User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Name       string    `gorm:"not null"`
+	Prefix     string    `gorm:"not null"`                 // Início da chave, para identificá-la na listagem
+	KeyHash    string    `gorm:"unique;not null" json:"-"` // Hash SHA-256 da chave completa
+	Scopes     string    `gorm:"not null"`                 // Escopos separados por vírgula
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
 
 // Modelo de Evento
 type Event struct {
 	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	Name        string    `gorm:"not null"`
 	Description string
-	Date        time.Time `gorm:"not null"`
-	Location    string    `gorm:"not null"`
-	OrganizerID uuid.UUID `gorm:"type:uuid;not null"`
+	Date        time.Time  `gorm:"not null"`
+	Location    string     `gorm:"not null"`
+	OrganizerID uuid.UUID  `gorm:"type:uuid;not null"`
+	Organizer   User       `gorm:"foreignKey:OrganizerID;constraint:OnDelete:CASCADE"`
+	CategoryID  *uuid.UUID `gorm:"type:uuid"`
+	Category    *Category  `gorm:"foreignKey:CategoryID;constraint:OnDelete:SET NULL"`
+	Tags        []EventTag `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	VenueID     *uuid.UUID `gorm:"type:uuid;index"`
+	Venue       *Venue     `gorm:"foreignKey:VenueID;constraint:OnDelete:SET NULL"`
+
+	// Ciclo de vida (ver event_status.go): o estado definido pela equipe e os agendamentos
+	Status       string     `gorm:"not null;default:'draft';check:status IN ('draft', 'published', 'sales_open', 'sales_closed', 'cancelled', 'finished')"`
+	PublishAt    *time.Time // Publicação agendada de um rascunho
+	SalesStartAt *time.Time // Abertura agendada das vendas
+	SalesEndAt   *time.Time // Fim das vendas; sem valor, as vendas vão até a data do evento
+
+	Capacity int        `gorm:"not null;default:0;check:capacity >= 0"` // Lugares à venda; 0 = sem limite
+	SeriesID *uuid.UUID `gorm:"type:uuid;index"`                        // Série da qual o evento é uma ocorrência
+	EndDate  *time.Time // Fim dos eventos de vários dias (festivais); sem valor, o evento dura um dia
+
+	Images []EventImage `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"` // Capa e galeria, em ordem de envio
+
+	// Tickets nominais: até quantas horas antes do início o comprador pode trocar o
+	// titular (0 = até o início) e se a portaria confere o nome do titular na entrada
+	HolderCutoffHours int  `gorm:"not null;default:0;check:holder_cutoff_hours >= 0"`
+	CheckHolderAtGate bool `gorm:"not null;default:false"`
+}
+
+// Função para calcular o fim do prazo para trocar o titular dos tickets do evento
+func (event Event) HolderChangesUntil() time.Time {
+	return event.Date.Add(-time.Duration(event.HolderCutoffHours) * time.Hour)
+}
+
+// Tipos de imagem de um evento
+const (
+	ImageCover   = "cover"   // Capa, no máximo uma por evento
+	ImageGallery = "gallery" // Galeria
+)
+
+// Modelo de Imagem de um evento: o arquivo original e as miniaturas ficam no
+// armazenamento (ver o pacote storage); o banco guarda as chaves e os endereços
+type EventImage struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	EventID     uuid.UUID `gorm:"type:uuid;not null;index:idx_event_images_event_created"`
+	Kind        string    `gorm:"not null;check:kind IN ('cover', 'gallery')"`
+	ContentType string    `gorm:"not null"`
+	Size        int64     `gorm:"not null"` // Tamanho do original em bytes
+	Width       int       `gorm:"not null"`
+	Height      int       `gorm:"not null"`
+
+	Key          string `gorm:"not null"` // Original
+	ThumbnailKey string `gorm:"not null"`
+	MediumKey    string `gorm:"not null"`
+	URL          string `gorm:"not null"`
+	ThumbnailURL string `gorm:"not null"`
+	MediumURL    string `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"not null;index:idx_event_images_event_created"`
+}
+
+// Modelo de Série de eventos recorrentes; cada ocorrência é um Event com o SeriesID,
+// com data, capacidade e tickets próprios
+type EventSeries struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Name        string    `gorm:"not null"`
+	Recurrence  string    // Regra no formato RRULE (ex: "FREQ=WEEKLY;BYDAY=FR;COUNT=10"); vazia com datas avulsas
+	OrganizerID uuid.UUID `gorm:"type:uuid;not null;index"`
 	Organizer   User      `gorm:"foreignKey:OrganizerID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// Modelo de Local de eventos, reutilizado entre os eventos dos organizadores
+type Venue struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Name          string    `gorm:"not null"`
+	Address       string    `gorm:"not null"`
+	City          string    `gorm:"not null;index"`
+	Latitude      float64   `gorm:"not null;check:latitude BETWEEN -90 AND 90"`
+	Longitude     float64   `gorm:"not null;check:longitude BETWEEN -180 AND 180"`
+	Capacity      int       `gorm:"not null;default:0;check:capacity >= 0"` // 0 = não informada
+	Accessibility string    // Informações de acessibilidade (rampas, lugares reservados, etc.)
+	CreatedByID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedBy     User      `gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+// Modelo de Categoria de evento (taxonomia mantida pelos administradores)
+type Category struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Slug      string    `gorm:"unique;not null"` // Identificador usado nos filtros (ex: "musica")
+	Name      string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// Modelo de Tag livre de um evento, definida pelo organizador
+type EventTag struct {
+	EventID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tag     string    `gorm:"primaryKey;index"`
+}
+
+// Modelo de Membro da equipe de um evento (co-organizador, financeiro ou porteiro)
+type EventMember struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	EventID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_member_email"`
+	Event       Event      `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	Email       string     `gorm:"not null;uniqueIndex:idx_event_member_email"`
+	UserID      *uuid.UUID `gorm:"type:uuid;index"` // Preenchido quando o convite é aceito
+	User        *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Role        string     `gorm:"not null;check:role IN ('co-organizer', 'finance', 'scanner')"`
+	Status      string     `gorm:"not null;check:status IN ('pendente', 'aceito');default:'pendente'"`
+	InvitedByID uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time
+	AcceptedAt  *time.Time
 }
 
 // Modelo de Ticket atualizado
 type Ticket struct {
-	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	EventID    uuid.UUID `gorm:"type:uuid;not null"`
-	Event      Event     `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
-	UserID     uuid.UUID `gorm:"type:uuid;not null"`
-	User       User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
-	Token      string    `gorm:"unique;not null"`
-	Status     string    `gorm:"not null;check:status IN ('valido', 'usado', 'cancelado');default:'valido'"`
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	EventID   uuid.UUID `gorm:"type:uuid;not null"`
+	Event     Event     `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Token     string    `gorm:"unique;not null"`
+	Status    string    `gorm:"not null;check:status IN ('valido', 'usado', 'cancelado');default:'valido'"`
+	Type      string    `gorm:"not null;check:type IN ('single', 'pass');default:'single'"` // Entrada única ou passe de vários dias
+	CreatedAt time.Time `gorm:"not null"`
+
+	// Titular do ticket (quem vai ao evento), quando não é o próprio comprador
+	HolderName  string `gorm:"not null;default:''"`
+	HolderEmail string `gorm:"not null;default:'';index:idx_tickets_holder_email,where:holder_copy"`
+	HolderCopy  bool   `gorm:"not null;default:false"` // O ticket aparece também na conta do titular
+
+	CheckIns []TicketCheckIn `gorm:"foreignKey:TicketID;constraint:OnDelete:CASCADE"` // Entradas do ticket (nos passes, uma por dia)
+	Answers  []TicketAnswer  `gorm:"foreignKey:TicketID;constraint:OnDelete:CASCADE"` // Respostas às perguntas do evento, dadas na compra
 }
 
+// Função para obter o nome e o email do titular do ticket (o comprador, sem titular definido)
+func (ticket Ticket) Holder() (name, email string) {
+	if ticket.HolderName == "" {
+		return ticket.User.Name, ticket.User.Email
+	}
+	return ticket.HolderName, ticket.HolderEmail
+}
+
+// Tipos de ticket
+const (
+	TicketSingle = "single" // Uma entrada no evento
+	TicketPass   = "pass"   // Uma entrada por dia do evento de vários dias
+)
 
+// Modelo de Entrada de um ticket em um dia do evento; o índice único impede
+// duas entradas do mesmo passe no mesmo dia
+type TicketCheckIn struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TicketID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_ticket_check_in_day"`
+	Day           time.Time  `gorm:"type:date;not null;uniqueIndex:idx_ticket_check_in_day"` // Dia do evento, no fuso configurado
+	CheckedInByID *uuid.UUID `gorm:"type:uuid"`                                              // Quem validou (organizador ou porteiro)
+	CheckedInBy   *User      `gorm:"foreignKey:CheckedInByID;constraint:OnDelete:SET NULL"`
+	CreatedAt     time.Time  `gorm:"not null"`
+}
+
+// Tipos de pergunta do cadastro dos participantes
+const (
+	QuestionText         = "text"          // Resposta livre
+	QuestionSingleChoice = "single_choice" // Uma das opções
+	QuestionMultiChoice  = "multi_choice"  // Nenhuma, uma ou várias opções
+)
+
+// Modelo de Pergunta do cadastro dos participantes de um evento (ex: tamanho da
+// camiseta), respondida pelo comprador na compra do ticket
+type EventQuestion struct {
+	ID        uuid.UUID             `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	EventID   uuid.UUID             `gorm:"type:uuid;not null;index:idx_event_questions_event_position"`
+	Label     string                `gorm:"not null"`
+	Kind      string                `gorm:"not null;check:kind IN ('text', 'single_choice', 'multi_choice')"`
+	Required  bool                  `gorm:"not null;default:false"`
+	Position  int                   `gorm:"not null;default:0;index:idx_event_questions_event_position"` // Ordem no formulário
+	Options   []EventQuestionOption `gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE"`           // Opções das perguntas de escolha, em ordem
+	CreatedAt time.Time             `gorm:"not null"`
+}
+
+// Modelo de Opção de uma pergunta de escolha
+type EventQuestionOption struct {
+	QuestionID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Position   int       `gorm:"primaryKey"`
+	Value      string    `gorm:"not null"`
+}
+
+// Modelo de Resposta de um ticket a uma pergunta do evento; nas perguntas de
+// múltipla escolha, há uma linha para cada opção marcada
+type TicketAnswer struct {
+	TicketID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	QuestionID uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Value      string    `gorm:"primaryKey"`
+}
 
 // Modelo de Pagamento
 type Payment struct {
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"slices"
+	"src/database"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Participante do evento exibido ao organizador
+type Attendee struct {
+	TicketID       uuid.UUID
+	HolderName     string
+	Email          string
+	Type           string
+	Status         string
+	CheckedInAt    []time.Time // Momentos das entradas (nos passes, uma por dia)
+	OrderReference string      // Transações M-Pesa dos pagamentos confirmados; vazia sem pagamento
+	PurchasedAt    time.Time
+	BuyerEmail     string   // Email da conta que comprou o ticket
+	Answers        []Answer // Respostas às perguntas do evento
+}
+
+// Colunas fixas da exportação da lista de participantes, na ordem de AttendeeRow;
+// depois delas vem uma coluna para cada pergunta do evento
+var AttendeeColumns = []string{
+	"Ticket ID", "Holder name", "Email", "Ticket type", "Status", "Checked in at", "Order reference", "Purchased at", "Buyer email",
+}
+
+// Função para converter o ticket na linha da lista de participantes
+func NewAttendee(ticket database.Ticket, orderReference string) Attendee {
+	attendee := Attendee{
+		TicketID:       ticket.ID,
+		BuyerEmail:     ticket.User.Email,
+		Type:           ticket.Type,
+		Status:         ticket.Status,
+		CheckedInAt:    make([]time.Time, 0, len(ticket.CheckIns)),
+		OrderReference: orderReference,
+		PurchasedAt:    ticket.CreatedAt,
+		Answers:        NewAnswers(ticket.Answers),
+	}
+	attendee.HolderName, attendee.Email = ticket.Holder()
+	for _, checkIn := range ticket.CheckIns {
+		attendee.CheckedInAt = append(attendee.CheckedInAt, checkIn.CreatedAt)
+	}
+	return attendee
+}
+
+// Função para montar o cabeçalho da exportação, com as perguntas do evento
+func AttendeeHeader(questions []database.EventQuestion) []string {
+	header := slices.Clone(AttendeeColumns)
+	for _, question := range questions {
+		header = append(header, question.Label)
+	}
+	return header
+}
+
+// Função para converter o participante nas células da exportação (datas em RFC 3339,
+// UTC, e as opções marcadas nas perguntas de múltipla escolha separadas por vírgula)
+func AttendeeRow(attendee Attendee, questions []database.EventQuestion) []string {
+	checkIns := make([]string, 0, len(attendee.CheckedInAt))
+	for _, moment := range attendee.CheckedInAt {
+		checkIns = append(checkIns, moment.UTC().Format(time.RFC3339))
+	}
+	row := []string{
+		attendee.TicketID.String(),
+		attendee.HolderName,
+		attendee.Email,
+		attendee.Type,
+		attendee.Status,
+		strings.Join(checkIns, ", "),
+		attendee.OrderReference,
+		attendee.PurchasedAt.UTC().Format(time.RFC3339),
+		attendee.BuyerEmail,
+	}
+	for _, question := range questions {
+		row = append(row, strings.Join(answerValues(question, attendee.Answers), ", "))
+	}
+	return row
+}
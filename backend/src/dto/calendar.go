@@ -0,0 +1,7 @@
+package dto
+
+// Endereços da assinatura do calendário do usuário; o token só aparece nesta resposta
+type CalendarFeed struct {
+	URL       string // Endereço HTTP(S) do arquivo .ics
+	WebcalURL string // Mesmo endereço com webcal://, que abre a assinatura no aplicativo de calendário
+}
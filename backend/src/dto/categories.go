@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+)
+
+// Categoria de evento exibida na API
+type Category struct {
+	ID   uuid.UUID
+	Slug string
+	Name string
+}
+
+// Categoria com a quantidade de eventos futuros, para a tela de descoberta
+type CategoryCount struct {
+	ID     uuid.UUID
+	Slug   string
+	Name   string
+	Events int64
+}
+
+// Função para converter o modelo de categoria na resposta da API
+func NewCategory(category database.Category) Category {
+	return Category{ID: category.ID, Slug: category.Slug, Name: category.Name}
+}
+
+// Função para converter uma categoria e a contagem de eventos dela
+func NewCategoryCount(category database.Category, events int64) CategoryCount {
+	return CategoryCount{ID: category.ID, Slug: category.Slug, Name: category.Name, Events: events}
+}
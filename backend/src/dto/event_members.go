@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"src/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Membro da equipe de um evento; o evento só é incluído nos convites do usuário
+type EventMember struct {
+	ID          uuid.UUID
+	EventID     uuid.UUID
+	Event       *Event `json:",omitempty"`
+	Email       string
+	UserID      *uuid.UUID
+	User        *UserSummary `json:",omitempty"`
+	Role        string
+	Status      string
+	InvitedByID uuid.UUID
+	CreatedAt   time.Time
+	AcceptedAt  *time.Time
+}
+
+// Função para converter o modelo de membro na resposta da API
+func NewEventMember(member database.EventMember) EventMember {
+	response := EventMember{
+		ID:          member.ID,
+		EventID:     member.EventID,
+		Email:       member.Email,
+		UserID:      member.UserID,
+		Role:        member.Role,
+		Status:      member.Status,
+		InvitedByID: member.InvitedByID,
+		CreatedAt:   member.CreatedAt,
+		AcceptedAt:  member.AcceptedAt,
+	}
+
+	// Relações só aparecem quando foram carregadas
+	if member.Event.ID != uuid.Nil {
+		event := NewEvent(member.Event)
+		response.Event = &event
+	}
+	if member.User != nil {
+		user := NewUserSummary(*member.User)
+		response.User = &user
+	}
+
+	return response
+}
+
+// Função para converter uma lista de membros (sempre um array, mesmo vazio)
+func NewEventMembers(members []database.EventMember) []EventMember {
+	response := make([]EventMember, 0, len(members))
+	for _, member := range members {
+		response = append(response, NewEventMember(member))
+	}
+	return response
+}
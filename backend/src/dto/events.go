@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"slices"
+	"src/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Evento exibido na API, com o organizador reduzido ao ID e ao nome
+type Event struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Date        time.Time
+	EndDate     *time.Time // null quando o evento dura um dia
+	Location    string
+	OrganizerID uuid.UUID
+	Organizer   UserSummary
+	Category    *Category // null quando o evento não tem categoria
+	Tags        []string
+	Venue       *Venue     // null quando o evento não tem local cadastrado
+	Capacity    int        // 0 quando não há limite de lugares
+	SeriesID    *uuid.UUID // null quando o evento não faz parte de uma série
+	Cover       *Image     // null quando o evento não tem capa
+	Gallery     []Image    // Em ordem de envio
+
+	// Estado atual (já considerando os agendamentos) e agendamentos do ciclo de vida
+	Status       string
+	PublishAt    *time.Time
+	SalesStartAt *time.Time
+	SalesEndAt   *time.Time
+
+	// Tickets nominais: prazo para trocar o titular (horas antes do início e o
+	// momento calculado) e conferência do titular na portaria
+	HolderCutoffHours  int
+	HolderChangesUntil time.Time
+	CheckHolderAtGate  bool
+}
+
+// Função para converter o modelo de evento na resposta da API
+func NewEvent(event database.Event) Event {
+	response := Event{
+		ID:          event.ID,
+		Name:        event.Name,
+		Description: event.Description,
+		Date:        event.Date,
+		EndDate:     event.EndDate,
+		Location:    event.Location,
+		OrganizerID: event.OrganizerID,
+		Organizer:   NewUserSummary(event.Organizer),
+		Tags:        make([]string, 0, len(event.Tags)),
+		Gallery:     []Image{},
+		Capacity:    event.Capacity,
+		SeriesID:    event.SeriesID,
+
+		Status:       event.StatusAt(time.Now()),
+		PublishAt:    event.PublishAt,
+		SalesStartAt: event.SalesStartAt,
+		SalesEndAt:   event.SalesEndAt,
+
+		HolderCutoffHours:  event.HolderCutoffHours,
+		HolderChangesUntil: event.HolderChangesUntil(),
+		CheckHolderAtGate:  event.CheckHolderAtGate,
+	}
+	if event.Category != nil {
+		category := NewCategory(*event.Category)
+		response.Category = &category
+	}
+	if event.Venue != nil {
+		venue := NewVenue(*event.Venue)
+		response.Venue = &venue
+	}
+	for _, tag := range event.Tags {
+		response.Tags = append(response.Tags, tag.Tag)
+	}
+	slices.Sort(response.Tags)
+	for _, image := range event.Images {
+		if image.Kind == database.ImageCover {
+			cover := NewImage(image)
+			response.Cover = &cover
+		} else {
+			response.Gallery = append(response.Gallery, NewImage(image))
+		}
+	}
+	return response
+}
+
+// Função para converter uma lista de eventos (sempre um array, mesmo vazio)
+func NewEvents(events []database.Event) []Event {
+	response := make([]Event, 0, len(events))
+	for _, event := range events {
+		response = append(response, NewEvent(event))
+	}
+	return response
+}
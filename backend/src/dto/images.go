@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+)
+
+// Imagem do evento exibida na API, com os endereços do original e das miniaturas
+type Image struct {
+	ID           uuid.UUID
+	URL          string
+	ThumbnailURL string
+	MediumURL    string
+	Width        int // Dimensões do original
+	Height       int
+}
+
+// Função para converter o modelo de imagem na resposta da API
+func NewImage(image database.EventImage) Image {
+	return Image{
+		ID:           image.ID,
+		URL:          image.URL,
+		ThumbnailURL: image.ThumbnailURL,
+		MediumURL:    image.MediumURL,
+		Width:        image.Width,
+		Height:       image.Height,
+	}
+}
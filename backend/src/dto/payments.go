@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+)
+
+// Pagamento exibido na API, sem os dados do comprador
+type Payment struct {
+	ID                 uuid.UUID
+	TicketID           uuid.UUID
+	UserID             uuid.UUID
+	Amount             float64
+	Status             string
+	MpesaTransactionID *string
+}
+
+// Função para converter o modelo de pagamento na resposta da API
+func NewPayment(payment database.Payment) Payment {
+	return Payment{
+		ID:                 payment.ID,
+		TicketID:           payment.TicketID,
+		UserID:             payment.UserID,
+		Amount:             payment.Amount,
+		Status:             payment.Status,
+		MpesaTransactionID: payment.MpesaTransactionID,
+	}
+}
+
+// Função para converter uma lista de pagamentos (sempre um array, mesmo vazio)
+func NewPayments(payments []database.Payment) []Payment {
+	response := make([]Payment, 0, len(payments))
+	for _, payment := range payments {
+		response = append(response, NewPayment(payment))
+	}
+	return response
+}
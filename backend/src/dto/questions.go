@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"slices"
+	"src/database"
+
+	"github.com/google/uuid"
+)
+
+// Pergunta do cadastro dos participantes exibida na API
+type Question struct {
+	ID       uuid.UUID
+	Label    string
+	Kind     string // text, single_choice ou multi_choice
+	Required bool
+	Position int
+	Options  []string // Sempre um array; vazio nas perguntas livres
+}
+
+// Resposta de um ticket a uma pergunta do evento
+type Answer struct {
+	QuestionID uuid.UUID
+	Values     []string // Um valor nas perguntas livres e de escolha única
+}
+
+// Função para converter o modelo de pergunta na resposta da API
+func NewQuestion(question database.EventQuestion) Question {
+	response := Question{
+		ID:       question.ID,
+		Label:    question.Label,
+		Kind:     question.Kind,
+		Required: question.Required,
+		Position: question.Position,
+		Options:  make([]string, 0, len(question.Options)),
+	}
+	for _, option := range question.Options {
+		response.Options = append(response.Options, option.Value)
+	}
+	return response
+}
+
+// Função para converter uma lista de perguntas (sempre um array, mesmo vazio)
+func NewQuestions(questions []database.EventQuestion) []Question {
+	response := make([]Question, 0, len(questions))
+	for _, question := range questions {
+		response = append(response, NewQuestion(question))
+	}
+	return response
+}
+
+// Função para agrupar as respostas do ticket por pergunta (sempre um array, mesmo vazio)
+func NewAnswers(answers []database.TicketAnswer) []Answer {
+	response := make([]Answer, 0, len(answers))
+	for _, answer := range answers {
+		index := slices.IndexFunc(response, func(existing Answer) bool { return existing.QuestionID == answer.QuestionID })
+		if index < 0 {
+			response = append(response, Answer{QuestionID: answer.QuestionID})
+			index = len(response) - 1
+		}
+		response[index].Values = append(response[index].Values, answer.Value)
+	}
+	return response
+}
+
+// Valores respondidos à pergunta, na ordem das opções (as que saíram da pergunta ficam no fim)
+func answerValues(question database.EventQuestion, answers []Answer) []string {
+	index := slices.IndexFunc(answers, func(answer Answer) bool { return answer.QuestionID == question.ID })
+	if index < 0 {
+		return nil
+	}
+	values := slices.Clone(answers[index].Values)
+	position := func(value string) int {
+		if i := slices.IndexFunc(question.Options, func(option database.EventQuestionOption) bool { return option.Value == value }); i >= 0 {
+			return i
+		}
+		return len(question.Options)
+	}
+	slices.SortStableFunc(values, func(a, b string) int { return position(a) - position(b) })
+	return values
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"html"
+	"src/repository"
+	"strings"
+)
+
+// Marcadores de destaque convertidos em HTML depois de escapar o texto
+var highlightTags = strings.NewReplacer(
+	repository.HighlightStart, "<mark>",
+	repository.HighlightStop, "</mark>",
+)
+
+// Trechos do evento com os termos encontrados entre <mark> e </mark>
+// (o restante do texto é escapado, seguro para exibir como HTML)
+type EventHighlight struct {
+	Name        string
+	Description string
+	Location    string
+}
+
+// Evento encontrado pela busca
+type EventSearchResult struct {
+	Event     Event
+	Relevance float64
+	Highlight EventHighlight
+}
+
+// Quantidade de eventos com um valor
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// Facetas dos resultados da busca
+type EventFacets struct {
+	Months     []FacetCount
+	Categories []FacetCount
+}
+
+// Resposta da busca: a página de resultados e as facetas de todos eles
+type EventSearch struct {
+	Results []EventSearchResult
+	Facets  EventFacets
+}
+
+// Função para converter os resultados da busca na resposta da API
+func NewEventSearch(hits []repository.EventSearchHit, facets repository.EventFacets) EventSearch {
+	response := EventSearch{
+		Results: make([]EventSearchResult, 0, len(hits)),
+		Facets: EventFacets{
+			Months:     newFacetCounts(facets.Months),
+			Categories: newFacetCounts(facets.Categories),
+		},
+	}
+	for _, hit := range hits {
+		response.Results = append(response.Results, EventSearchResult{
+			Event:     NewEvent(hit.Event),
+			Relevance: hit.Rank,
+			Highlight: EventHighlight{
+				Name:        highlightHTML(hit.Highlight.Name),
+				Description: highlightHTML(hit.Highlight.Description),
+				Location:    highlightHTML(hit.Highlight.Location),
+			},
+		})
+	}
+	return response
+}
+
+func newFacetCounts(counts []repository.FacetCount) []FacetCount {
+	response := make([]FacetCount, 0, len(counts))
+	for _, count := range counts {
+		response = append(response, FacetCount{Value: count.Value, Count: count.Count})
+	}
+	return response
+}
+
+// Escapa o trecho e troca os marcadores pelas tags <mark>
+func highlightHTML(text string) string {
+	return highlightTags.Replace(html.EscapeString(text))
+}
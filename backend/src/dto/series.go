@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"src/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Série de eventos recorrentes exibida na API, com as ocorrências em ordem cronológica
+type Series struct {
+	ID          uuid.UUID
+	Name        string
+	Recurrence  string // Vazia quando a série foi criada com datas avulsas
+	OrganizerID uuid.UUID
+	Organizer   UserSummary
+	CreatedAt   time.Time
+	Occurrences []Occurrence
+}
+
+// Ocorrência de uma série, com os tickets vendidos e os lugares restantes
+type Occurrence struct {
+	Event     Event
+	Sold      int64
+	Available *int64 // null quando o evento não tem limite de lugares
+}
+
+// Função para converter uma ocorrência na resposta da API
+func NewOccurrence(event database.Event, sold int64) Occurrence {
+	occurrence := Occurrence{Event: NewEvent(event), Sold: sold}
+	if event.Capacity > 0 {
+		available := max(int64(event.Capacity)-sold, 0)
+		occurrence.Available = &available
+	}
+	return occurrence
+}
+
+// Função para converter a série e as ocorrências na resposta da API
+func NewSeries(series database.EventSeries, occurrences []database.Event, sold map[uuid.UUID]int64) Series {
+	response := Series{
+		ID:          series.ID,
+		Name:        series.Name,
+		Recurrence:  series.Recurrence,
+		OrganizerID: series.OrganizerID,
+		Organizer:   NewUserSummary(series.Organizer),
+		CreatedAt:   series.CreatedAt,
+		Occurrences: make([]Occurrence, 0, len(occurrences)),
+	}
+	for _, event := range occurrences {
+		response.Occurrences = append(response.Occurrences, NewOccurrence(event, sold[event.ID]))
+	}
+	return response
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"maps"
+	"src/database"
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Painel de vendas do evento exibido ao organizador
+type EventStats struct {
+	EventID          uuid.UUID
+	TicketsSold      int64            // Tickets não cancelados de todos os tipos
+	SoldByType       map[string]int64 // single e pass, sempre presentes
+	TicketsCheckedIn int64
+	CheckInRate      float64 // Fração dos vendidos que já entraram (0 a 1)
+	TicketsCancelled int64
+	Refunds          int64   // Tickets cancelados com pagamento confirmado
+	GrossRevenue     float64 // Soma dos pagamentos confirmados
+	RefundedAmount   float64 // Pagamentos dos tickets cancelados
+	NetRevenue       float64 // GrossRevenue - RefundedAmount
+	Capacity         int     // 0 quando não há limite de lugares
+	Remaining        *int64  // null quando o evento não tem limite de lugares
+	Interval         string  // Período dos grupos de Sales (hour, day, week ou month)
+	Sales            []SalesBucket
+}
+
+// Vendas de um período do painel, em ordem cronológica
+type SalesBucket struct {
+	Start   time.Time
+	Tickets int64
+	Revenue float64
+}
+
+// Função para converter os números agregados no painel de vendas da API
+func NewEventStats(event database.Event, tickets repository.TicketStats, interval string, sales []repository.SalesBucket) EventStats {
+	response := EventStats{
+		EventID:          event.ID,
+		SoldByType:       map[string]int64{database.TicketSingle: 0, database.TicketPass: 0},
+		TicketsCheckedIn: tickets.CheckedIn,
+		TicketsCancelled: tickets.Cancelled,
+		Refunds:          tickets.Refunded,
+		GrossRevenue:     tickets.Gross,
+		RefundedAmount:   tickets.Refunds,
+		NetRevenue:       tickets.Gross - tickets.Refunds,
+		Capacity:         event.Capacity,
+		Interval:         interval,
+		Sales:            make([]SalesBucket, 0, len(sales)),
+	}
+	maps.Copy(response.SoldByType, tickets.SoldByType)
+	for _, sold := range tickets.SoldByType {
+		response.TicketsSold += sold
+	}
+	if response.TicketsSold > 0 {
+		response.CheckInRate = float64(tickets.CheckedIn) / float64(response.TicketsSold)
+	}
+	if event.Capacity > 0 {
+		remaining := max(int64(event.Capacity)-response.TicketsSold, 0)
+		response.Remaining = &remaining
+	}
+	for _, bucket := range sales {
+		response.Sales = append(response.Sales, SalesBucket{Start: bucket.Start, Tickets: bucket.Tickets, Revenue: bucket.Revenue})
+	}
+	return response
+}
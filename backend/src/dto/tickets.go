@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"src/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ticket exibido na API, com o evento e o nome do comprador
+type Ticket struct {
+	ID      uuid.UUID
+	EventID uuid.UUID
+	Event   Event
+	UserID  uuid.UUID
+	User    UserSummary
+	Token   string
+	// Titular (quem vai ao evento); sem titular informado, é o próprio comprador
+	HolderName  string
+	HolderEmail string
+	HolderCopy  bool // O ticket aparece também na conta do titular
+	Status      string
+	Type        string   // single ou pass
+	CheckIns    []string // Dias (AAAA-MM-DD) em que o ticket já entrou
+	Answers     []Answer // Respostas às perguntas do evento, dadas na compra
+	CreatedAt   time.Time
+}
+
+// Função para converter o modelo de ticket na resposta da API
+func NewTicket(ticket database.Ticket) Ticket {
+	response := Ticket{
+		ID:         ticket.ID,
+		EventID:    ticket.EventID,
+		Event:      NewEvent(ticket.Event),
+		UserID:     ticket.UserID,
+		User:       NewUserSummary(ticket.User),
+		Token:      ticket.Token,
+		HolderCopy: ticket.HolderCopy,
+		Status:     ticket.Status,
+		Type:       ticket.Type,
+		CheckIns:   make([]string, 0, len(ticket.CheckIns)),
+		Answers:    NewAnswers(ticket.Answers),
+		CreatedAt:  ticket.CreatedAt,
+	}
+	response.HolderName, response.HolderEmail = ticket.Holder()
+	for _, checkIn := range ticket.CheckIns {
+		response.CheckIns = append(response.CheckIns, checkIn.Day.Format(time.DateOnly))
+	}
+	return response
+}
+
+// Função para converter uma lista de tickets (sempre um array, mesmo vazio)
+func NewTickets(tickets []database.Ticket) []Ticket {
+	response := make([]Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		response = append(response, NewTicket(ticket))
+	}
+	return response
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+)
+
+// Dados do usuário autenticado (perfil, registro e login); nunca inclui a senha
+type User struct {
+	ID            uuid.UUID
+	Name          string
+	Email         string
+	Role          string
+	EmailVerified bool // Email confirmado pelo link enviado ou pelo login social
+	TOTPEnabled   bool
+}
+
+// Dados públicos de um usuário exibidos junto de outros recursos (organizador, comprador)
+type UserSummary struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// Função para converter o modelo de usuário na resposta da API
+func NewUser(user database.User) User {
+	return User{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		TOTPEnabled:   user.TOTPEnabled,
+	}
+}
+
+// Função para obter apenas o ID e o nome do usuário
+func NewUserSummary(user database.User) UserSummary {
+	return UserSummary{ID: user.ID, Name: user.Name}
+}
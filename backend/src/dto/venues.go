@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"math"
+	"src/database"
+	"src/repository"
+
+	"github.com/google/uuid"
+)
+
+// Local de eventos exibido na API
+type Venue struct {
+	ID            uuid.UUID
+	Name          string
+	Address       string
+	City          string
+	Latitude      float64
+	Longitude     float64
+	Capacity      int // 0 quando não informada
+	Accessibility string
+	CreatedByID   uuid.UUID
+}
+
+// Evento próximo, com a distância até o local em quilômetros
+type EventDistance struct {
+	Event    Event
+	Distance float64
+}
+
+// Função para converter o modelo de local na resposta da API
+func NewVenue(venue database.Venue) Venue {
+	return Venue{
+		ID:            venue.ID,
+		Name:          venue.Name,
+		Address:       venue.Address,
+		City:          venue.City,
+		Latitude:      venue.Latitude,
+		Longitude:     venue.Longitude,
+		Capacity:      venue.Capacity,
+		Accessibility: venue.Accessibility,
+		CreatedByID:   venue.CreatedByID,
+	}
+}
+
+// Função para converter uma lista de locais (sempre um array, mesmo vazio)
+func NewVenues(venues []database.Venue) []Venue {
+	response := make([]Venue, 0, len(venues))
+	for _, venue := range venues {
+		response = append(response, NewVenue(venue))
+	}
+	return response
+}
+
+// Função para converter os eventos próximos, com a distância arredondada ao metro
+func NewEventDistances(results []repository.EventDistance) []EventDistance {
+	response := make([]EventDistance, 0, len(results))
+	for _, result := range results {
+		response = append(response, EventDistance{
+			Event:    NewEvent(result.Event),
+			Distance: math.Round(result.Distance*1000) / 1000,
+		})
+	}
+	return response
+}
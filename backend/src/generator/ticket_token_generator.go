@@ -1,14 +1,23 @@
 package generator
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
 )
 
-// 🔥 Definição direta da chave secreta para testes
-var jwtSecret = []byte("869687084")
+// Assina e valida os tokens dos tickets com a chave secreta da configuração (TICKET_SECRET)
+type TicketSigner struct {
+	secret []byte
+}
+
+// Função para criar o assinador dos tokens dos tickets
+func NewTicketSigner(secret string) *TicketSigner {
+	return &TicketSigner{secret: []byte(secret)}
+}
 
 // Estrutura do Payload do Token
 type TicketClaims struct {
@@ -16,16 +25,24 @@ type TicketClaims struct {
 	EventID  uuid.UUID `json:"event_id"`
 	UserID   uuid.UUID `json:"user_id"`
 	Status   string    `json:"status"`
+	// Nome do titular, para os leitores exibirem sem consultar o servidor
+	HolderName string `json:"holder_name,omitempty"`
 	jwt.StandardClaims
 }
 
 // Função para gerar o token JWT do ticket
-func GenerateTicketToken(ticketID, eventID, userID uuid.UUID) (string, error) {
+func (s *TicketSigner) GenerateTicketToken(ticketID, eventID, userID uuid.UUID, holderName string) (string, error) {
+	// Nunca assina com a chave vazia, que qualquer um poderia reproduzir
+	if len(s.secret) == 0 {
+		return "", errors.New("ticket secret is not configured")
+	}
+
 	claims := TicketClaims{
-		TicketID: ticketID,
-		EventID:  eventID,
-		UserID:   userID,
-		Status:   "valido",
+		TicketID:   ticketID,
+		EventID:    eventID,
+		UserID:     userID,
+		Status:     "valido",
+		HolderName: holderName,
 		StandardClaims: jwt.StandardClaims{
 			IssuedAt: time.Now().Unix(),
 		},
@@ -34,11 +51,31 @@ func GenerateTicketToken(ticketID, eventID, userID uuid.UUID) (string, error) {
 	// Criando o token JWT
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	// Assinando o token com a chave secreta fixa
-	signedToken, err := token.SignedString(jwtSecret)
+	// Assinando o token com a chave secreta
+	signedToken, err := token.SignedString(s.secret)
 	if err != nil {
 		return "", err
 	}
 
 	return signedToken, nil
 }
+
+// Função para validar a assinatura do token do ticket e extrair o payload
+func (s *TicketSigner) ParseTicketToken(tokenString string) (*TicketClaims, error) {
+	claims := &TicketClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// Verifica se o método de assinatura do token é correto
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("invalid signing method")
+		}
+		return s.secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid ticket token")
+	}
+
+	return claims, nil
+}
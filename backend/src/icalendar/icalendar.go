@@ -0,0 +1,134 @@
+package icalendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Identificador do produto que gera os calendários (PRODID)
+const productID = "-//Ticketing System//Eventos//PT"
+
+// Formato das datas em UTC exigido pelo iCalendar (RFC 5545)
+const dateTimeFormat = "20060102T150405Z"
+
+// Tamanho máximo de uma linha, em bytes; as linhas maiores são dobradas
+const maxLineLength = 75
+
+// Estados de um evento no calendário
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Calendário com os eventos, no formato text/calendar
+type Calendar struct {
+	Name    string        // Nome exibido pelos aplicativos (X-WR-CALNAME); opcional
+	Refresh time.Duration // Intervalo sugerido de atualização das assinaturas; 0 = não informado
+	Events  []Event
+}
+
+// Evento do calendário; o UID se mantém entre as atualizações para o
+// aplicativo substituir o evento em vez de duplicá-lo
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         *time.Time // nil quando o evento não tem hora de fim
+	Summary     string
+	Description string
+	Location    string
+	Latitude    *float64 // Coordenadas do local (GEO); opcionais
+	Longitude   *float64
+	URL         string
+	Status      string
+}
+
+// Função para gerar o calendário no formato iCalendar
+func (c Calendar) Encode(stamp time.Time) []byte {
+	var buffer bytes.Buffer
+	line := func(name, value string) {
+		writeLine(&buffer, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.Refresh > 0 {
+		duration := formatDuration(c.Refresh)
+		writeLine(&buffer, "REFRESH-INTERVAL;VALUE=DURATION:"+duration)
+		line("X-PUBLISHED-TTL", duration)
+	}
+
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", stamp.UTC().Format(dateTimeFormat))
+		line("DTSTART", event.Start.UTC().Format(dateTimeFormat))
+		if event.End != nil {
+			line("DTEND", event.End.UTC().Format(dateTimeFormat))
+		}
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escape(event.Location))
+		}
+		if event.Latitude != nil && event.Longitude != nil {
+			line("GEO", fmt.Sprintf("%.6f;%.6f", *event.Latitude, *event.Longitude))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		if event.Status != "" {
+			line("STATUS", event.Status)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buffer.Bytes()
+}
+
+// Função para escapar um valor de texto (barra invertida, vírgula, ponto e vírgula e quebras de linha)
+func escape(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(value)
+}
+
+// Função para escrever uma linha terminada em CRLF, dobrando-a a cada 75 bytes
+// sem partir caracteres UTF-8 (as continuações começam com um espaço)
+func writeLine(buffer *bytes.Buffer, content string) {
+	limit := maxLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		buffer.WriteString(content[:cut])
+		buffer.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineLength - 1
+	}
+	buffer.WriteString(content)
+	buffer.WriteString("\r\n")
+}
+
+// Função para formatar um intervalo como duração do iCalendar (ex: PT1H, PT30M)
+func formatDuration(d time.Duration) string {
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case minutes == 0:
+		return fmt.Sprintf("PT%dH", hours)
+	case hours == 0:
+		return fmt.Sprintf("PT%dM", minutes)
+	}
+	return fmt.Sprintf("PT%dH%dM", hours, minutes)
+}
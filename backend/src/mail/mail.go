@@ -0,0 +1,102 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"src/config"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Email enviado pela aplicação (hoje, apenas o link de verificação do email)
+type Message struct {
+	To      string
+	Subject string
+	Body    string // Texto simples, em UTF-8
+}
+
+// Envio dos emails; a implementação vem da configuração (SMTP ou log)
+type Sender interface {
+	Send(message Message) error
+}
+
+// Função para criar o envio configurado: SMTP com SMTP_HOST definido, senão
+// apenas registra as mensagens no log (desenvolvimento)
+func New(cfg config.SMTPConfig) Sender {
+	if cfg.Host == "" {
+		return Log{}
+	}
+	return &SMTP{config: cfg}
+}
+
+// Envio por um servidor SMTP, com autenticação PLAIN quando há usuário
+type SMTP struct {
+	config config.SMTPConfig
+}
+
+func (s *SMTP) Send(message Message) error {
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+	address := net.JoinHostPort(s.config.Host, s.config.Port)
+	if err := smtp.SendMail(address, auth, s.config.From, []string{message.To}, encode(s.config.From, message)); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", message.To, err)
+	}
+	return nil
+}
+
+// Monta a mensagem com os cabeçalhos MIME (assunto codificado e corpo em UTF-8)
+func encode(from string, message Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// Sem SMTP configurado, as mensagens (com os links) vão para o log do servidor;
+// serve apenas para o desenvolvimento
+type Log struct{}
+
+func (Log) Send(message Message) error {
+	log.Printf("Email para %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// Guarda as mensagens em memória, para os testes lerem os links enviados
+type Outbox struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (o *Outbox) Send(message Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+// Função para listar as mensagens enviadas para o endereço, da mais antiga à mais recente
+func (o *Outbox) To(address string) []Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var messages []Message
+	for _, message := range o.messages {
+		if strings.EqualFold(message.To, address) {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
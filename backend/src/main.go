@@ -4,31 +4,116 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"src/config"
 	"src/database"
+	"src/mail"
+	"src/repository/postgres"
 	"src/routes"
+	"src/services"
+	"strconv"
+	"time"
+
 	"github.com/rs/cors"
+	"gorm.io/gorm"
 )
 
 func main() {
+	// Carrega e valida a configuração (variáveis de ambiente e .env)
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Inicializa o banco de dados
-	database.InitDB()
+	db, err := database.InitDB(cfg.Database)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Subcomando de migrações: app migrate up|down [n]|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(db, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Recusa iniciar com o esquema do banco desatualizado
+	if err := database.EnsureSchemaUpToDate(db, cfg.MigrateOnStart); err != nil {
+		log.Fatal(err)
+	}
+
+	// Cria os serviços sobre os repositórios do PostgreSQL, com o envio de emails configurado
+	svc := services.New(postgres.New(db), cfg, mail.New(cfg.SMTP))
 
 	// Configura as rotas
-	router := routes.SetupRoutes()
+	router := routes.SetupRoutes(svc)
 
 	// Configuração do CORS
 	corsMiddleware := cors.New(cors.Options{
-		AllowedOrigins: []string{"http://localhost:8081"}, // Permitir requisições do frontend
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"}, // Métodos permitidos
-		AllowedHeaders: []string{"Content-Type", "Authorization"}, // Cabeçalhos permitidos
-		AllowCredentials: true, // Permitir cookies e credenciais
+		AllowedOrigins:   cfg.CORSOrigins,                                        // Permitir requisições do frontend
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},               // Métodos permitidos
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-API-Key"}, // Cabeçalhos permitidos
+		ExposedHeaders:   []string{"X-Total-Count", "X-Next-Cursor"},             // Cabeçalhos da paginação lidos pelo app
+		AllowCredentials: true,                                                   // Permitir cookies e credenciais
 	})
 
 	// Aplica o middleware CORS
 	handler := corsMiddleware.Handler(router)
 
 	// Inicia o servidor
-	port := ":8080"
+	port := ":" + cfg.Port
 	fmt.Printf("Server is running on port %s...\n", port)
 	log.Fatal(http.ListenAndServe(port, handler))
 }
+
+// Executa o subcomando de migrações
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: app migrate up|down [n]|status")
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := database.MigrateUp(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migração(ões) aplicada(s)\n", applied)
+
+	case "down":
+		// Por padrão desfaz apenas a última migração
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+			steps = n
+		}
+		reverted, err := database.MigrateDown(db, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migração(ões) desfeita(s)\n", reverted)
+
+	case "status":
+		status, err := database.GetMigrationStatus(db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range status {
+			applied := "pendente"
+			if migration.AppliedAt != nil {
+				applied = "aplicada em " + migration.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-40s %s\n", migration.Version, migration.Name, applied)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command %q, use up, down or status", args[0])
+	}
+
+	return nil
+}
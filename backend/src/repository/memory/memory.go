@@ -0,0 +1,1476 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"src/database"
+	"src/repository"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Armazenamento em memória compartilhado pelos repositórios, usado em testes
+// e no desenvolvimento sem banco. Os registros são guardados como cópias,
+// sem as relações, que são preenchidas nas leituras.
+type store struct {
+	mu sync.RWMutex
+
+	users         map[uuid.UUID]database.User
+	events        map[uuid.UUID]database.Event
+	tickets       map[uuid.UUID]database.Ticket
+	payments      map[uuid.UUID]database.Payment
+	recoveryCodes map[uuid.UUID]database.RecoveryCode
+	identities    map[uuid.UUID]database.UserIdentity
+	loginAttempts []database.LoginAttempt
+	apiKeys       map[uuid.UUID]database.APIKey
+	eventMembers  map[uuid.UUID]database.EventMember
+	categories    map[uuid.UUID]database.Category
+	venues        map[uuid.UUID]database.Venue
+	series        map[uuid.UUID]database.EventSeries
+	eventImages   map[uuid.UUID]database.EventImage
+	questions     map[uuid.UUID]database.EventQuestion
+}
+
+// Função para criar os repositórios em memória
+func New() repository.Repositories {
+	s := &store{
+		users:         map[uuid.UUID]database.User{},
+		events:        map[uuid.UUID]database.Event{},
+		tickets:       map[uuid.UUID]database.Ticket{},
+		payments:      map[uuid.UUID]database.Payment{},
+		recoveryCodes: map[uuid.UUID]database.RecoveryCode{},
+		identities:    map[uuid.UUID]database.UserIdentity{},
+		apiKeys:       map[uuid.UUID]database.APIKey{},
+		eventMembers:  map[uuid.UUID]database.EventMember{},
+		categories:    map[uuid.UUID]database.Category{},
+		venues:        map[uuid.UUID]database.Venue{},
+		series:        map[uuid.UUID]database.EventSeries{},
+		eventImages:   map[uuid.UUID]database.EventImage{},
+		questions:     map[uuid.UUID]database.EventQuestion{},
+	}
+
+	return repository.Repositories{
+		Users:         &userRepository{s},
+		Events:        &eventRepository{s},
+		Tickets:       &ticketRepository{s},
+		Payments:      &paymentRepository{s},
+		RecoveryCodes: &recoveryCodeRepository{s},
+		Identities:    &identityRepository{s},
+		LoginAttempts: &loginAttemptRepository{s},
+		APIKeys:       &apiKeyRepository{s},
+		EventMembers:  &eventMemberRepository{s},
+		Categories:    &categoryRepository{s},
+		Venues:        &venueRepository{s},
+		Series:        &seriesRepository{s},
+		EventImages:   &eventImageRepository{s},
+		Questions:     &eventQuestionRepository{s},
+	}
+}
+
+// Gera o ID quando não informado, como o default do banco
+func ensureID(id *uuid.UUID) {
+	if *id == uuid.Nil {
+		*id = uuid.New()
+	}
+}
+
+// Preenche a data de criação, como o GORM faz com CreatedAt
+func ensureCreatedAt(createdAt *time.Time) {
+	if createdAt.IsZero() {
+		*createdAt = time.Now()
+	}
+}
+
+// Remove o evento e tudo o que depende dele (ON DELETE CASCADE)
+func (s *store) deleteEventCascade(eventID uuid.UUID) {
+	delete(s.events, eventID)
+	for id, ticket := range s.tickets {
+		if ticket.EventID == eventID {
+			delete(s.tickets, id)
+			for paymentID, payment := range s.payments {
+				if payment.TicketID == id {
+					delete(s.payments, paymentID)
+				}
+			}
+		}
+	}
+	for id, member := range s.eventMembers {
+		if member.EventID == eventID {
+			delete(s.eventMembers, id)
+		}
+	}
+	for id, image := range s.eventImages {
+		if image.EventID == eventID {
+			delete(s.eventImages, id)
+		}
+	}
+	for id, question := range s.questions {
+		if question.EventID == eventID {
+			delete(s.questions, id)
+		}
+	}
+}
+
+func (s *store) event(id uuid.UUID) database.Event {
+	event := s.events[id]
+	event.Organizer = s.users[event.OrganizerID]
+	if event.CategoryID != nil {
+		category := s.categories[*event.CategoryID]
+		event.Category = &category
+	}
+	if event.VenueID != nil {
+		venue := s.venues[*event.VenueID]
+		event.Venue = &venue
+	}
+	event.Tags = slices.Clone(event.Tags)
+	event.Images = []database.EventImage{}
+	for _, image := range s.eventImages {
+		if image.EventID == id {
+			event.Images = append(event.Images, image)
+		}
+	}
+	slices.SortFunc(event.Images, func(a, b database.EventImage) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return event
+}
+
+// Guarda o evento sem o organizador, a categoria e o local; as tags ficam com o evento
+func (s *store) saveEvent(event *database.Event) {
+	for i := range event.Tags {
+		event.Tags[i].EventID = event.ID
+	}
+	stored := *event
+	stored.Organizer, stored.Category, stored.Venue, stored.Images = database.User{}, nil, nil, nil
+	stored.Tags = slices.Clone(event.Tags)
+	s.events[event.ID] = stored
+}
+
+func (s *store) ticket(id uuid.UUID) database.Ticket {
+	ticket := s.tickets[id]
+	ticket.Event = s.event(ticket.EventID)
+	ticket.User = s.users[ticket.UserID]
+	ticket.CheckIns = slices.Clone(ticket.CheckIns)
+	ticket.Answers = slices.Clone(ticket.Answers)
+	return ticket
+}
+
+func (s *store) userEmailTaken(email string, except uuid.UUID) bool {
+	for id, user := range s.users {
+		if id != except && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+type userRepository struct{ s *store }
+
+func (r *userRepository) Create(user *database.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.userEmailTaken(user.Email, uuid.Nil) {
+		return repository.ErrDuplicate
+	}
+	ensureID(&user.ID)
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) FindByID(id uuid.UUID) (*database.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	user, ok := r.s.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*database.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) FindByCalendarToken(tokenHash string) (*database.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.CalendarTokenHash != nil && *user.CalendarTokenHash == tokenHash {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) FindByEmailVerificationToken(tokenHash string) (*database.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.EmailVerificationHash != nil && *user.EmailVerificationHash == tokenHash {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) Update(user *database.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.users[user.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if r.s.userEmailTaken(user.Email, user.ID) {
+		return repository.ErrDuplicate
+	}
+	updated := *user
+	updated.TOTPLastStep = stored.TOTPLastStep
+	r.s.users[user.ID] = updated
+	return nil
+}
+
+func (r *userRepository) AdvanceTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[userID]
+	if !ok || user.TOTPLastStep >= step {
+		return false, nil
+	}
+	user.TOTPLastStep = step
+	r.s.users[userID] = user
+	return true, nil
+}
+
+type eventRepository struct{ s *store }
+
+func (r *eventRepository) Create(event *database.Event) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ensureID(&event.ID)
+	if event.Status == "" {
+		event.Status = database.EventDraft
+	}
+	r.s.saveEvent(event)
+	event.Organizer = r.s.users[event.OrganizerID]
+	return nil
+}
+
+func (r *eventRepository) FindByID(id uuid.UUID) (*database.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	if _, ok := r.s.events[id]; !ok {
+		return nil, repository.ErrNotFound
+	}
+	event := r.s.event(id)
+	return &event, nil
+}
+
+// Verifica se o evento atende aos filtros da listagem
+func (s *store) eventMatches(event database.Event, filter repository.EventFilter) bool {
+	if filter.OrganizerID != nil && event.OrganizerID != *filter.OrganizerID {
+		return false
+	}
+	if filter.Location != "" && !strings.Contains(strings.ToLower(event.Location), strings.ToLower(filter.Location)) {
+		return false
+	}
+	if filter.Category != "" && (event.CategoryID == nil || s.categories[*event.CategoryID].Slug != filter.Category) {
+		return false
+	}
+	if filter.Tag != "" && !slices.ContainsFunc(event.Tags, func(tag database.EventTag) bool { return tag.Tag == filter.Tag }) {
+		return false
+	}
+	if filter.SeriesID != nil && (event.SeriesID == nil || *event.SeriesID != *filter.SeriesID) {
+		return false
+	}
+	if filter.VisibleAt != nil && !event.VisibleAt(*filter.VisibleAt) {
+		return false
+	}
+	return inDateRange(event.Date, filter.DateFrom, filter.DateTo)
+}
+
+// Valor de ordenação do evento, como as colunas do banco
+func eventSortKey(field string) func(database.Event) (any, uuid.UUID) {
+	if field == repository.SortByName {
+		return func(event database.Event) (any, uuid.UUID) { return event.Name, event.ID }
+	}
+	return func(event database.Event) (any, uuid.UUID) { return event.Date, event.ID }
+}
+
+func (r *eventRepository) List(filter repository.EventFilter, page repository.Page) ([]database.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var events []database.Event
+	for id, event := range r.s.events {
+		if r.s.eventMatches(event, filter) {
+			events = append(events, r.s.event(id))
+		}
+	}
+	return paginate(events, page, eventSortKey(page.Sort.Field)), nil
+}
+
+func (r *eventRepository) Count(filter repository.EventFilter) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total int64
+	for _, event := range r.s.events {
+		if r.s.eventMatches(event, filter) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *eventRepository) CountByCategory(filter repository.EventFilter) (map[uuid.UUID]int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	counts := map[uuid.UUID]int64{}
+	for _, event := range r.s.events {
+		if event.CategoryID != nil && r.s.eventMatches(event, filter) {
+			counts[*event.CategoryID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *eventRepository) Update(event *database.Event) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.events[event.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	r.s.saveEvent(event)
+	return nil
+}
+
+func (r *eventRepository) UpdateAll(events []database.Event) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Verifica todos antes de salvar, para não salvar só uma parte
+	for _, event := range events {
+		if _, ok := r.s.events[event.ID]; !ok {
+			return repository.ErrNotFound
+		}
+	}
+	for i := range events {
+		r.s.saveEvent(&events[i])
+	}
+	return nil
+}
+
+func (r *eventRepository) Delete(id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.deleteEventCascade(id)
+	return nil
+}
+
+type ticketRepository struct{ s *store }
+
+func (r *ticketRepository) Create(ticket *database.Ticket) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.createTicket(ticket)
+}
+
+// Guarda um novo ticket; quem chama já tem o lock
+func (s *store) createTicket(ticket *database.Ticket) error {
+	for _, existing := range s.tickets {
+		if existing.Token == ticket.Token {
+			return repository.ErrDuplicate
+		}
+	}
+	ensureID(&ticket.ID)
+	ensureCreatedAt(&ticket.CreatedAt)
+	if ticket.Status == "" {
+		ticket.Status = "valido"
+	}
+	if ticket.Type == "" {
+		ticket.Type = database.TicketSingle
+	}
+	for i := range ticket.Answers {
+		ticket.Answers[i].TicketID = ticket.ID
+	}
+	stored := *ticket
+	stored.Event, stored.User, stored.CheckIns = database.Event{}, database.User{}, nil
+	stored.Answers = slices.Clone(ticket.Answers)
+	slices.SortFunc(stored.Answers, func(a, b database.TicketAnswer) int {
+		if c := strings.Compare(a.QuestionID.String(), b.QuestionID.String()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	s.tickets[ticket.ID] = stored
+	return nil
+}
+
+func (r *ticketRepository) CreateWithinCapacity(ticket *database.Ticket, capacity int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if capacity > 0 {
+		var sold int
+		for _, existing := range r.s.tickets {
+			if existing.EventID == ticket.EventID && existing.Status != "cancelado" {
+				sold++
+			}
+		}
+		if sold >= capacity {
+			return false, nil
+		}
+	}
+	if err := r.s.createTicket(ticket); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ticketRepository) FindByID(id uuid.UUID) (*database.Ticket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	if _, ok := r.s.tickets[id]; !ok {
+		return nil, repository.ErrNotFound
+	}
+	found := r.s.ticket(id)
+	return &found, nil
+}
+
+func (r *ticketRepository) FindByToken(token string) (*database.Ticket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for id, ticket := range r.s.tickets {
+		if ticket.Token == token {
+			found := r.s.ticket(id)
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// Verifica se o ticket atende aos filtros da listagem
+func (s *store) ticketMatches(ticket database.Ticket, filter repository.TicketFilter) bool {
+	if filter.UserID != nil && ticket.UserID != *filter.UserID {
+		return false
+	}
+	if filter.EventID != nil && ticket.EventID != *filter.EventID {
+		return false
+	}
+	if filter.Status != "" && ticket.Status != filter.Status {
+		return false
+	}
+	if filter.Search != "" {
+		buyer, search := s.users[ticket.UserID], strings.ToLower(filter.Search)
+		matches := slices.ContainsFunc([]string{buyer.Name, buyer.Email, ticket.HolderName, ticket.HolderEmail}, func(value string) bool {
+			return strings.Contains(strings.ToLower(value), search)
+		})
+		if !matches {
+			return false
+		}
+	}
+	if filter.SharedWith != "" && (!ticket.HolderCopy || ticket.HolderEmail != filter.SharedWith) {
+		return false
+	}
+	return inDateRange(s.events[ticket.EventID].Date, filter.DateFrom, filter.DateTo)
+}
+
+// Valor de ordenação do ticket, como as colunas do banco
+func ticketSortKey(field string) func(database.Ticket) (any, uuid.UUID) {
+	if field == repository.SortByEventDate {
+		return func(ticket database.Ticket) (any, uuid.UUID) { return ticket.Event.Date, ticket.ID }
+	}
+	return func(ticket database.Ticket) (any, uuid.UUID) { return ticket.CreatedAt, ticket.ID }
+}
+
+func (r *ticketRepository) List(filter repository.TicketFilter, page repository.Page) ([]database.Ticket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var tickets []database.Ticket
+	for id, ticket := range r.s.tickets {
+		if r.s.ticketMatches(ticket, filter) {
+			tickets = append(tickets, r.s.ticket(id))
+		}
+	}
+	return paginate(tickets, page, ticketSortKey(page.Sort.Field)), nil
+}
+
+func (r *ticketRepository) Count(filter repository.TicketFilter) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total int64
+	for _, ticket := range r.s.tickets {
+		if r.s.ticketMatches(ticket, filter) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *ticketRepository) ListByEvent(eventID uuid.UUID) ([]database.Ticket, error) {
+	return r.list(func(ticket database.Ticket) bool { return ticket.EventID == eventID })
+}
+
+func (r *ticketRepository) list(match func(database.Ticket) bool) ([]database.Ticket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var tickets []database.Ticket
+	for id, ticket := range r.s.tickets {
+		if match(ticket) {
+			tickets = append(tickets, r.s.ticket(id))
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].Token < tickets[j].Token })
+	return tickets, nil
+}
+
+func (r *ticketRepository) TransitionStatus(id uuid.UUID, from, to string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ticket, ok := r.s.tickets[id]
+	if !ok || ticket.Status != from {
+		return false, nil
+	}
+	ticket.Status = to
+	r.s.tickets[id] = ticket
+	return true, nil
+}
+
+func (r *ticketRepository) UpdateHolder(ticket *database.Ticket) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.tickets[ticket.ID]
+	if !ok || stored.Status != "valido" || len(stored.CheckIns) > 0 {
+		return false, nil
+	}
+	stored.HolderName, stored.HolderEmail, stored.HolderCopy = ticket.HolderName, ticket.HolderEmail, ticket.HolderCopy
+	stored.Token = ticket.Token
+	r.s.tickets[ticket.ID] = stored
+	return true, nil
+}
+
+func (r *ticketRepository) CountByStatus(eventID uuid.UUID) (map[string]int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	counts := map[string]int64{}
+	for _, ticket := range r.s.tickets {
+		if ticket.EventID == eventID {
+			counts[ticket.Status]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *ticketRepository) CountSoldByEvents(eventIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int64, len(eventIDs))
+	for _, ticket := range r.s.tickets {
+		if ticket.Status != "cancelado" && slices.Contains(eventIDs, ticket.EventID) {
+			counts[ticket.EventID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *ticketRepository) CheckIn(checkIn *database.TicketCheckIn) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ticket, ok := r.s.tickets[checkIn.TicketID]
+	if !ok {
+		return false, repository.ErrNotFound
+	}
+	for _, existing := range ticket.CheckIns {
+		if existing.Day.Equal(checkIn.Day) {
+			return false, nil
+		}
+	}
+
+	ensureID(&checkIn.ID)
+	ensureCreatedAt(&checkIn.CreatedAt)
+	stored := *checkIn
+	stored.CheckedInBy = nil
+	ticket.CheckIns = append(slices.Clone(ticket.CheckIns), stored)
+	slices.SortFunc(ticket.CheckIns, func(a, b database.TicketCheckIn) int { return a.Day.Compare(b.Day) })
+	r.s.tickets[ticket.ID] = ticket
+	return true, nil
+}
+
+// Soma dos pagamentos confirmados de cada ticket
+func (s *store) paidByTicket() map[uuid.UUID]float64 {
+	paid := map[uuid.UUID]float64{}
+	for _, payment := range s.payments {
+		if payment.Status == "pago" {
+			paid[payment.TicketID] += payment.Amount
+		}
+	}
+	return paid
+}
+
+func (r *ticketRepository) Stats(eventID uuid.UUID) (*repository.TicketStats, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	paid := r.s.paidByTicket()
+	stats := &repository.TicketStats{SoldByType: map[string]int64{}}
+	for _, ticket := range r.s.tickets {
+		if ticket.EventID != eventID {
+			continue
+		}
+		amount, hasPayment := paid[ticket.ID]
+		stats.Gross += amount
+		if ticket.Status == "cancelado" {
+			stats.Cancelled++
+			stats.Refunds += amount
+			if hasPayment {
+				stats.Refunded++
+			}
+			continue
+		}
+		stats.SoldByType[ticket.Type]++
+		if ticket.Status == "usado" || len(ticket.CheckIns) > 0 {
+			stats.CheckedIn++
+		}
+	}
+	return stats, nil
+}
+
+func (r *ticketRepository) SalesOverTime(eventID uuid.UUID, bucket string, loc *time.Location) ([]repository.SalesBucket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	paid := r.s.paidByTicket()
+	totals := map[time.Time]*repository.SalesBucket{}
+	for _, ticket := range r.s.tickets {
+		if ticket.EventID != eventID || ticket.Status == "cancelado" {
+			continue
+		}
+		start := truncateBucket(ticket.CreatedAt.In(loc), bucket)
+		total, ok := totals[start]
+		if !ok {
+			total = &repository.SalesBucket{Start: start}
+			totals[start] = total
+		}
+		total.Tickets++
+		total.Revenue += paid[ticket.ID]
+	}
+
+	buckets := make([]repository.SalesBucket, 0, len(totals))
+	for _, total := range totals {
+		buckets = append(buckets, *total)
+	}
+	slices.SortFunc(buckets, func(a, b repository.SalesBucket) int { return a.Start.Compare(b.Start) })
+	return buckets, nil
+}
+
+// Início do período do momento, como o date_trunc do PostgreSQL no fuso do momento
+func truncateBucket(moment time.Time, bucket string) time.Time {
+	year, month, day := moment.Date()
+	loc := moment.Location()
+	switch bucket {
+	case repository.BucketHour:
+		return time.Date(year, month, day, moment.Hour(), 0, 0, 0, loc)
+	case repository.BucketWeek:
+		offset := (int(moment.Weekday()) + 6) % 7 // Dias desde a segunda-feira
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc)
+	case repository.BucketMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+type paymentRepository struct{ s *store }
+
+func (r *paymentRepository) Create(payment *database.Payment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ensureID(&payment.ID)
+	if payment.Status == "" {
+		payment.Status = "pendente"
+	}
+	stored := *payment
+	stored.Ticket, stored.User = database.Ticket{}, database.User{}
+	r.s.payments[payment.ID] = stored
+	return nil
+}
+
+func (r *paymentRepository) FindByID(id uuid.UUID) (*database.Payment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	payment, ok := r.s.payments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepository) ListByTicket(ticketID uuid.UUID) ([]database.Payment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var payments []database.Payment
+	for _, payment := range r.s.payments {
+		if payment.TicketID == ticketID {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
+
+func (r *paymentRepository) Update(payment *database.Payment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.payments[payment.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	stored := *payment
+	stored.Ticket, stored.User = database.Ticket{}, database.User{}
+	r.s.payments[payment.ID] = stored
+	return nil
+}
+
+func (r *paymentRepository) TransactionsByTickets(ticketIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transactions := map[uuid.UUID][]string{}
+	for _, payment := range r.s.payments {
+		if payment.Status == "pago" && payment.MpesaTransactionID != nil && slices.Contains(ticketIDs, payment.TicketID) {
+			transactions[payment.TicketID] = append(transactions[payment.TicketID], *payment.MpesaTransactionID)
+		}
+	}
+
+	references := make(map[uuid.UUID]string, len(transactions))
+	for ticketID, ids := range transactions {
+		slices.Sort(ids)
+		references[ticketID] = strings.Join(ids, ", ")
+	}
+	return references, nil
+}
+
+type recoveryCodeRepository struct{ s *store }
+
+func (r *recoveryCodeRepository) Replace(userID uuid.UUID, codes []database.RecoveryCode) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.deleteRecoveryCodes(userID)
+	for i := range codes {
+		ensureID(&codes[i].ID)
+		stored := codes[i]
+		stored.User = database.User{}
+		r.s.recoveryCodes[stored.ID] = stored
+	}
+	return nil
+}
+
+func (r *recoveryCodeRepository) DeleteByUser(userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.deleteRecoveryCodes(userID)
+	return nil
+}
+
+func (s *store) deleteRecoveryCodes(userID uuid.UUID) {
+	for id, code := range s.recoveryCodes {
+		if code.UserID == userID {
+			delete(s.recoveryCodes, id)
+		}
+	}
+}
+
+func (r *recoveryCodeRepository) MarkUsed(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, code := range r.s.recoveryCodes {
+		if code.UserID == userID && code.CodeHash == codeHash && code.UsedAt == nil {
+			code.UsedAt = &usedAt
+			r.s.recoveryCodes[id] = code
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type identityRepository struct{ s *store }
+
+func (r *identityRepository) FindByProviderSubject(provider, subject string) (*database.UserIdentity, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, identity := range r.s.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			identity.User = r.s.users[identity.UserID]
+			return &identity, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *identityRepository) Link(identity *database.UserIdentity, newUser *database.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.identities {
+		if existing.Provider == identity.Provider && existing.Subject == identity.Subject {
+			return repository.ErrDuplicate
+		}
+	}
+
+	if newUser != nil {
+		if r.s.userEmailTaken(newUser.Email, uuid.Nil) {
+			return repository.ErrDuplicate
+		}
+		ensureID(&newUser.ID)
+		r.s.users[newUser.ID] = *newUser
+		identity.UserID = newUser.ID
+	}
+
+	ensureID(&identity.ID)
+	stored := *identity
+	stored.User = database.User{}
+	r.s.identities[identity.ID] = stored
+	return nil
+}
+
+type loginAttemptRepository struct{ s *store }
+
+func (r *loginAttemptRepository) Create(attempt *database.LoginAttempt) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ensureID(&attempt.ID)
+	ensureCreatedAt(&attempt.CreatedAt)
+	stored := *attempt
+	stored.User = nil
+	r.s.loginAttempts = append(r.s.loginAttempts, stored)
+	return nil
+}
+
+// Verifica se a tentativa atende aos filtros da listagem
+func loginAttemptMatches(attempt database.LoginAttempt, filter repository.LoginAttemptFilter) bool {
+	return (filter.Email == "" || attempt.Email == filter.Email) && (filter.IP == "" || attempt.IP == filter.IP)
+}
+
+func (r *loginAttemptRepository) List(filter repository.LoginAttemptFilter, page repository.Page) ([]database.LoginAttempt, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var attempts []database.LoginAttempt
+	for _, attempt := range r.s.loginAttempts {
+		if loginAttemptMatches(attempt, filter) {
+			attempts = append(attempts, attempt)
+		}
+	}
+	return paginate(attempts, page, func(attempt database.LoginAttempt) (any, uuid.UUID) {
+		return attempt.CreatedAt, attempt.ID
+	}), nil
+}
+
+func (r *loginAttemptRepository) Count(filter repository.LoginAttemptFilter) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total int64
+	for _, attempt := range r.s.loginAttempts {
+		if loginAttemptMatches(attempt, filter) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+type apiKeyRepository struct{ s *store }
+
+func (r *apiKeyRepository) Create(apiKey *database.APIKey) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.apiKeys {
+		if existing.KeyHash == apiKey.KeyHash {
+			return repository.ErrDuplicate
+		}
+	}
+	ensureID(&apiKey.ID)
+	ensureCreatedAt(&apiKey.CreatedAt)
+	stored := *apiKey
+	stored.User = database.User{}
+	r.s.apiKeys[apiKey.ID] = stored
+	return nil
+}
+
+func (r *apiKeyRepository) ListByUser(userID uuid.UUID) ([]database.APIKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var apiKeys []database.APIKey
+	for _, apiKey := range r.s.apiKeys {
+		if apiKey.UserID == userID {
+			apiKeys = append(apiKeys, apiKey)
+		}
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].CreatedAt.After(apiKeys[j].CreatedAt) })
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepository) FindActiveByHash(keyHash string) (*database.APIKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, apiKey := range r.s.apiKeys {
+		if apiKey.KeyHash == keyHash && apiKey.RevokedAt == nil {
+			apiKey.User = r.s.users[apiKey.UserID]
+			return &apiKey, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *apiKeyRepository) Revoke(id, userID uuid.UUID, revokedAt time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	apiKey, ok := r.s.apiKeys[id]
+	if !ok || apiKey.UserID != userID || apiKey.RevokedAt != nil {
+		return false, nil
+	}
+	apiKey.RevokedAt = &revokedAt
+	r.s.apiKeys[id] = apiKey
+	return true, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(id uuid.UUID, usedAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if apiKey, ok := r.s.apiKeys[id]; ok {
+		apiKey.LastUsedAt = &usedAt
+		r.s.apiKeys[id] = apiKey
+	}
+	return nil
+}
+
+type eventMemberRepository struct{ s *store }
+
+func (r *eventMemberRepository) Create(member *database.EventMember) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.eventMembers {
+		if existing.EventID == member.EventID && existing.Email == member.Email {
+			return repository.ErrDuplicate
+		}
+	}
+	ensureID(&member.ID)
+	ensureCreatedAt(&member.CreatedAt)
+	if member.Status == "" {
+		member.Status = "pendente"
+	}
+	r.s.eventMembers[member.ID] = stripMember(*member)
+	return nil
+}
+
+func (r *eventMemberRepository) Update(member *database.EventMember) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.eventMembers[member.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	r.s.eventMembers[member.ID] = stripMember(*member)
+	return nil
+}
+
+func stripMember(member database.EventMember) database.EventMember {
+	member.Event = database.Event{}
+	member.User = nil
+	return member
+}
+
+func (r *eventMemberRepository) FindByEventAndEmail(eventID uuid.UUID, email string) (*database.EventMember, error) {
+	return r.find(func(member database.EventMember) bool {
+		return member.EventID == eventID && member.Email == email
+	}, false)
+}
+
+func (r *eventMemberRepository) FindAccepted(eventID, userID uuid.UUID) (*database.EventMember, error) {
+	return r.find(func(member database.EventMember) bool {
+		return member.EventID == eventID && member.UserID != nil && *member.UserID == userID && member.Status == "aceito"
+	}, false)
+}
+
+func (r *eventMemberRepository) FindPending(id uuid.UUID, email string) (*database.EventMember, error) {
+	return r.find(func(member database.EventMember) bool {
+		return member.ID == id && member.Email == email && member.Status == "pendente"
+	}, true)
+}
+
+func (r *eventMemberRepository) find(match func(database.EventMember) bool, withEvent bool) (*database.EventMember, error) {
+	members := r.list(match, withEvent)
+	if len(members) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &members[0], nil
+}
+
+func (r *eventMemberRepository) ListByEvent(eventID uuid.UUID) ([]database.EventMember, error) {
+	return r.list(func(member database.EventMember) bool { return member.EventID == eventID }, false), nil
+}
+
+func (r *eventMemberRepository) ListPendingByEmail(email string) ([]database.EventMember, error) {
+	return r.list(func(member database.EventMember) bool {
+		return member.Email == email && member.Status == "pendente"
+	}, true), nil
+}
+
+func (r *eventMemberRepository) list(match func(database.EventMember) bool, withEvent bool) []database.EventMember {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var members []database.EventMember
+	for _, member := range r.s.eventMembers {
+		if !match(member) {
+			continue
+		}
+		if withEvent {
+			member.Event = r.s.event(member.EventID)
+		}
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].CreatedAt.Before(members[j].CreatedAt) })
+	return members
+}
+
+func (r *eventMemberRepository) Delete(id, eventID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	member, ok := r.s.eventMembers[id]
+	if !ok || member.EventID != eventID {
+		return false, nil
+	}
+	delete(r.s.eventMembers, id)
+	return true, nil
+}
+
+type categoryRepository struct{ s *store }
+
+func (r *categoryRepository) Create(category *database.Category) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.categorySlugTaken(category.Slug, uuid.Nil) {
+		return repository.ErrDuplicate
+	}
+	ensureID(&category.ID)
+	ensureCreatedAt(&category.CreatedAt)
+	r.s.categories[category.ID] = *category
+	return nil
+}
+
+func (s *store) categorySlugTaken(slug string, except uuid.UUID) bool {
+	for id, category := range s.categories {
+		if id != except && category.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *categoryRepository) FindByID(id uuid.UUID) (*database.Category, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	category, ok := r.s.categories[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) List() ([]database.Category, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var categories []database.Category
+	for _, category := range r.s.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+func (r *categoryRepository) Update(category *database.Category) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.categories[category.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	if r.s.categorySlugTaken(category.Slug, category.ID) {
+		return repository.ErrDuplicate
+	}
+	r.s.categories[category.ID] = *category
+	return nil
+}
+
+func (r *categoryRepository) Delete(id uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.categories[id]; !ok {
+		return false, nil
+	}
+	delete(r.s.categories, id)
+
+	// Os eventos da categoria ficam sem categoria (ON DELETE SET NULL)
+	for eventID, event := range r.s.events {
+		if event.CategoryID != nil && *event.CategoryID == id {
+			event.CategoryID = nil
+			r.s.events[eventID] = event
+		}
+	}
+	return true, nil
+}
+
+type eventImageRepository struct{ s *store }
+
+func (r *eventImageRepository) Create(image *database.EventImage) (*database.EventImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var replaced *database.EventImage
+	if image.Kind == database.ImageCover {
+		for id, existing := range r.s.eventImages {
+			if existing.EventID == image.EventID && existing.Kind == database.ImageCover {
+				delete(r.s.eventImages, id)
+				replaced = &existing
+			}
+		}
+	}
+
+	ensureID(&image.ID)
+	ensureCreatedAt(&image.CreatedAt)
+	r.s.eventImages[image.ID] = *image
+	return replaced, nil
+}
+
+func (r *eventImageRepository) FindByID(id uuid.UUID) (*database.EventImage, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	image, ok := r.s.eventImages[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &image, nil
+}
+
+func (r *eventImageRepository) CountByEvent(eventID uuid.UUID, kind string) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total int64
+	for _, image := range r.s.eventImages {
+		if image.EventID == eventID && image.Kind == kind {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *eventImageRepository) Delete(id uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.eventImages[id]; !ok {
+		return false, nil
+	}
+	delete(r.s.eventImages, id)
+	return true, nil
+}
+
+type eventQuestionRepository struct{ s *store }
+
+// Guarda a pergunta com as opções; quem chama já tem o lock
+func (s *store) saveQuestion(question *database.EventQuestion) {
+	for i := range question.Options {
+		question.Options[i].QuestionID = question.ID
+	}
+	stored := *question
+	stored.Options = slices.Clone(question.Options)
+	s.questions[question.ID] = stored
+}
+
+func (r *eventQuestionRepository) Create(question *database.EventQuestion) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ensureID(&question.ID)
+	ensureCreatedAt(&question.CreatedAt)
+	r.s.saveQuestion(question)
+	return nil
+}
+
+func (r *eventQuestionRepository) FindByID(id uuid.UUID) (*database.EventQuestion, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	question, ok := r.s.questions[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	question.Options = slices.Clone(question.Options)
+	return &question, nil
+}
+
+func (r *eventQuestionRepository) ListByEvent(eventID uuid.UUID) ([]database.EventQuestion, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	questions := []database.EventQuestion{}
+	for _, question := range r.s.questions {
+		if question.EventID == eventID {
+			question.Options = slices.Clone(question.Options)
+			questions = append(questions, question)
+		}
+	}
+	slices.SortFunc(questions, func(a, b database.EventQuestion) int {
+		if a.Position != b.Position {
+			return a.Position - b.Position
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return questions, nil
+}
+
+func (r *eventQuestionRepository) CountByEvent(eventID uuid.UUID) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total int64
+	for _, question := range r.s.questions {
+		if question.EventID == eventID {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *eventQuestionRepository) Update(question *database.EventQuestion) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.questions[question.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	r.s.saveQuestion(question)
+	return nil
+}
+
+func (r *eventQuestionRepository) Delete(id uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.questions[id]; !ok {
+		return false, nil
+	}
+	delete(r.s.questions, id)
+
+	// As respostas à pergunta saem junto (ON DELETE CASCADE)
+	for ticketID, ticket := range r.s.tickets {
+		answers := slices.DeleteFunc(slices.Clone(ticket.Answers), func(answer database.TicketAnswer) bool {
+			return answer.QuestionID == id
+		})
+		if len(answers) != len(ticket.Answers) {
+			ticket.Answers = answers
+			r.s.tickets[ticketID] = ticket
+		}
+	}
+	return true, nil
+}
+
+type venueRepository struct{ s *store }
+
+func (r *venueRepository) Create(venue *database.Venue) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ensureID(&venue.ID)
+	ensureCreatedAt(&venue.CreatedAt)
+	stored := *venue
+	stored.CreatedBy = database.User{}
+	r.s.venues[venue.ID] = stored
+	return nil
+}
+
+func (r *venueRepository) FindByID(id uuid.UUID) (*database.Venue, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	venue, ok := r.s.venues[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &venue, nil
+}
+
+// Verifica se o local atende aos filtros da listagem
+func venueMatches(venue database.Venue, filter repository.VenueFilter) bool {
+	return filter.City == "" || strings.EqualFold(venue.City, filter.City)
+}
+
+func (r *venueRepository) List(filter repository.VenueFilter, page repository.Page) ([]database.Venue, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var venues []database.Venue
+	for _, venue := range r.s.venues {
+		if venueMatches(venue, filter) {
+			venues = append(venues, venue)
+		}
+	}
+	return paginate(venues, page, func(venue database.Venue) (any, uuid.UUID) { return venue.Name, venue.ID }), nil
+}
+
+func (r *venueRepository) Count(filter repository.VenueFilter) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total int64
+	for _, venue := range r.s.venues {
+		if venueMatches(venue, filter) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *venueRepository) Update(venue *database.Venue) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.venues[venue.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	stored := *venue
+	stored.CreatedBy = database.User{}
+	r.s.venues[venue.ID] = stored
+	return nil
+}
+
+func (r *venueRepository) Delete(id uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.venues[id]; !ok {
+		return false, nil
+	}
+	delete(r.s.venues, id)
+
+	// Os eventos do local ficam sem local (ON DELETE SET NULL)
+	for eventID, event := range r.s.events {
+		if event.VenueID != nil && *event.VenueID == id {
+			event.VenueID = nil
+			r.s.events[eventID] = event
+		}
+	}
+	return true, nil
+}
+
+type seriesRepository struct{ s *store }
+
+func (r *seriesRepository) Create(series *database.EventSeries, occurrences []database.Event) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ensureID(&series.ID)
+	ensureCreatedAt(&series.CreatedAt)
+	stored := *series
+	stored.Organizer = database.User{}
+	r.s.series[series.ID] = stored
+
+	for i := range occurrences {
+		occurrences[i].SeriesID = &series.ID
+		ensureID(&occurrences[i].ID)
+		if occurrences[i].Status == "" {
+			occurrences[i].Status = database.EventDraft
+		}
+		r.s.saveEvent(&occurrences[i])
+	}
+	return nil
+}
+
+func (r *seriesRepository) FindByID(id uuid.UUID) (*database.EventSeries, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	series, ok := r.s.series[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	series.Organizer = r.s.users[series.OrganizerID]
+	return &series, nil
+}
+
+func (r *seriesRepository) Delete(id uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.series[id]; !ok {
+		return false, nil
+	}
+	delete(r.s.series, id)
+
+	// As ocorrências são removidas junto com a série (ON DELETE CASCADE)
+	for eventID, event := range r.s.events {
+		if event.SeriesID != nil && *event.SeriesID == id {
+			r.s.deleteEventCascade(eventID)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"math"
+	"src/repository"
+
+	"github.com/google/uuid"
+)
+
+// Distância em quilômetros entre dois pontos, pela fórmula de haversine
+// (a mesma usada na consulta do banco)
+func distanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	radians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	h := math.Pow(math.Sin(radians(lat2-lat1)/2), 2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(radians(lng2-lng1)/2), 2)
+	return repository.EarthRadiusKm * 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Eventos que atendem aos filtros, em locais dentro do raio, com a distância
+func (s *store) nearby(search repository.NearbySearch) []repository.EventDistance {
+	var results []repository.EventDistance
+	for id, event := range s.events {
+		if event.VenueID == nil || !s.eventMatches(event, search.Filter) {
+			continue
+		}
+		venue := s.venues[*event.VenueID]
+		distance := distanceKm(search.Latitude, search.Longitude, venue.Latitude, venue.Longitude)
+		if distance <= search.RadiusKm {
+			results = append(results, repository.EventDistance{Event: s.event(id), Distance: distance})
+		}
+	}
+	return results
+}
+
+func (r *eventRepository) Nearby(search repository.NearbySearch, page repository.Page) ([]repository.EventDistance, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	key := func(result repository.EventDistance) (any, uuid.UUID) { return result.Distance, result.Event.ID }
+	if page.Sort.Field == repository.SortByDate {
+		key = func(result repository.EventDistance) (any, uuid.UUID) { return result.Event.Date, result.Event.ID }
+	}
+	return paginate(r.s.nearby(search), page, key), nil
+}
+
+func (r *eventRepository) CountNearby(search repository.NearbySearch) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return int64(len(r.s.nearby(search))), nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"src/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ordena os registros e aplica o cursor e o limite da página, com o mesmo
+// resultado do ORDER BY (coluna, id) e da comparação de keyset no banco
+func paginate[T any](items []T, page repository.Page, key func(T) (any, uuid.UUID)) []T {
+	compare := func(value any, id uuid.UUID, otherValue any, otherID uuid.UUID) int {
+		c := compareValues(value, otherValue)
+		if c == 0 {
+			c = bytes.Compare(id[:], otherID[:])
+		}
+		if page.Sort.Desc {
+			c = -c
+		}
+		return c
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		valueA, idA := key(a)
+		valueB, idB := key(b)
+		return compare(valueA, idA, valueB, idB)
+	})
+
+	if page.After != nil {
+		start := len(items)
+		for i, item := range items {
+			value, id := key(item)
+			if compare(value, id, page.After.Value, page.After.ID) > 0 {
+				start = i
+				break
+			}
+		}
+		items = items[start:]
+	}
+
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
+// Compara os valores de ordenação suportados (datas, textos e números)
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case time.Time:
+		b, _ := b.(time.Time)
+		return a.Compare(b)
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(a, b)
+	case float64:
+		b, _ := b.(float64)
+		return cmp.Compare(a, b)
+	default:
+		return 0
+	}
+}
+
+// Verifica se a data está no intervalo [from, to) dos filtros
+func inDateRange(date time.Time, from, to *time.Time) bool {
+	if from != nil && date.Before(*from) {
+		return false
+	}
+	if to != nil && !date.Before(*to) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,221 @@
+package memory
+
+import (
+	"slices"
+	"src/database"
+	"src/repository"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Pesos de cada campo na relevância, como os pesos A, B e C do search_vector
+const (
+	nameWeight        = 1.0
+	locationWeight    = 0.4
+	descriptionWeight = 0.2
+)
+
+// Letras acentuadas do português e as letras sem acento
+var unaccent = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e",
+	"í", "i", "ì", "i", "î", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c",
+)
+
+// Normaliza uma palavra para comparação: minúsculas e sem acentos
+func normalizeWord(word string) string {
+	return unaccent.Replace(strings.ToLower(word))
+}
+
+// Divide o texto em palavras (letras e números)
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// Verifica se a palavra do texto corresponde ao termo buscado. Aproxima o
+// stemming pelo prefixo e tolera um erro de digitação nos termos mais longos.
+func wordMatches(term, word string) bool {
+	word = normalizeWord(word)
+	switch {
+	case word == term:
+		return true
+	case len(term) >= 3 && strings.HasPrefix(word, term):
+		return true
+	case len(term) >= 4 && len(word) >= 4 && strings.HasPrefix(term, word[:len(word)-1]) && len(term)-len(word) <= 2:
+		return true
+	case len(term) >= 4:
+		return editDistance(term, word) <= 1
+	default:
+		return false
+	}
+}
+
+// Distância de edição (Levenshtein) entre duas palavras
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+// Verifica se algum termo aparece no texto
+func textMatches(term, text string) bool {
+	for _, word := range splitWords(text) {
+		if wordMatches(term, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Relevância do evento para a busca; zero quando algum termo não aparece
+func searchRank(event database.Event, terms []string) float64 {
+	rank := 0.0
+	for _, term := range terms {
+		termRank := 0.0
+		if textMatches(term, event.Name) {
+			termRank += nameWeight
+		}
+		if textMatches(term, event.Location) {
+			termRank += locationWeight
+		}
+		if textMatches(term, event.Description) {
+			termRank += descriptionWeight
+		}
+		if termRank == 0 {
+			return 0
+		}
+		rank += termRank
+	}
+	return rank
+}
+
+// Envolve as palavras encontradas com os marcadores de destaque
+func highlight(text string, terms []string) string {
+	var result strings.Builder
+	start := 0
+	inWord := false
+	flush := func(end int) {
+		word := text[start:end]
+		for _, term := range terms {
+			if wordMatches(term, word) {
+				result.WriteString(repository.HighlightStart + word + repository.HighlightStop)
+				return
+			}
+		}
+		result.WriteString(word)
+	}
+
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && !inWord:
+			start, inWord = i, true
+		case !isWordRune && inWord:
+			flush(i)
+			inWord = false
+		}
+		if !isWordRune {
+			result.WriteRune(r)
+		}
+	}
+	if inWord {
+		flush(len(text))
+	}
+	return result.String()
+}
+
+// Termos normalizados da busca
+func searchTerms(query string) []string {
+	words := splitWords(query)
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, normalizeWord(word))
+	}
+	return terms
+}
+
+// Eventos que atendem aos filtros e à busca, com a relevância de cada um
+func (s *store) searchHits(search repository.EventSearch) []repository.EventSearchHit {
+	terms := searchTerms(search.Query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var hits []repository.EventSearchHit
+	for id, event := range s.events {
+		if !s.eventMatches(event, search.Filter) {
+			continue
+		}
+		if rank := searchRank(event, terms); rank > 0 {
+			hits = append(hits, repository.EventSearchHit{Event: s.event(id), Rank: rank})
+		}
+	}
+	return hits
+}
+
+func (r *eventRepository) Search(search repository.EventSearch, page repository.Page) ([]repository.EventSearchHit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	key := func(hit repository.EventSearchHit) (any, uuid.UUID) { return hit.Rank, hit.Event.ID }
+	if page.Sort.Field == repository.SortByDate {
+		key = func(hit repository.EventSearchHit) (any, uuid.UUID) { return hit.Event.Date, hit.Event.ID }
+	}
+	hits := paginate(r.s.searchHits(search), page, key)
+
+	// Os trechos destacados só são calculados para as linhas da página
+	terms := searchTerms(search.Query)
+	for i, hit := range hits {
+		hits[i].Highlight = repository.EventHighlight{
+			Name:        highlight(hit.Event.Name, terms),
+			Description: highlight(hit.Event.Description, terms),
+			Location:    highlight(hit.Event.Location, terms),
+		}
+	}
+	return hits, nil
+}
+
+func (r *eventRepository) SearchFacets(search repository.EventSearch, loc *time.Location) (*repository.EventFacets, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	months, categories := map[string]int64{}, map[string]int64{}
+	for _, hit := range r.s.searchHits(search) {
+		months[hit.Event.Date.In(loc).Format("2006-01")]++
+		if hit.Event.Category != nil {
+			categories[hit.Event.Category.Slug]++
+		}
+	}
+	return &repository.EventFacets{Months: sortedFacets(months), Categories: sortedFacets(categories)}, nil
+}
+
+// Converte as contagens em facetas ordenadas pelo valor
+func sortedFacets(counts map[string]int64) []repository.FacetCount {
+	facets := make([]repository.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, repository.FacetCount{Value: value, Count: count})
+	}
+	slices.SortFunc(facets, func(a, b repository.FacetCount) int { return strings.Compare(a.Value, b.Value) })
+	return facets
+}
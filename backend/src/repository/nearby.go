@@ -0,0 +1,23 @@
+package repository
+
+import "src/database"
+
+// Ordenação pela distância até o ponto buscado
+const SortByDistance = "distance"
+
+// Raio médio da Terra, em quilômetros, usado no cálculo das distâncias
+const EarthRadiusKm = 6371.0
+
+// Busca de eventos pela proximidade do local, combinada com os filtros da listagem
+type NearbySearch struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	Filter    EventFilter
+}
+
+// Evento encontrado pela proximidade, com a distância até o local em quilômetros
+type EventDistance struct {
+	Event    database.Event
+	Distance float64
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Campos pelos quais as listagens podem ser ordenadas
+const (
+	SortByDate      = "date"       // Data do evento
+	SortByName      = "name"       // Nome do evento
+	SortByCreatedAt = "created_at" // Data de criação do registro
+	SortByEventDate = "event_date" // Data do evento do ticket
+)
+
+// Ordenação de uma listagem; o ID desempata registros com o mesmo valor
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Posição na listagem: a página começa depois deste registro
+type Cursor struct {
+	Value any // Valor do campo de ordenação no último registro (time.Time, string ou float64)
+	ID    uuid.UUID
+}
+
+// Página pedida a uma listagem (paginação por cursor, sem OFFSET)
+type Page struct {
+	Limit int // 0 = sem limite
+	Sort  Sort
+	After *Cursor
+}
+
+// Filtros da listagem de eventos
+type EventFilter struct {
+	OrganizerID *uuid.UUID
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	Location    string // Parte do local, sem diferenciar maiúsculas e minúsculas
+	Category    string // Slug da categoria
+	Tag         string
+	VisibleAt   *time.Time // Apenas os eventos visíveis aos compradores nesse momento
+	SeriesID    *uuid.UUID // Apenas as ocorrências da série
+}
+
+// Filtros da listagem de locais
+type VenueFilter struct {
+	City string // Cidade, sem diferenciar maiúsculas e minúsculas
+}
+
+// Filtros da listagem de tickets
+type TicketFilter struct {
+	UserID   *uuid.UUID
+	EventID  *uuid.UUID
+	Status   string
+	DateFrom *time.Time // Data do evento
+	DateTo   *time.Time
+	Search   string // Parte do nome ou do email do comprador ou do titular, sem diferenciar maiúsculas e minúsculas
+	// Tickets com cópia para o titular com este email (os tickets compartilhados com o usuário)
+	SharedWith string
+}
+
+// Filtros da listagem de tentativas de login
+type LoginAttemptFilter struct {
+	Email string
+	IP    string
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func (r *categoryRepository) Create(category *database.Category) error {
+	return translate(r.db.Create(category).Error)
+}
+
+func (r *categoryRepository) FindByID(id uuid.UUID) (*database.Category, error) {
+	var category database.Category
+	if err := r.db.First(&category, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) List() ([]database.Category, error) {
+	var categories []database.Category
+	if err := r.db.Order("name").Find(&categories).Error; err != nil {
+		return nil, translate(err)
+	}
+	return categories, nil
+}
+
+func (r *categoryRepository) Update(category *database.Category) error {
+	return translate(r.db.Save(category).Error)
+}
+
+func (r *categoryRepository) Delete(id uuid.UUID) (bool, error) {
+	result := r.db.Where("id = ?", id).Delete(&database.Category{})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
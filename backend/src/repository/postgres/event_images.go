@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"errors"
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type eventImageRepository struct {
+	db *gorm.DB
+}
+
+func (r *eventImageRepository) Create(image *database.EventImage) (*database.EventImage, error) {
+	var replaced *database.EventImage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if image.Kind == database.ImageCover {
+			// Bloqueia a linha do evento para serializar as trocas de capa concorrentes
+			if err := tx.Exec("SELECT 1 FROM events WHERE id = ? FOR UPDATE", image.EventID).Error; err != nil {
+				return err
+			}
+
+			// Remove a capa anterior antes de criar a nova (índice único por evento)
+			var previous database.EventImage
+			err := tx.Where("event_id = ? AND kind = ?", image.EventID, database.ImageCover).First(&previous).Error
+			switch {
+			case err == nil:
+				if err := tx.Delete(&previous).Error; err != nil {
+					return err
+				}
+				replaced = &previous
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			}
+		}
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	return replaced, nil
+}
+
+func (r *eventImageRepository) FindByID(id uuid.UUID) (*database.EventImage, error) {
+	var image database.EventImage
+	if err := r.db.First(&image, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &image, nil
+}
+
+func (r *eventImageRepository) CountByEvent(eventID uuid.UUID, kind string) (int64, error) {
+	var total int64
+	err := r.db.Model(&database.EventImage{}).
+		Where("event_id = ? AND kind = ?", eventID, kind).
+		Count(&total).Error
+	if err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
+
+func (r *eventImageRepository) Delete(id uuid.UUID) (bool, error) {
+	result := r.db.Where("id = ?", id).Delete(&database.EventImage{})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
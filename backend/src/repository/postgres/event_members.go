@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type eventMemberRepository struct {
+	db *gorm.DB
+}
+
+func (r *eventMemberRepository) Create(member *database.EventMember) error {
+	return translate(r.db.Omit("Event", "User").Create(member).Error)
+}
+
+func (r *eventMemberRepository) Update(member *database.EventMember) error {
+	return translate(r.db.Omit("Event", "User").Save(member).Error)
+}
+
+func (r *eventMemberRepository) FindByEventAndEmail(eventID uuid.UUID, email string) (*database.EventMember, error) {
+	var member database.EventMember
+	if err := r.db.Where("event_id = ? AND email = ?", eventID, email).First(&member).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &member, nil
+}
+
+func (r *eventMemberRepository) FindAccepted(eventID, userID uuid.UUID) (*database.EventMember, error) {
+	var member database.EventMember
+	err := r.db.Where("event_id = ? AND user_id = ? AND status = ?", eventID, userID, "aceito").First(&member).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &member, nil
+}
+
+func (r *eventMemberRepository) FindPending(id uuid.UUID, email string) (*database.EventMember, error) {
+	var member database.EventMember
+	err := preloadEvent(r.db, "Event").Where("id = ? AND email = ? AND status = ?", id, email, "pendente").First(&member).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &member, nil
+}
+
+func (r *eventMemberRepository) ListByEvent(eventID uuid.UUID) ([]database.EventMember, error) {
+	var members []database.EventMember
+	if err := r.db.Where("event_id = ?", eventID).Order("created_at").Find(&members).Error; err != nil {
+		return nil, translate(err)
+	}
+	return members, nil
+}
+
+func (r *eventMemberRepository) ListPendingByEmail(email string) ([]database.EventMember, error) {
+	var members []database.EventMember
+	err := preloadEvent(r.db, "Event").Where("email = ? AND status = ?", email, "pendente").Find(&members).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return members, nil
+}
+
+func (r *eventMemberRepository) Delete(id, eventID uuid.UUID) (bool, error) {
+	result := r.db.Where("id = ? AND event_id = ?", id, eventID).Delete(&database.EventMember{})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type eventQuestionRepository struct {
+	db *gorm.DB
+}
+
+// Carrega as opções na ordem definida pelo organizador
+func preloadQuestionOptions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("event_question_options.position")
+	})
+}
+
+func (r *eventQuestionRepository) Create(question *database.EventQuestion) error {
+	// As opções são criadas junto com a pergunta
+	return translate(r.db.Create(question).Error)
+}
+
+func (r *eventQuestionRepository) FindByID(id uuid.UUID) (*database.EventQuestion, error) {
+	var question database.EventQuestion
+	if err := preloadQuestionOptions(r.db).First(&question, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &question, nil
+}
+
+func (r *eventQuestionRepository) ListByEvent(eventID uuid.UUID) ([]database.EventQuestion, error) {
+	var questions []database.EventQuestion
+	err := preloadQuestionOptions(r.db).
+		Where("event_id = ?", eventID).
+		Order("position, created_at, id").
+		Find(&questions).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return questions, nil
+}
+
+func (r *eventQuestionRepository) CountByEvent(eventID uuid.UUID) (int64, error) {
+	var total int64
+	if err := r.db.Model(&database.EventQuestion{}).Where("event_id = ?", eventID).Count(&total).Error; err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
+
+func (r *eventQuestionRepository) Update(question *database.EventQuestion) error {
+	return translate(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Options").Save(question).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("question_id = ?", question.ID).Delete(&database.EventQuestionOption{}).Error; err != nil {
+			return err
+		}
+		for i := range question.Options {
+			question.Options[i].QuestionID = question.ID
+		}
+		if len(question.Options) == 0 {
+			return nil
+		}
+		return tx.Create(&question.Options).Error
+	}))
+}
+
+func (r *eventQuestionRepository) Delete(id uuid.UUID) (bool, error) {
+	result := r.db.Where("id = ?", id).Delete(&database.EventQuestion{})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
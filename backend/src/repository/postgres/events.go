@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"src/database"
+	"src/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type eventRepository struct {
+	db *gorm.DB
+}
+
+// Carrega o organizador, a categoria, as tags, o local e as imagens
+func (r *eventRepository) withRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Organizer").Preload("Category").Preload("Tags").Preload("Venue").Preload("Images", orderImages)
+}
+
+// Carrega o evento de outro registro (ex: "Event" do ticket) com as relações dele
+func preloadEvent(db *gorm.DB, association string) *gorm.DB {
+	return db.Preload(association).
+		Preload(association+".Organizer").
+		Preload(association+".Category").
+		Preload(association+".Tags").
+		Preload(association+".Venue").
+		Preload(association+".Images", orderImages)
+}
+
+// Ordena as imagens do evento pela ordem de envio
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("event_images.created_at, event_images.id")
+}
+
+func (r *eventRepository) Create(event *database.Event) error {
+	// As tags são criadas junto com o evento
+	return translate(r.db.Omit("Organizer", "Category", "Venue").Create(event).Error)
+}
+
+func (r *eventRepository) FindByID(id uuid.UUID) (*database.Event, error) {
+	var event database.Event
+	if err := r.withRelations(r.db).First(&event, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &event, nil
+}
+
+// Colunas pelas quais os eventos podem ser ordenados
+var eventSortColumns = map[string]string{
+	repository.SortByDate: "events.date",
+	repository.SortByName: "events.name",
+}
+
+// Monta a consulta com os filtros da listagem
+func (r *eventRepository) filtered(filter repository.EventFilter) *gorm.DB {
+	query := r.db.Model(&database.Event{})
+	if filter.OrganizerID != nil {
+		query = query.Where("events.organizer_id = ?", *filter.OrganizerID)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("events.date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("events.date < ?", *filter.DateTo)
+	}
+	if filter.Location != "" {
+		query = query.Where("events.location ILIKE ?", likePattern(filter.Location))
+	}
+	if filter.Category != "" {
+		query = query.Where("events.category_id IN (SELECT id FROM categories WHERE slug = ?)", filter.Category)
+	}
+	if filter.Tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM event_tags WHERE event_tags.event_id = events.id AND event_tags.tag = ?)", filter.Tag)
+	}
+	if filter.SeriesID != nil {
+		query = query.Where("events.series_id = ?", *filter.SeriesID)
+	}
+	if filter.VisibleAt != nil {
+		// Mesma regra de Event.VisibleAt: publicado ou rascunho com a publicação agendada já passada
+		query = query.Where("(events.status IN ? OR (events.status = ? AND events.publish_at <= ?))",
+			database.PublicEventStatuses, database.EventDraft, *filter.VisibleAt)
+	}
+	return query
+}
+
+func (r *eventRepository) List(filter repository.EventFilter, page repository.Page) ([]database.Event, error) {
+	column, ok := eventSortColumns[page.Sort.Field]
+	if !ok {
+		column = eventSortColumns[repository.SortByDate]
+	}
+
+	var events []database.Event
+	query := paginate(r.withRelations(r.filtered(filter)), page, column, "events.id")
+	if err := query.Find(&events).Error; err != nil {
+		return nil, translate(err)
+	}
+	return events, nil
+}
+
+func (r *eventRepository) Count(filter repository.EventFilter) (int64, error) {
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
+
+func (r *eventRepository) CountByCategory(filter repository.EventFilter) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		CategoryID uuid.UUID
+		Total      int64
+	}
+	err := r.filtered(filter).
+		Select("events.category_id, COUNT(*) AS total").
+		Where("events.category_id IS NOT NULL").
+		Group("events.category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Total
+	}
+	return counts, nil
+}
+
+func (r *eventRepository) Update(event *database.Event) error {
+	return translate(r.db.Transaction(func(tx *gorm.DB) error {
+		return saveEvent(tx, event)
+	}))
+}
+
+func (r *eventRepository) UpdateAll(events []database.Event) error {
+	return translate(r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range events {
+			if err := saveEvent(tx, &events[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Salva o evento e substitui as tags dele, dentro da transação
+func saveEvent(tx *gorm.DB, event *database.Event) error {
+	if err := tx.Omit("Organizer", "Category", "Tags", "Venue", "Images").Save(event).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("event_id = ?", event.ID).Delete(&database.EventTag{}).Error; err != nil {
+		return err
+	}
+	for i := range event.Tags {
+		event.Tags[i].EventID = event.ID
+	}
+	if len(event.Tags) == 0 {
+		return nil
+	}
+	return tx.Create(&event.Tags).Error
+}
+
+func (r *eventRepository) Delete(id uuid.UUID) error {
+	return translate(r.db.Delete(&database.Event{}, "id = ?", id).Error)
+}
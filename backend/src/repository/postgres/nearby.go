@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"math"
+	"src/database"
+	"src/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Distância em quilômetros entre o local do evento e o ponto buscado, pela
+// fórmula de haversine (o least evita erros de arredondamento fora do asin).
+// As coordenadas ficam entre parênteses porque podem ser negativas.
+const distanceSQL = "(?) * 2 * asin(least(1, sqrt(" +
+	"power(sin(radians(venues.latitude - (?)) / 2), 2) + " +
+	"cos(radians(?)) * cos(radians(venues.latitude)) * power(sin(radians(venues.longitude - (?)) / 2), 2))))"
+
+// Colunas pelas quais os eventos próximos podem ser ordenados
+var nearbySortColumns = map[string]string{
+	repository.SortByDistance: "nearby.distance",
+	repository.SortByDate:     "nearby.date",
+}
+
+// Linha do resultado da busca por proximidade: o evento e a distância
+type nearbyRow struct {
+	database.Event
+	Distance float64
+}
+
+// Eventos que atendem aos filtros, em locais dentro do raio. A caixa delimitadora
+// usa o índice das coordenadas para descartar os locais distantes antes de
+// calcular a distância exata.
+func (r *eventRepository) nearby(search repository.NearbySearch) *gorm.DB {
+	query := r.filtered(search.Filter).Joins("JOIN venues ON venues.id = events.venue_id")
+
+	// Graus de latitude (e de longitude, na latitude buscada) que cabem no raio
+	angle := search.RadiusKm / repository.EarthRadiusKm
+	latDelta := angle * 180 / math.Pi
+	query = query.Where("venues.latitude BETWEEN ? AND ?", search.Latitude-latDelta, search.Latitude+latDelta)
+
+	// Perto dos polos ou da linha de data a caixa dá a volta; fica só a latitude
+	if math.Abs(search.Latitude)+latDelta < 90 {
+		lngDelta := math.Asin(math.Sin(angle)/math.Cos(search.Latitude*math.Pi/180)) * 180 / math.Pi
+		if search.Longitude-lngDelta >= -180 && search.Longitude+lngDelta <= 180 {
+			query = query.Where("venues.longitude BETWEEN ? AND ?", search.Longitude-lngDelta, search.Longitude+lngDelta)
+		}
+	}
+
+	query = query.Select("events.*, "+distanceSQL+" AS distance",
+		repository.EarthRadiusKm, search.Latitude, search.Latitude, search.Longitude)
+	return r.db.Table("(?) AS nearby", query).Where("nearby.distance <= ?", search.RadiusKm)
+}
+
+func (r *eventRepository) Nearby(search repository.NearbySearch, page repository.Page) ([]repository.EventDistance, error) {
+	column, ok := nearbySortColumns[page.Sort.Field]
+	if !ok {
+		column = nearbySortColumns[repository.SortByDistance]
+	}
+
+	var rows []nearbyRow
+	if err := paginate(r.nearby(search).Select("nearby.*"), page, column, "nearby.id").Scan(&rows).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	events, err := r.loadEvents(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]repository.EventDistance, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, repository.EventDistance{Event: events[row.ID], Distance: row.Distance})
+	}
+	return results, nil
+}
+
+func (r *eventRepository) CountNearby(search repository.NearbySearch) (int64, error) {
+	var total int64
+	if err := r.nearby(search).Count(&total).Error; err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
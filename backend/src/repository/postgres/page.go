@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"fmt"
+	"src/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Aplica a ordenação, o cursor e o limite da página. A paginação é por
+// keyset: a comparação (coluna, id) usa o índice e não depende de OFFSET.
+func paginate(query *gorm.DB, page repository.Page, column, idColumn string) *gorm.DB {
+	operator, direction := ">", "ASC"
+	if page.Sort.Desc {
+		operator, direction = "<", "DESC"
+	}
+
+	if page.After != nil {
+		condition := fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, operator)
+		query = query.Where(condition, page.After.Value, page.After.ID)
+	}
+	query = query.Order(fmt.Sprintf("%s %s, %s %s", column, direction, idColumn, direction))
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+	return query
+}
+
+// Escapa os curingas do LIKE para buscar o texto literalmente
+func likePattern(text string) string {
+	text = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+	return "%" + text + "%"
+}
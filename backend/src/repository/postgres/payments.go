@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func (r *paymentRepository) Create(payment *database.Payment) error {
+	return translate(r.db.Omit("Ticket", "User").Create(payment).Error)
+}
+
+func (r *paymentRepository) FindByID(id uuid.UUID) (*database.Payment, error) {
+	var payment database.Payment
+	if err := r.db.First(&payment, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepository) ListByTicket(ticketID uuid.UUID) ([]database.Payment, error) {
+	var payments []database.Payment
+	if err := r.db.Where("ticket_id = ?", ticketID).Find(&payments).Error; err != nil {
+		return nil, translate(err)
+	}
+	return payments, nil
+}
+
+func (r *paymentRepository) Update(payment *database.Payment) error {
+	return translate(r.db.Omit("Ticket", "User").Save(payment).Error)
+}
+
+func (r *paymentRepository) TransactionsByTickets(ticketIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	references := make(map[uuid.UUID]string, len(ticketIDs))
+	if len(ticketIDs) == 0 {
+		return references, nil
+	}
+
+	var rows []struct {
+		TicketID     uuid.UUID
+		Transactions string
+	}
+	err := r.db.Model(&database.Payment{}).
+		Select("ticket_id, string_agg(mpesa_transaction_id, ', ' ORDER BY mpesa_transaction_id) AS transactions").
+		Where("ticket_id IN ? AND status = ? AND mpesa_transaction_id IS NOT NULL", ticketIDs, "pago").
+		Group("ticket_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	for _, row := range rows {
+		references[row.TicketID] = row.Transactions
+	}
+	return references, nil
+}
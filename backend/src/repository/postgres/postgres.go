@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"errors"
+	"src/repository"
+
+	"gorm.io/gorm"
+)
+
+// Função para criar os repositórios sobre uma conexão GORM/PostgreSQL
+func New(db *gorm.DB) repository.Repositories {
+	return repository.Repositories{
+		Users:         &userRepository{db: db},
+		Events:        &eventRepository{db: db},
+		Tickets:       &ticketRepository{db: db},
+		Payments:      &paymentRepository{db: db},
+		RecoveryCodes: &recoveryCodeRepository{db: db},
+		Identities:    &identityRepository{db: db},
+		LoginAttempts: &loginAttemptRepository{db: db},
+		APIKeys:       &apiKeyRepository{db: db},
+		EventMembers:  &eventMemberRepository{db: db},
+		Categories:    &categoryRepository{db: db},
+		Venues:        &venueRepository{db: db},
+		Series:        &seriesRepository{db: db},
+		EventImages:   &eventImageRepository{db: db},
+		Questions:     &eventQuestionRepository{db: db},
+	}
+}
+
+// Converte os erros do GORM nos erros do pacote repository
+// (requer gorm.Config{TranslateError: true} para detectar duplicados)
+func translate(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repository.ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return repository.ErrDuplicate
+	default:
+		return err
+	}
+}
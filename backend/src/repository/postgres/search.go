@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"src/database"
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Consulta da busca textual, com stemming em português (mesma configuração do search_vector)
+const tsQuery = "websearch_to_tsquery('portuguese', ?)"
+
+// Opções do ts_headline: o nome e o local inteiros, a descrição em trechos
+const (
+	headlineFull     = "HighlightAll=true, StartSel=" + repository.HighlightStart + ", StopSel=" + repository.HighlightStop
+	headlineFragment = "MaxWords=35, MinWords=15, MaxFragments=2, StartSel=" + repository.HighlightStart + ", StopSel=" + repository.HighlightStop
+)
+
+// Colunas pelas quais os resultados da busca podem ser ordenados
+var searchSortColumns = map[string]string{
+	repository.SortByRelevance: "hits.rank",
+	repository.SortByDate:      "hits.date",
+}
+
+// Linha do resultado da busca: o evento, a relevância e os trechos destacados
+type searchRow struct {
+	database.Event
+	Rank                 float64
+	NameHighlight        string
+	DescriptionHighlight string
+	LocationHighlight    string
+}
+
+// Eventos que atendem aos filtros e à busca: pelo texto (search_vector) ou,
+// para tolerar erros de digitação, pela similaridade de trigramas com o nome
+func (r *eventRepository) matching(search repository.EventSearch) *gorm.DB {
+	return r.filtered(search.Filter).
+		Where("(events.search_vector @@ "+tsQuery+" OR ? <% events.name)", search.Query, search.Query)
+}
+
+func (r *eventRepository) Search(search repository.EventSearch, page repository.Page) ([]repository.EventSearchHit, error) {
+	column, ok := searchSortColumns[page.Sort.Field]
+	if !ok {
+		column = searchSortColumns[repository.SortByRelevance]
+	}
+
+	// A relevância soma o ts_rank (com os pesos do search_vector) e a similaridade do nome
+	hits := r.matching(search).
+		Select("events.*, ts_rank(events.search_vector, "+tsQuery+") + word_similarity(?, events.name) AS rank", search.Query, search.Query)
+
+	// Os trechos destacados só são calculados para as linhas da página
+	query := r.db.Table("(?) AS hits", hits).Select(
+		"hits.*, "+
+			"ts_headline('portuguese', hits.name, "+tsQuery+", ?) AS name_highlight, "+
+			"ts_headline('portuguese', coalesce(hits.description, ''), "+tsQuery+", ?) AS description_highlight, "+
+			"ts_headline('portuguese', hits.location, "+tsQuery+", ?) AS location_highlight",
+		search.Query, headlineFull, search.Query, headlineFragment, search.Query, headlineFull,
+	)
+
+	var rows []searchRow
+	if err := paginate(query, page, column, "hits.id").Scan(&rows).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	events, err := r.loadEvents(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]repository.EventSearchHit, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, repository.EventSearchHit{
+			Event: events[row.ID],
+			Rank:  row.Rank,
+			Highlight: repository.EventHighlight{
+				Name:        row.NameHighlight,
+				Description: row.DescriptionHighlight,
+				Location:    row.LocationHighlight,
+			},
+		})
+	}
+	return results, nil
+}
+
+// Carrega os eventos encontrados com as relações (o Preload não se aplica à subconsulta)
+func (r *eventRepository) loadEvents(ids []uuid.UUID) (map[uuid.UUID]database.Event, error) {
+	events := map[uuid.UUID]database.Event{}
+	if len(ids) == 0 {
+		return events, nil
+	}
+
+	var loaded []database.Event
+	if err := r.withRelations(r.db).Where("id IN ?", ids).Find(&loaded).Error; err != nil {
+		return nil, translate(err)
+	}
+	for _, event := range loaded {
+		events[event.ID] = event
+	}
+	return events, nil
+}
+
+func (r *eventRepository) SearchFacets(search repository.EventSearch, loc *time.Location) (*repository.EventFacets, error) {
+	var months []repository.FacetCount
+	err := r.matching(search).
+		Select("to_char(events.date AT TIME ZONE ?, 'YYYY-MM') AS value, COUNT(*) AS count", loc.String()).
+		Group("value").
+		Order("value").
+		Scan(&months).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	var categories []repository.FacetCount
+	err = r.matching(search).
+		Joins("JOIN categories ON categories.id = events.category_id").
+		Select("categories.slug AS value, COUNT(*) AS count").
+		Group("categories.slug").
+		Order("value").
+		Scan(&categories).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	return &repository.EventFacets{Months: months, Categories: categories}, nil
+}
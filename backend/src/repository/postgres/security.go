@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"src/database"
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func (r *recoveryCodeRepository) Replace(userID uuid.UUID, codes []database.RecoveryCode) error {
+	return translate(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&database.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Omit("User").Create(&codes).Error
+	}))
+}
+
+func (r *recoveryCodeRepository) DeleteByUser(userID uuid.UUID) error {
+	return translate(r.db.Where("user_id = ?", userID).Delete(&database.RecoveryCode{}).Error)
+}
+
+func (r *recoveryCodeRepository) MarkUsed(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&database.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+func (r *identityRepository) FindByProviderSubject(provider, subject string) (*database.UserIdentity, error) {
+	var identity database.UserIdentity
+	err := r.db.Preload("User").Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &identity, nil
+}
+
+func (r *identityRepository) Link(identity *database.UserIdentity, newUser *database.User) error {
+	return translate(r.db.Transaction(func(tx *gorm.DB) error {
+		if newUser != nil {
+			if err := tx.Create(newUser).Error; err != nil {
+				return err
+			}
+			identity.UserID = newUser.ID
+		}
+		return tx.Omit("User").Create(identity).Error
+	}))
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func (r *loginAttemptRepository) Create(attempt *database.LoginAttempt) error {
+	return translate(r.db.Omit("User").Create(attempt).Error)
+}
+
+// Monta a consulta com os filtros da listagem
+func (r *loginAttemptRepository) filtered(filter repository.LoginAttemptFilter) *gorm.DB {
+	query := r.db.Model(&database.LoginAttempt{})
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	return query
+}
+
+func (r *loginAttemptRepository) List(filter repository.LoginAttemptFilter, page repository.Page) ([]database.LoginAttempt, error) {
+	var attempts []database.LoginAttempt
+	if err := paginate(r.filtered(filter), page, "created_at", "id").Find(&attempts).Error; err != nil {
+		return nil, translate(err)
+	}
+	return attempts, nil
+}
+
+func (r *loginAttemptRepository) Count(filter repository.LoginAttemptFilter) (int64, error) {
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func (r *apiKeyRepository) Create(apiKey *database.APIKey) error {
+	return translate(r.db.Omit("User").Create(apiKey).Error)
+}
+
+func (r *apiKeyRepository) ListByUser(userID uuid.UUID) ([]database.APIKey, error) {
+	var apiKeys []database.APIKey
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&apiKeys).Error; err != nil {
+		return nil, translate(err)
+	}
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepository) FindActiveByHash(keyHash string) (*database.APIKey, error) {
+	var apiKey database.APIKey
+	err := r.db.Preload("User").Where("key_hash = ? AND revoked_at IS NULL", keyHash).First(&apiKey).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) Revoke(id, userID uuid.UUID, revokedAt time.Time) (bool, error) {
+	result := r.db.Model(&database.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(id uuid.UUID, usedAt time.Time) error {
+	return translate(r.db.Model(&database.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error)
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+func (r *seriesRepository) Create(series *database.EventSeries, occurrences []database.Event) error {
+	return translate(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Organizer").Create(series).Error; err != nil {
+			return err
+		}
+		// As tags de cada ocorrência são criadas junto com ela
+		for i := range occurrences {
+			occurrences[i].SeriesID = &series.ID
+			if err := tx.Omit("Organizer", "Category", "Venue").Create(&occurrences[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+func (r *seriesRepository) FindByID(id uuid.UUID) (*database.EventSeries, error) {
+	var series database.EventSeries
+	if err := r.db.Preload("Organizer").First(&series, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &series, nil
+}
+
+func (r *seriesRepository) Delete(id uuid.UUID) (bool, error) {
+	// As ocorrências são removidas pelo ON DELETE CASCADE
+	result := r.db.Where("id = ?", id).Delete(&database.EventSeries{})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pagamentos confirmados somados por ticket, para um ticket com vários
+// pagamentos não se repetir nas junções
+const paidByTicket = `(SELECT ticket_id, SUM(amount) AS amount FROM payments WHERE status = 'pago' GROUP BY ticket_id)`
+
+func (r *ticketRepository) Stats(eventID uuid.UUID) (*repository.TicketStats, error) {
+	var rows []struct {
+		Type      string
+		Status    string
+		Total     int64
+		CheckedIn int64
+		Refunded  int64
+		Amount    float64
+	}
+	err := r.db.Raw(`
+		SELECT t.type, t.status, COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE t.status = 'usado' OR EXISTS (
+				SELECT 1 FROM ticket_check_ins c WHERE c.ticket_id = t.id
+			)) AS checked_in,
+			COUNT(p.ticket_id) AS refunded,
+			COALESCE(SUM(p.amount), 0) AS amount
+		FROM tickets t
+		LEFT JOIN `+paidByTicket+` p ON p.ticket_id = t.id
+		WHERE t.event_id = ?
+		GROUP BY t.type, t.status`, eventID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	// Cada linha é um par (tipo, status); os cancelados entram à parte
+	stats := &repository.TicketStats{SoldByType: map[string]int64{}}
+	for _, row := range rows {
+		stats.Gross += row.Amount
+		if row.Status == "cancelado" {
+			stats.Cancelled += row.Total
+			stats.Refunded += row.Refunded
+			stats.Refunds += row.Amount
+			continue
+		}
+		stats.SoldByType[row.Type] += row.Total
+		stats.CheckedIn += row.CheckedIn
+	}
+	return stats, nil
+}
+
+func (r *ticketRepository) SalesOverTime(eventID uuid.UUID, bucket string, loc *time.Location) ([]repository.SalesBucket, error) {
+	var rows []struct {
+		PeriodStart time.Time
+		Tickets     int64
+		Revenue     float64
+	}
+	// date_trunc no horário local do fuso; o AT TIME ZONE externo volta para timestamptz
+	err := r.db.Raw(`
+		SELECT date_trunc(?, t.created_at AT TIME ZONE ?) AT TIME ZONE ? AS period_start,
+			COUNT(*) AS tickets,
+			COALESCE(SUM(p.amount), 0) AS revenue
+		FROM tickets t
+		LEFT JOIN `+paidByTicket+` p ON p.ticket_id = t.id
+		WHERE t.event_id = ? AND t.status <> 'cancelado'
+		GROUP BY period_start
+		ORDER BY period_start`, bucket, loc.String(), loc.String(), eventID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	buckets := make([]repository.SalesBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, repository.SalesBucket{Start: row.PeriodStart.In(loc), Tickets: row.Tickets, Revenue: row.Revenue})
+	}
+	return buckets, nil
+}
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"errors"
+	"src/database"
+	"src/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ticketRepository struct {
+	db *gorm.DB
+}
+
+// Carrega o evento (com o organizador, a categoria e as tags), o comprador, as entradas e as respostas
+func (r *ticketRepository) withRelations() *gorm.DB {
+	return preloadTicketRelations(r.db)
+}
+
+func preloadTicketRelations(db *gorm.DB) *gorm.DB {
+	return preloadEvent(db, "Event").Preload("User").Preload("CheckIns", func(db *gorm.DB) *gorm.DB {
+		return db.Order("ticket_check_ins.day")
+	}).Preload("Answers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("ticket_answers.question_id, ticket_answers.value")
+	})
+}
+
+func (r *ticketRepository) Create(ticket *database.Ticket) error {
+	return translate(r.db.Omit("Event", "User").Create(ticket).Error)
+}
+
+func (r *ticketRepository) CreateWithinCapacity(ticket *database.Ticket, capacity int) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Bloqueia a linha do evento para serializar as vendas concorrentes
+		if err := tx.Exec("SELECT 1 FROM events WHERE id = ? FOR UPDATE", ticket.EventID).Error; err != nil {
+			return err
+		}
+
+		if capacity > 0 {
+			var sold int64
+			err := tx.Model(&database.Ticket{}).
+				Where("event_id = ? AND status <> ?", ticket.EventID, "cancelado").
+				Count(&sold).Error
+			if err != nil {
+				return err
+			}
+			if sold >= int64(capacity) {
+				return nil
+			}
+		}
+
+		if err := tx.Omit("Event", "User").Create(ticket).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, translate(err)
+	}
+	return created, nil
+}
+
+func (r *ticketRepository) FindByID(id uuid.UUID) (*database.Ticket, error) {
+	var ticket database.Ticket
+	if err := r.withRelations().Where("tickets.id = ?", id).First(&ticket).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &ticket, nil
+}
+
+func (r *ticketRepository) FindByToken(token string) (*database.Ticket, error) {
+	var ticket database.Ticket
+	if err := r.withRelations().Where("token = ?", token).First(&ticket).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &ticket, nil
+}
+
+// Colunas pelas quais os tickets podem ser ordenados
+var ticketSortColumns = map[string]string{
+	repository.SortByCreatedAt: "tickets.created_at",
+	repository.SortByEventDate: "events.date",
+}
+
+// Monta a consulta com os filtros da listagem; o JOIN com events permite
+// filtrar e ordenar pela data do evento
+func (r *ticketRepository) filtered(filter repository.TicketFilter) *gorm.DB {
+	query := r.db.Model(&database.Ticket{}).Joins("JOIN events ON events.id = tickets.event_id")
+	if filter.UserID != nil {
+		query = query.Where("tickets.user_id = ?", *filter.UserID)
+	}
+	if filter.EventID != nil {
+		query = query.Where("tickets.event_id = ?", *filter.EventID)
+	}
+	if filter.Status != "" {
+		query = query.Where("tickets.status = ?", filter.Status)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("events.date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("events.date < ?", *filter.DateTo)
+	}
+	if filter.Search != "" {
+		pattern := likePattern(filter.Search)
+		query = query.Where(
+			"(tickets.holder_name ILIKE ? OR tickets.holder_email ILIKE ? OR tickets.user_id IN (SELECT id FROM users WHERE name ILIKE ? OR email ILIKE ?))",
+			pattern, pattern, pattern, pattern,
+		)
+	}
+	if filter.SharedWith != "" {
+		query = query.Where("tickets.holder_copy AND tickets.holder_email = ?", filter.SharedWith)
+	}
+	return query
+}
+
+func (r *ticketRepository) List(filter repository.TicketFilter, page repository.Page) ([]database.Ticket, error) {
+	column, ok := ticketSortColumns[page.Sort.Field]
+	if !ok {
+		column = ticketSortColumns[repository.SortByCreatedAt]
+	}
+
+	var tickets []database.Ticket
+	query := preloadTicketRelations(r.filtered(filter))
+	if err := paginate(query, page, column, "tickets.id").Find(&tickets).Error; err != nil {
+		return nil, translate(err)
+	}
+	return tickets, nil
+}
+
+func (r *ticketRepository) Count(filter repository.TicketFilter) (int64, error) {
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
+
+func (r *ticketRepository) ListByEvent(eventID uuid.UUID) ([]database.Ticket, error) {
+	var tickets []database.Ticket
+	if err := r.withRelations().Where("event_id = ?", eventID).Find(&tickets).Error; err != nil {
+		return nil, translate(err)
+	}
+	return tickets, nil
+}
+
+func (r *ticketRepository) TransitionStatus(id uuid.UUID, from, to string) (bool, error) {
+	result := r.db.Model(&database.Ticket{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ticketRepository) UpdateHolder(ticket *database.Ticket) (bool, error) {
+	result := r.db.Model(&database.Ticket{}).
+		Where("id = ? AND status = ?", ticket.ID, "valido").
+		Where("NOT EXISTS (SELECT 1 FROM ticket_check_ins WHERE ticket_check_ins.ticket_id = tickets.id)").
+		Updates(map[string]any{
+			"holder_name":  ticket.HolderName,
+			"holder_email": ticket.HolderEmail,
+			"holder_copy":  ticket.HolderCopy,
+			"token":        ticket.Token,
+		})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ticketRepository) CountByStatus(eventID uuid.UUID) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Total  int64
+	}
+	err := r.db.Model(&database.Ticket{}).
+		Select("status, COUNT(*) AS total").
+		Where("event_id = ?", eventID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return counts, nil
+}
+
+func (r *ticketRepository) CountSoldByEvents(eventIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(eventIDs))
+	if len(eventIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		EventID uuid.UUID
+		Total   int64
+	}
+	err := r.db.Model(&database.Ticket{}).
+		Select("event_id, COUNT(*) AS total").
+		Where("event_id IN ? AND status <> ?", eventIDs, "cancelado").
+		Group("event_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+
+	for _, row := range rows {
+		counts[row.EventID] = row.Total
+	}
+	return counts, nil
+}
+
+func (r *ticketRepository) CheckIn(checkIn *database.TicketCheckIn) (bool, error) {
+	// O índice único (ticket_id, day) garante uma entrada por dia mesmo com leituras simultâneas
+	err := translate(r.db.Omit("CheckedInBy").Create(checkIn).Error)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"src/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func (r *userRepository) Create(user *database.User) error {
+	return translate(r.db.Create(user).Error)
+}
+
+func (r *userRepository) FindByID(id uuid.UUID) (*database.User, error) {
+	var user database.User
+	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*database.User, error) {
+	var user database.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByCalendarToken(tokenHash string) (*database.User, error) {
+	var user database.User
+	if err := r.db.Where("calendar_token_hash = ?", tokenHash).First(&user).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmailVerificationToken(tokenHash string) (*database.User, error) {
+	var user database.User
+	if err := r.db.Where("email_verification_hash = ?", tokenHash).First(&user).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Update(user *database.User) error {
+	return translate(r.db.Omit("TOTPLastStep").Save(user).Error)
+}
+
+func (r *userRepository) AdvanceTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	result := r.db.Model(&database.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"src/database"
+	"src/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+func (r *venueRepository) Create(venue *database.Venue) error {
+	return translate(r.db.Omit("CreatedBy").Create(venue).Error)
+}
+
+func (r *venueRepository) FindByID(id uuid.UUID) (*database.Venue, error) {
+	var venue database.Venue
+	if err := r.db.First(&venue, "id = ?", id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &venue, nil
+}
+
+// Monta a consulta com os filtros da listagem
+func (r *venueRepository) filtered(filter repository.VenueFilter) *gorm.DB {
+	query := r.db.Model(&database.Venue{})
+	if filter.City != "" {
+		query = query.Where("lower(venues.city) = lower(?)", filter.City)
+	}
+	return query
+}
+
+func (r *venueRepository) List(filter repository.VenueFilter, page repository.Page) ([]database.Venue, error) {
+	var venues []database.Venue
+	if err := paginate(r.filtered(filter), page, "venues.name", "venues.id").Find(&venues).Error; err != nil {
+		return nil, translate(err)
+	}
+	return venues, nil
+}
+
+func (r *venueRepository) Count(filter repository.VenueFilter) (int64, error) {
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return 0, translate(err)
+	}
+	return total, nil
+}
+
+func (r *venueRepository) Update(venue *database.Venue) error {
+	return translate(r.db.Omit("CreatedBy").Save(venue).Error)
+}
+
+func (r *venueRepository) Delete(id uuid.UUID) (bool, error) {
+	result := r.db.Where("id = ?", id).Delete(&database.Venue{})
+	if result.Error != nil {
+		return false, translate(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
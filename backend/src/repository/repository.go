@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"errors"
+	"src/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// Registro não encontrado
+	ErrNotFound = errors.New("record not found")
+	// Violação de uma restrição de unicidade (ex: email já cadastrado)
+	ErrDuplicate = errors.New("duplicate record")
+)
+
+// Acesso aos usuários
+type UserRepository interface {
+	Create(user *database.User) error
+	FindByID(id uuid.UUID) (*database.User, error)
+	FindByEmail(email string) (*database.User, error)
+	FindByCalendarToken(tokenHash string) (*database.User, error)
+	FindByEmailVerificationToken(tokenHash string) (*database.User, error)
+	// Atualiza o usuário, exceto o último passo TOTP aceito (só alterado por AdvanceTOTPStep)
+	Update(user *database.User) error
+	// Registra o passo TOTP usado apenas se for posterior ao último aceito;
+	// retorna false se o código já foi usado (ou é de um passo anterior)
+	AdvanceTOTPStep(userID uuid.UUID, step int64) (bool, error)
+}
+
+// Acesso aos eventos (sempre com o organizador, a categoria, as tags e o local carregados)
+type EventRepository interface {
+	Create(event *database.Event) error
+	FindByID(id uuid.UUID) (*database.Event, error)
+	// Lista uma página dos eventos que atendem ao filtro
+	List(filter EventFilter, page Page) ([]database.Event, error)
+	Count(filter EventFilter) (int64, error)
+	// Busca textual, ordenada pela relevância ou pela data
+	Search(search EventSearch, page Page) ([]EventSearchHit, error)
+	// Facetas de todos os eventos encontrados; os meses seguem o fuso informado
+	SearchFacets(search EventSearch, loc *time.Location) (*EventFacets, error)
+	// Quantidade de eventos de cada categoria que atendem ao filtro
+	CountByCategory(filter EventFilter) (map[uuid.UUID]int64, error)
+	// Eventos em locais dentro do raio, com a distância calculada no banco
+	Nearby(search NearbySearch, page Page) ([]EventDistance, error)
+	CountNearby(search NearbySearch) (int64, error)
+	Update(event *database.Event) error
+	// Salva vários eventos na mesma transação (ex: ocorrências de uma série)
+	UpdateAll(events []database.Event) error
+	Delete(id uuid.UUID) error
+}
+
+// Acesso às séries de eventos recorrentes
+type SeriesRepository interface {
+	// Cria a série e as ocorrências dela na mesma transação
+	Create(series *database.EventSeries, occurrences []database.Event) error
+	FindByID(id uuid.UUID) (*database.EventSeries, error)
+	// Remove a série com todas as ocorrências
+	Delete(id uuid.UUID) (bool, error)
+}
+
+// Acesso às imagens dos eventos
+type EventImageRepository interface {
+	// Cria a imagem; uma nova capa substitui a anterior na mesma transação,
+	// e a capa substituída é retornada para que os arquivos dela sejam removidos
+	Create(image *database.EventImage) (*database.EventImage, error)
+	FindByID(id uuid.UUID) (*database.EventImage, error)
+	CountByEvent(eventID uuid.UUID, kind string) (int64, error)
+	Delete(id uuid.UUID) (bool, error)
+}
+
+// Acesso às perguntas do cadastro dos participantes (sempre com as opções carregadas)
+type EventQuestionRepository interface {
+	Create(question *database.EventQuestion) error
+	FindByID(id uuid.UUID) (*database.EventQuestion, error)
+	// Lista as perguntas do evento na ordem do formulário
+	ListByEvent(eventID uuid.UUID) ([]database.EventQuestion, error)
+	CountByEvent(eventID uuid.UUID) (int64, error)
+	// Salva a pergunta e substitui as opções dela na mesma transação
+	Update(question *database.EventQuestion) error
+	// Remove a pergunta com as respostas dadas a ela
+	Delete(id uuid.UUID) (bool, error)
+}
+
+// Acesso às categorias de evento
+type CategoryRepository interface {
+	Create(category *database.Category) error
+	FindByID(id uuid.UUID) (*database.Category, error)
+	// Lista todas as categorias em ordem alfabética
+	List() ([]database.Category, error)
+	Update(category *database.Category) error
+	// Remove a categoria; os eventos dela ficam sem categoria
+	Delete(id uuid.UUID) (bool, error)
+}
+
+// Acesso aos locais de eventos
+type VenueRepository interface {
+	Create(venue *database.Venue) error
+	FindByID(id uuid.UUID) (*database.Venue, error)
+	// Lista uma página dos locais, ordenados pelo nome
+	List(filter VenueFilter, page Page) ([]database.Venue, error)
+	Count(filter VenueFilter) (int64, error)
+	Update(venue *database.Venue) error
+	// Remove o local; os eventos dele ficam sem local
+	Delete(id uuid.UUID) (bool, error)
+}
+
+// Acesso aos tickets (sempre com o evento, o organizador, o comprador, as entradas e as respostas carregados)
+type TicketRepository interface {
+	Create(ticket *database.Ticket) error
+	// Cria o ticket (com as respostas) apenas se o evento ainda tiver lugares (capacity 0 = sem
+	// limite), de forma atômica entre vendas concorrentes; retorna false se esgotado
+	CreateWithinCapacity(ticket *database.Ticket, capacity int) (bool, error)
+	FindByID(id uuid.UUID) (*database.Ticket, error)
+	FindByToken(token string) (*database.Ticket, error)
+	// Lista uma página dos tickets que atendem ao filtro
+	List(filter TicketFilter, page Page) ([]database.Ticket, error)
+	Count(filter TicketFilter) (int64, error)
+	ListByEvent(eventID uuid.UUID) ([]database.Ticket, error)
+	// Altera o status apenas se o atual for `from`; retorna false se não alterou
+	TransitionStatus(id uuid.UUID, from, to string) (bool, error)
+	// Troca o titular e o token do ticket apenas se ele ainda for válido e não
+	// tiver entradas; retorna false se não alterou
+	UpdateHolder(ticket *database.Ticket) (bool, error)
+	CountByStatus(eventID uuid.UUID) (map[string]int64, error)
+	// Quantidade de tickets vendidos (não cancelados) de cada evento
+	CountSoldByEvents(eventIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	// Registra a entrada de um passe no dia; retorna false se ele já entrou nesse dia
+	CheckIn(checkIn *database.TicketCheckIn) (bool, error)
+	// Números de vendas, entradas, cancelamentos e receita do evento
+	Stats(eventID uuid.UUID) (*TicketStats, error)
+	// Vendas do evento agrupadas por período (BucketHour, ...) no fuso informado,
+	// em ordem cronológica; os períodos sem vendas não aparecem
+	SalesOverTime(eventID uuid.UUID, bucket string, loc *time.Location) ([]SalesBucket, error)
+}
+
+// Acesso aos pagamentos
+type PaymentRepository interface {
+	Create(payment *database.Payment) error
+	FindByID(id uuid.UUID) (*database.Payment, error)
+	ListByTicket(ticketID uuid.UUID) ([]database.Payment, error)
+	Update(payment *database.Payment) error
+	// Referências M-Pesa dos pagamentos confirmados de cada ticket (os tickets sem pagamento ficam de fora)
+	TransactionsByTickets(ticketIDs []uuid.UUID) (map[uuid.UUID]string, error)
+}
+
+// Acesso aos códigos de recuperação do 2FA
+type RecoveryCodeRepository interface {
+	// Substitui todos os códigos do usuário pelos novos
+	Replace(userID uuid.UUID, codes []database.RecoveryCode) error
+	DeleteByUser(userID uuid.UUID) error
+	// Marca como usado um código ainda não usado; retorna false se não existir
+	MarkUsed(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error)
+}
+
+// Acesso às identidades externas (login social)
+type IdentityRepository interface {
+	FindByProviderSubject(provider, subject string) (*database.UserIdentity, error)
+	// Cria a identidade, criando antes newUser (se não for nil) na mesma transação
+	Link(identity *database.UserIdentity, newUser *database.User) error
+}
+
+// Acesso ao registro de tentativas de login falhadas
+type LoginAttemptRepository interface {
+	Create(attempt *database.LoginAttempt) error
+	// Lista uma página das tentativas, ordenadas por data de criação
+	List(filter LoginAttemptFilter, page Page) ([]database.LoginAttempt, error)
+	Count(filter LoginAttemptFilter) (int64, error)
+}
+
+// Acesso às chaves de API
+type APIKeyRepository interface {
+	Create(apiKey *database.APIKey) error
+	ListByUser(userID uuid.UUID) ([]database.APIKey, error)
+	// Busca uma chave não revogada pelo hash, com o dono carregado
+	FindActiveByHash(keyHash string) (*database.APIKey, error)
+	Revoke(id, userID uuid.UUID, revokedAt time.Time) (bool, error)
+	TouchLastUsed(id uuid.UUID, usedAt time.Time) error
+}
+
+// Acesso à equipe dos eventos
+type EventMemberRepository interface {
+	Create(member *database.EventMember) error
+	Update(member *database.EventMember) error
+	FindByEventAndEmail(eventID uuid.UUID, email string) (*database.EventMember, error)
+	FindAccepted(eventID, userID uuid.UUID) (*database.EventMember, error)
+	// Busca um convite pendente para o email, com o evento carregado
+	FindPending(id uuid.UUID, email string) (*database.EventMember, error)
+	ListByEvent(eventID uuid.UUID) ([]database.EventMember, error)
+	// Lista os convites pendentes para o email, com o evento carregado
+	ListPendingByEmail(email string) ([]database.EventMember, error)
+	Delete(id, eventID uuid.UUID) (bool, error)
+}
+
+// Conjunto de repositórios usado pelos serviços
+type Repositories struct {
+	Users         UserRepository
+	Events        EventRepository
+	Tickets       TicketRepository
+	Payments      PaymentRepository
+	RecoveryCodes RecoveryCodeRepository
+	Identities    IdentityRepository
+	LoginAttempts LoginAttemptRepository
+	APIKeys       APIKeyRepository
+	EventMembers  EventMemberRepository
+	Categories    CategoryRepository
+	Venues        VenueRepository
+	Series        SeriesRepository
+	EventImages   EventImageRepository
+	Questions     EventQuestionRepository
+}
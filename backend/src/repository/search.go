@@ -0,0 +1,45 @@
+package repository
+
+import "src/database"
+
+// Ordenação da busca pela relevância (sempre dos mais relevantes para os menos)
+const SortByRelevance = "relevance"
+
+// Marcadores dos termos encontrados nos trechos destacados; são caracteres de
+// controle para não se confundirem com o texto, que é escapado na resposta
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
+)
+
+// Busca textual de eventos, combinada com os filtros da listagem
+type EventSearch struct {
+	Query  string
+	Filter EventFilter
+}
+
+// Trechos do evento com os termos da busca entre os marcadores
+type EventHighlight struct {
+	Name        string
+	Description string
+	Location    string
+}
+
+// Evento encontrado pela busca, com a relevância e os trechos destacados
+type EventSearchHit struct {
+	Event     database.Event
+	Rank      float64
+	Highlight EventHighlight
+}
+
+// Quantidade de eventos com um valor
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// Contagens dos eventos encontrados, agrupados para refinar a busca
+type EventFacets struct {
+	Months     []FacetCount // Mês do evento (AAAA-MM) no fuso configurado, em ordem cronológica
+	Categories []FacetCount // Slug da categoria, em ordem alfabética
+}
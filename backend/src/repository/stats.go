@@ -0,0 +1,28 @@
+package repository
+
+import "time"
+
+// Períodos em que as vendas são agrupadas no painel do organizador
+const (
+	BucketHour  = "hour"
+	BucketDay   = "day"
+	BucketWeek  = "week" // Semanas começando na segunda-feira, como no date_trunc
+	BucketMonth = "month"
+)
+
+// Números de vendas de um evento, agregados no banco
+type TicketStats struct {
+	SoldByType map[string]int64 // Tickets vendidos (não cancelados) por tipo
+	CheckedIn  int64            // Tickets vendidos já usados ou com alguma entrada registrada
+	Cancelled  int64
+	Refunded   int64   // Tickets cancelados com pagamento confirmado
+	Gross      float64 // Soma dos pagamentos confirmados
+	Refunds    float64 // Parte de Gross paga pelos tickets cancelados
+}
+
+// Vendas de um período: tickets vendidos (não cancelados) e os pagamentos confirmados deles
+type SalesBucket struct {
+	Start   time.Time // Início do período, no fuso informado
+	Tickets int64
+	Revenue float64
+}
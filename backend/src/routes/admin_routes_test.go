@@ -0,0 +1,63 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/url"
+	"src/database"
+	"testing"
+)
+
+func TestGetLoginAttempts(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.newAdmin()
+	user := s.newUser("buyer")
+	organizer := s.newUser("organizer")
+
+	expectStatus(t, s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": "errada"}), http.StatusUnauthorized)
+	expectStatus(t, s.do("POST", "/login", "", map[string]string{"email": "ninguem@example.com", "password": "errada"}), http.StatusUnauthorized)
+
+	tests := []struct {
+		name   string
+		token  string
+		query  string
+		want   int
+		count  int
+		reason string
+	}{
+		{"all attempts", admin.Token, "", http.StatusOK, 2, ""},
+		{"filtered by email", admin.Token, "?email=" + user.Email, http.StatusOK, 1, "invalid_password"},
+		{"limited", admin.Token, "?limit=1", http.StatusOK, 1, "unknown_email"},
+		{"organizer", organizer.Token, "", http.StatusForbidden, 0, ""},
+		{"unauthenticated", "", "", http.StatusUnauthorized, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", "/admin/login-attempts"+tt.query, tt.token, nil)
+			expectStatus(t, rec, tt.want)
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			attempts := decode[[]database.LoginAttempt](t, rec)
+			if len(attempts) != tt.count {
+				t.Fatalf("got %d attempts, want %d", len(attempts), tt.count)
+			}
+			if tt.reason != "" && attempts[0].Reason != tt.reason {
+				t.Fatalf("most recent attempt reason = %q, want %q", attempts[0].Reason, tt.reason)
+			}
+		})
+	}
+
+	// A página seguinte continua a partir do cursor, das mais recentes para as mais antigas
+	rec := s.do("GET", "/admin/login-attempts?limit=1", admin.Token, nil)
+	expectTotal(t, rec, 2)
+	rec = s.do("GET", "/admin/login-attempts?limit=1&cursor="+url.QueryEscape(rec.Header().Get("X-Next-Cursor")), admin.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if attempts := decode[[]database.LoginAttempt](t, rec); len(attempts) != 1 || attempts[0].Reason != "invalid_password" {
+		t.Fatalf("second page = %+v, want the invalid_password attempt", attempts)
+	}
+	if cursor := rec.Header().Get("X-Next-Cursor"); cursor != "" {
+		t.Fatalf("last page has cursor %q", cursor)
+	}
+}
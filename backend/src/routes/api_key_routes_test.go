@@ -0,0 +1,123 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/services"
+	"strings"
+	"testing"
+)
+
+// Cria uma chave de API pela rota e devolve o ID e a chave em claro
+func (s *testServer) createAPIKey(organizer testUser, scopes ...string) (database.APIKey, string) {
+	s.t.Helper()
+
+	rec := s.do("POST", "/api-keys", organizer.Token, map[string]any{"name": "Catraca", "scopes": scopes})
+	expectStatus(s.t, rec, http.StatusCreated)
+
+	response := decode[struct {
+		database.APIKey
+		Key string `json:"key"`
+	}](s.t, rec)
+	return response.APIKey, response.Key
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"organizer", organizer.Token, map[string]any{"name": "Catraca", "scopes": []string{services.ScopeTicketsValidate}}, http.StatusCreated},
+		{"buyer", buyer.Token, map[string]any{"name": "Catraca", "scopes": []string{services.ScopeTicketsValidate}}, http.StatusForbidden},
+		{"unknown scope", organizer.Token, map[string]any{"name": "Catraca", "scopes": []string{"admin:all"}}, http.StatusBadRequest},
+		{"no scopes", organizer.Token, map[string]any{"name": "Catraca", "scopes": []string{}}, http.StatusBadRequest},
+		{"missing name", organizer.Token, map[string]any{"name": " ", "scopes": []string{services.ScopeEventsRead}}, http.StatusBadRequest},
+		{"malformed JSON", organizer.Token, `{"name":`, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]any{"name": "Catraca", "scopes": []string{services.ScopeEventsRead}}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("POST", "/api-keys", tt.token, tt.body), tt.want)
+		})
+	}
+}
+
+func TestListAndRevokeAPIKeys(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	other := s.newUser("organizer")
+	apiKey, plainKey := s.createAPIKey(organizer, services.ScopeEventsRead)
+
+	rec := s.do("GET", "/api-keys", organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	keys := decode[[]database.APIKey](t, rec)
+	if len(keys) != 1 || keys[0].ID != apiKey.ID {
+		t.Fatalf("api keys = %+v, want only %s", keys, apiKey.ID)
+	}
+	if body := rec.Body.String(); strings.Contains(body, plainKey) || strings.Contains(body, "KeyHash") {
+		t.Fatalf("listing leaks the key or its hash: %s", body)
+	}
+	expectStatus(t, s.do("GET", "/api-keys", "", nil), http.StatusUnauthorized)
+
+	revokePath := "/api-keys/" + apiKey.ID.String()
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"another organizer", other.Token, revokePath, http.StatusNotFound},
+		{"invalid ID", organizer.Token, "/api-keys/nao-e-uuid", http.StatusBadRequest},
+		{"unauthenticated", "", revokePath, http.StatusUnauthorized},
+		{"owner", organizer.Token, revokePath, http.StatusNoContent},
+		{"already revoked", organizer.Token, revokePath, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("DELETE", tt.path, tt.token, nil), tt.want)
+		})
+	}
+
+	// A chave revogada deixa de autenticar
+	expectStatus(t, s.do("GET", "/events", "", nil, header{"X-API-Key": plainKey}), http.StatusUnauthorized)
+}
+
+func TestAuthenticateWithAPIKey(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	_, readKey := s.createAPIKey(organizer, services.ScopeEventsRead)
+	_, validateKey := s.createAPIKey(organizer, services.ScopeTicketsValidate)
+	ticket := s.buyTicket(buyer, event.ID)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    any
+		headers header
+		want    int
+	}{
+		{"X-API-Key header", "GET", "/events", nil, header{"X-API-Key": readKey}, http.StatusOK},
+		{"ApiKey authorization", "GET", "/events/" + event.ID.String(), nil, header{"Authorization": "ApiKey " + readKey}, http.StatusOK},
+		{"missing scope", "GET", "/events", nil, header{"X-API-Key": validateKey}, http.StatusForbidden},
+		{"unknown key", "GET", "/events", nil, header{"X-API-Key": "tk_desconhecida"}, http.StatusUnauthorized},
+		{"keys do not work on JWT-only routes", "GET", "/user", nil, header{"X-API-Key": readKey}, http.StatusUnauthorized},
+		{"validate ticket", "POST", "/tickets/validate", map[string]string{"token": ticket.Token}, header{"X-API-Key": validateKey}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do(tt.method, tt.path, "", tt.body, tt.headers), tt.want)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package routes_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"src/database"
+	"src/dto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Troca o nome do usuário direto no repositório
+func (s *testServer) renameUser(user *testUser, name string) {
+	s.t.Helper()
+
+	stored, err := s.repos.Users.FindByID(user.ID)
+	if err != nil {
+		s.t.Fatalf("finding user: %v", err)
+	}
+	stored.Name = name
+	if err := s.repos.Users.Update(stored); err != nil {
+		s.t.Fatalf("updating user: %v", err)
+	}
+	user.Name = name
+}
+
+// Lê o CSV exportado, sem a marca de ordem de bytes do início
+func readCSV(t *testing.T, body []byte) [][]string {
+	t.Helper()
+
+	if !bytes.HasPrefix(body, []byte("\uFEFF")) {
+		t.Fatalf("CSV without UTF-8 BOM: %q", body)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\uFEFF")))).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	return rows
+}
+
+// Lê as linhas da aba de uma planilha XLSX exportada
+func readXLSX(t *testing.T, body []byte) [][]string {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("opening XLSX: %v", err)
+	}
+	parts := map[string]*zip.File{}
+	for _, file := range archive.File {
+		parts[file.Name] = file
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if parts[name] == nil {
+			t.Fatalf("XLSX without %s", name)
+		}
+	}
+
+	file, err := parts["xl/worksheets/sheet1.xml"].Open()
+	if err != nil {
+		t.Fatalf("opening sheet: %v", err)
+	}
+	defer file.Close()
+	content, _ := io.ReadAll(file)
+
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref  string `xml:"r,attr"`
+				Text string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(content, &sheet); err != nil {
+		t.Fatalf("parsing sheet: %v", err)
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		cells := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			cells = append(cells, cell.Text)
+		}
+		rows = append(rows, cells)
+	}
+	return rows
+}
+
+// Evento com dois compradores: Ana com um ticket pago e já usado e Bruno com um ticket sem pagamento
+func setupAttendees(t *testing.T) (*testServer, testUser, database.Event, testUser, testUser) {
+	t.Helper()
+
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	event := s.createEvent(organizer, "Conferência")
+
+	ana, bruno := s.newUser("buyer"), s.newUser("buyer")
+	s.renameUser(&ana, "Ana Machava")
+	s.renameUser(&bruno, "Bruno Sitoe")
+
+	used := s.buyTicket(ana, event.ID)
+	transaction := "MP-0001"
+	payment := database.Payment{TicketID: used.ID, UserID: ana.ID, Amount: 500, Status: "pago", MpesaTransactionID: &transaction}
+	if err := s.repos.Payments.Create(&payment); err != nil {
+		t.Fatalf("creating payment: %v", err)
+	}
+	expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": used.Token}), http.StatusOK)
+	s.buyTicket(bruno, event.ID)
+
+	return s, organizer, event, ana, bruno
+}
+
+func TestEventAttendees(t *testing.T) {
+	s, organizer, event, ana, bruno := setupAttendees(t)
+	attendeesPath := "/events/" + event.ID.String() + "/attendees"
+
+	rec := s.do("GET", attendeesPath, organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	expectTotal(t, rec, 2)
+	attendees := decode[[]dto.Attendee](t, rec)
+	if len(attendees) != 2 {
+		t.Fatalf("got %d attendees, want 2", len(attendees))
+	}
+
+	first := attendees[0]
+	if first.HolderName != ana.Name || first.Email != ana.Email || first.Type != "single" || first.Status != "usado" || first.OrderReference != "MP-0001" {
+		t.Fatalf("first attendee = %+v", first)
+	}
+	if len(first.CheckedInAt) != 1 || time.Since(first.CheckedInAt[0]) > time.Minute {
+		t.Fatalf("check-ins = %v", first.CheckedInAt)
+	}
+	if second := attendees[1]; second.HolderName != bruno.Name || second.OrderReference != "" || len(second.CheckedInAt) != 0 {
+		t.Fatalf("second attendee = %+v", second)
+	}
+
+	t.Run("search by name or email", func(t *testing.T) {
+		for _, search := range []string{"machava", strings.ToUpper(ana.Email[:8])} {
+			rec := s.do("GET", attendeesPath+"?search="+search, organizer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			expectTotal(t, rec, 1)
+			if got := decode[[]dto.Attendee](t, rec); got[0].HolderName != ana.Name {
+				t.Fatalf("search %q = %+v", search, got)
+			}
+		}
+
+		rec := s.do("GET", attendeesPath+"?search=100%25", organizer.Token, nil)
+		expectTotal(t, rec, 0)
+	})
+
+	t.Run("filter by status and paginate", func(t *testing.T) {
+		rec := s.do("GET", attendeesPath+"?status=valido", organizer.Token, nil)
+		expectTotal(t, rec, 1)
+
+		rec = s.do("GET", attendeesPath+"?limit=1", organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		cursor := rec.Header().Get("X-Next-Cursor")
+		if cursor == "" {
+			t.Fatal("missing next cursor")
+		}
+		rec = s.do("GET", attendeesPath+"?limit=1&cursor="+cursor, organizer.Token, nil)
+		if got := decode[[]dto.Attendee](t, rec); len(got) != 1 || got[0].HolderName != bruno.Name {
+			t.Fatalf("second page = %+v", got)
+		}
+	})
+
+	t.Run("only the organizer and co-organizers", func(t *testing.T) {
+		members := map[string]testUser{}
+		for _, role := range []string{"co-organizer", "finance", "scanner"} {
+			members[role] = s.newUser("buyer")
+			s.addTeamMember(organizer, event.ID, members[role], role)
+		}
+
+		expectStatus(t, s.do("GET", attendeesPath, members["co-organizer"].Token, nil), http.StatusOK)
+		expectStatus(t, s.do("GET", attendeesPath+"/export", members["co-organizer"].Token, nil), http.StatusOK)
+		for _, user := range []testUser{members["finance"], members["scanner"], ana} {
+			expectStatus(t, s.do("GET", attendeesPath, user.Token, nil), http.StatusForbidden)
+			expectStatus(t, s.do("GET", attendeesPath+"/export", user.Token, nil), http.StatusForbidden)
+		}
+		expectStatus(t, s.do("GET", attendeesPath, "", nil), http.StatusUnauthorized)
+		expectStatus(t, s.do("GET", "/events/"+uuid.NewString()+"/attendees", organizer.Token, nil), http.StatusNotFound)
+	})
+}
+
+func TestExportEventAttendees(t *testing.T) {
+	s, organizer, event, ana, bruno := setupAttendees(t)
+	exportPath := "/events/" + event.ID.String() + "/attendees/export"
+
+	// Nomes que começam com "=" não podem virar fórmulas na planilha
+	s.renameUser(&bruno, "=HYPERLINK(\"http://example.com\")")
+
+	tests := []struct {
+		format      string
+		contentType string
+		read        func(*testing.T, []byte) [][]string
+		injected    string
+	}{
+		{"csv", "text/csv", readCSV, "'" + bruno.Name},
+		{"xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", readXLSX, bruno.Name},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			rec := s.do("GET", exportPath+"?format="+tt.format, organizer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+				t.Fatalf("Content-Type = %q", got)
+			}
+			if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, "."+tt.format) {
+				t.Fatalf("Content-Disposition = %q", got)
+			}
+
+			rows := tt.read(t, rec.Body.Bytes())
+			if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(dto.AttendeeColumns, ",") {
+				t.Fatalf("rows = %v", rows)
+			}
+			if row := rows[1]; row[1] != ana.Name || row[2] != ana.Email || row[3] != "single" || row[4] != "usado" || row[5] == "" || row[6] != "MP-0001" {
+				t.Fatalf("Ana's row = %v", row)
+			}
+			if _, err := time.Parse(time.RFC3339, rows[1][5]); err != nil {
+				t.Fatalf("check-in time %q: %v", rows[1][5], err)
+			}
+			if row := rows[2]; row[1] != tt.injected || row[5] != "" || row[6] != "" {
+				t.Fatalf("Bruno's row = %v", row)
+			}
+		})
+	}
+
+	t.Run("defaults to CSV and applies the filters", func(t *testing.T) {
+		rec := s.do("GET", exportPath+"?search=machava", organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		if rows := readCSV(t, rec.Body.Bytes()); len(rows) != 2 || rows[1][1] != ana.Name {
+			t.Fatalf("rows = %v", rows)
+		}
+	})
+
+	t.Run("streams every batch", func(t *testing.T) {
+		buyer := s.newUser("buyer")
+		for i := range 1100 {
+			ticket := database.Ticket{EventID: event.ID, UserID: buyer.ID, Token: fmt.Sprintf("lote-%d", i), Status: "valido"}
+			if err := s.repos.Tickets.Create(&ticket); err != nil {
+				t.Fatalf("creating ticket: %v", err)
+			}
+		}
+
+		rec := s.do("GET", exportPath+"?format=xlsx", organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		if rows := readXLSX(t, rec.Body.Bytes()); len(rows) != 1103 {
+			t.Fatalf("got %d rows, want 1103", len(rows))
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		body := expectError(t, s.do("GET", exportPath+"?format=pdf", organizer.Token, nil), http.StatusBadRequest, "validation_failed")
+		if body.Fields["format"] == "" {
+			t.Fatalf("fields = %v", body.Fields)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/config"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	s := newTestServer(t)
+	existing := s.newUser("buyer")
+
+	tests := []struct {
+		name string
+		body any
+		want int
+	}{
+		{"buyer", map[string]string{"name": "Ana", "email": uniqueEmail("ana"), "password": testPassword, "role": "buyer"}, http.StatusCreated},
+		{"organizer", map[string]string{"name": "Rui", "email": uniqueEmail("rui"), "password": testPassword, "role": "organizer"}, http.StatusCreated},
+		{"duplicate email", map[string]string{"name": "Ana", "email": existing.Email, "password": testPassword, "role": "buyer"}, http.StatusConflict},
+		{"admin role is not self-service", map[string]string{"name": "Eva", "email": uniqueEmail("eva"), "password": testPassword, "role": "admin"}, http.StatusBadRequest},
+		{"unknown role", map[string]string{"name": "Eva", "email": uniqueEmail("eva"), "password": testPassword, "role": "superuser"}, http.StatusBadRequest},
+		{"weak password", map[string]string{"name": "Eva", "email": uniqueEmail("eva"), "password": "12345678", "role": "buyer"}, http.StatusBadRequest},
+		{"invalid email", map[string]string{"name": "Eva", "email": "eva", "password": testPassword, "role": "buyer"}, http.StatusBadRequest},
+		{"empty name", map[string]string{"name": "", "email": uniqueEmail("eva"), "password": testPassword, "role": "buyer"}, http.StatusBadRequest},
+		{"malformed JSON", `{"name":`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("POST", "/register", "", tt.body), tt.want)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    func(user testUser) string
+		password string
+		body     string
+		want     int
+	}{
+		{"valid credentials", func(u testUser) string { return u.Email }, testPassword, "", http.StatusOK},
+		{"wrong password", func(u testUser) string { return u.Email }, "errada", "", http.StatusUnauthorized},
+		{"unknown email", func(testUser) string { return "ninguem@example.com" }, testPassword, "", http.StatusUnauthorized},
+		{"malformed JSON", nil, "", `{"email":`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			user := s.newUser("buyer")
+
+			var body any = tt.body
+			if tt.email != nil {
+				body = map[string]string{"email": tt.email(user), "password": tt.password}
+			}
+
+			rec := s.do("POST", "/login", "", body)
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusOK {
+				response := decode[struct {
+					Token string `json:"token"`
+					User  struct{ Email string }
+				}](t, rec)
+				if response.Token == "" || response.User.Email != user.Email {
+					t.Fatalf("unexpected login response: %s", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestLoginIsThrottledAfterRepeatedFailures(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+
+	for i := 0; i < 3; i++ {
+		rec := s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": "errada"})
+		expectStatus(t, rec, http.StatusUnauthorized)
+	}
+
+	// Mesmo com a senha correta, a conta fica em espera após as tentativas livres
+	rec := s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": testPassword})
+	expectError(t, rec, http.StatusTooManyRequests, "login_throttled")
+
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 {
+		t.Fatalf("Retry-After = %q, want a positive number of seconds", rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestConcurrentLoginsAreThrottled(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+
+	// Uma rajada simultânea não pode passar toda pela verificação antes de a primeira falha ser registrada
+	const attempts = 20
+	statuses := make([]int, attempts)
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": "errada"}).Code
+		}()
+	}
+	wg.Wait()
+
+	counts := map[int]int{}
+	for _, status := range statuses {
+		counts[status]++
+	}
+	if counts[http.StatusUnauthorized] != 3 || counts[http.StatusTooManyRequests] != attempts-3 {
+		t.Fatalf("statuses = %v, want 3 guesses and the rest throttled", counts)
+	}
+
+	// Mesmo com a senha correta, a conta continua em espera
+	expectError(t, s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": testPassword}), http.StatusTooManyRequests, "login_throttled")
+}
+
+func TestHello(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"valid token", "Bearer " + user.Token, http.StatusOK},
+		{"missing header", "", http.StatusUnauthorized},
+		{"not a bearer token", "Basic " + user.Token, http.StatusUnauthorized},
+		{"tampered token", "Bearer " + user.Token + "x", http.StatusUnauthorized},
+		{"token signed with another secret", "Bearer " + tokenWithOtherSecret(t), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", "/hello", "", nil, header{"Authorization": tt.authorization})
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusOK && !strings.Contains(rec.Body.String(), user.Name) {
+				t.Fatalf("greeting %q does not contain the user name", rec.Body.String())
+			}
+		})
+	}
+}
+
+// Token válido de outro servidor (outro JWT_SECRET), que deve ser recusado
+func tokenWithOtherSecret(t *testing.T) string {
+	other := newTestServer(t, func(cfg *config.Config) { cfg.JWTSecret = "another-secret" })
+	return other.newUser("buyer").Token
+}
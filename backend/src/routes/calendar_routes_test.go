@@ -0,0 +1,190 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/url"
+	"src/database"
+	"src/dto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Desdobra as linhas do iCalendar (as continuações começam com espaço) e confere o CRLF
+func unfoldCalendar(t *testing.T, body string) []string {
+	t.Helper()
+
+	if !strings.HasSuffix(body, "\r\n") || strings.Contains(strings.ReplaceAll(body, "\r\n", ""), "\n") {
+		t.Fatalf("calendar lines must end with CRLF: %q", body)
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line longer than 75 bytes: %q", line)
+		}
+		if strings.HasPrefix(line, " ") && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Agrupa as propriedades de cada VEVENT do calendário pelo nome
+func calendarEvents(t *testing.T, body string) []map[string]string {
+	t.Helper()
+
+	var events []map[string]string
+	var current map[string]string
+	for _, line := range unfoldCalendar(t, body) {
+		name, value, _ := strings.Cut(line, ":")
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = map[string]string{}
+		case line == "END:VEVENT":
+			events = append(events, current)
+			current = nil
+		case current != nil:
+			current[name] = value
+		}
+	}
+	return events
+}
+
+// Ativa a assinatura do usuário e devolve o caminho do calendário
+func (s *testServer) enableCalendar(user testUser) string {
+	s.t.Helper()
+
+	rec := s.do("POST", "/user/calendar", user.Token, nil)
+	expectStatus(s.t, rec, http.StatusCreated)
+	feed := decode[dto.CalendarFeed](s.t, rec)
+
+	address, err := url.Parse(feed.URL)
+	if err != nil || !strings.HasPrefix(feed.WebcalURL, "webcal://") || strings.TrimPrefix(feed.WebcalURL, "webcal:") != strings.TrimPrefix(feed.URL, address.Scheme+":") {
+		s.t.Fatalf("feed = %+v", feed)
+	}
+	return address.Path
+}
+
+func TestEventCalendar(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	date := time.Now().Add(10 * 24 * time.Hour).UTC().Truncate(time.Second)
+	event := s.createEventAt(organizer, "Jazz, Vinho; e Poesia", date)
+
+	rec := s.do("GET", "/events/"+event.ID.String()+".ics", buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/calendar") {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, ".ics") {
+		t.Fatalf("Content-Disposition = %q", got)
+	}
+
+	events := calendarEvents(t, rec.Body.String())
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	got := events[0]
+	if got["UID"] != event.ID.String()+"@ticketing-system" || got["DTSTART"] != date.Format("20060102T150405Z") {
+		t.Fatalf("event = %v", got)
+	}
+	if got["SUMMARY"] != `Jazz\, Vinho\; e Poesia` || got["LOCATION"] != "Maputo" || got["STATUS"] != "CONFIRMED" {
+		t.Fatalf("event = %v", got)
+	}
+
+	t.Run("drafts are hidden from buyers", func(t *testing.T) {
+		rec := s.do("POST", "/events", organizer.Token, map[string]any{
+			"name": "Rascunho", "location": "Beira", "date": date,
+		})
+		expectStatus(t, rec, http.StatusOK)
+		draft := decode[database.Event](t, rec)
+
+		expectError(t, s.do("GET", "/events/"+draft.ID.String()+".ics", buyer.Token, nil), http.StatusNotFound, "event_not_found")
+		expectStatus(t, s.do("GET", "/events/"+draft.ID.String()+".ics", organizer.Token, nil), http.StatusOK)
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		expectStatus(t, s.do("GET", "/events/"+event.ID.String()+".ics", "", nil), http.StatusUnauthorized)
+	})
+}
+
+func TestCalendarFeed(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	festival := s.createEventAt(organizer, "Festival de Marrabenta", time.Now().Add(20*24*time.Hour))
+	concert := s.createEventAt(organizer, "Concerto", time.Now().Add(30*24*time.Hour))
+	s.createEvent(organizer, "Sem ticket")
+
+	// Dois tickets do mesmo evento aparecem uma vez só
+	s.buyTicket(buyer, festival.ID)
+	s.buyTicket(buyer, festival.ID)
+	s.buyTicket(buyer, concert.ID)
+
+	feed := s.enableCalendar(buyer)
+	rec := s.do("GET", feed, "", nil)
+	expectStatus(t, rec, http.StatusOK)
+	if !strings.Contains(rec.Body.String(), "REFRESH-INTERVAL;VALUE=DURATION:PT1H") {
+		t.Fatalf("missing refresh interval: %s", rec.Body.String())
+	}
+	events := calendarEvents(t, rec.Body.String())
+	if len(events) != 2 || events[0]["SUMMARY"] != "Festival de Marrabenta" || events[1]["SUMMARY"] != "Concerto" {
+		t.Fatalf("events = %v", events)
+	}
+
+	t.Run("reflects changes made by the organizer", func(t *testing.T) {
+		newDate := time.Now().Add(60 * 24 * time.Hour).UTC().Truncate(time.Second)
+		endDate := newDate.Add(48 * time.Hour)
+		body := map[string]any{
+			"name":        "Festival de Marrabenta",
+			"description": strings.Repeat("Música ao vivo, comida e dança. ", 10),
+			"location":    "Praça da Independência, Maputo",
+			"date":        newDate,
+			"end_date":    endDate,
+		}
+		expectStatus(t, s.do("PUT", "/events/"+festival.ID.String(), organizer.Token, body), http.StatusOK)
+
+		rec := s.do("GET", feed, "", nil)
+		expectStatus(t, rec, http.StatusOK)
+		events := calendarEvents(t, rec.Body.String())
+
+		// A nova data muda a ordem: o concerto passa a ser o primeiro
+		got := events[1]
+		if got["UID"] != festival.ID.String()+"@ticketing-system" || got["DTSTART"] != newDate.Format("20060102T150405Z") || got["DTEND"] != endDate.Format("20060102T150405Z") {
+			t.Fatalf("event = %v", got)
+		}
+		if got["LOCATION"] != `Praça da Independência\, Maputo` || !strings.HasPrefix(got["DESCRIPTION"], `Música ao vivo\, comida e dança. Música`) {
+			t.Fatalf("event = %v", got)
+		}
+	})
+
+	t.Run("cancelled events stay marked as cancelled", func(t *testing.T) {
+		s.setEventStatus(organizer, concert.ID, database.EventCancelled)
+
+		events := calendarEvents(t, s.do("GET", feed, "", nil).Body.String())
+		if events[0]["UID"] != concert.ID.String()+"@ticketing-system" || events[0]["STATUS"] != "CANCELLED" {
+			t.Fatalf("events = %v", events)
+		}
+	})
+
+	t.Run("rotating the token invalidates the old URL", func(t *testing.T) {
+		rotated := s.enableCalendar(buyer)
+		if rotated == feed {
+			t.Fatal("token was not rotated")
+		}
+		expectError(t, s.do("GET", feed, "", nil), http.StatusNotFound, "calendar_not_found")
+		expectStatus(t, s.do("GET", rotated, "", nil), http.StatusOK)
+
+		expectStatus(t, s.do("DELETE", "/user/calendar", buyer.Token, nil), http.StatusNoContent)
+		expectError(t, s.do("GET", rotated, "", nil), http.StatusNotFound, "calendar_not_found")
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		expectError(t, s.do("GET", "/calendar/nao-existe.ics", "", nil), http.StatusNotFound, "calendar_not_found")
+	})
+}
@@ -0,0 +1,160 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cria uma categoria pela rota de administração
+func (s *testServer) createCategory(admin testUser, name string) dto.Category {
+	s.t.Helper()
+
+	rec := s.do("POST", "/admin/categories", admin.Token, map[string]string{"name": name})
+	expectStatus(s.t, rec, http.StatusCreated)
+	return decode[dto.Category](s.t, rec)
+}
+
+func TestCategoryAdministration(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.newAdmin()
+	organizer := s.newUser("organizer")
+
+	music := s.createCategory(admin, "Música ao Vivo")
+	if music.Slug != "musica-ao-vivo" {
+		t.Fatalf("generated slug = %q, want musica-ao-vivo", music.Slug)
+	}
+	path := "/admin/categories/" + music.ID.String()
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		body   any
+		want   int
+	}{
+		{"create with slug", admin.Token, "POST", "/admin/categories", map[string]string{"name": "Desporto", "slug": "desporto"}, http.StatusCreated},
+		{"duplicate slug", admin.Token, "POST", "/admin/categories", map[string]string{"name": "Desportos", "slug": "desporto"}, http.StatusConflict},
+		{"invalid slug", admin.Token, "POST", "/admin/categories", map[string]string{"name": "Teatro", "slug": "Teatro Infantil"}, http.StatusBadRequest},
+		{"blank name", admin.Token, "POST", "/admin/categories", map[string]string{"name": " "}, http.StatusBadRequest},
+		{"organizer cannot create", organizer.Token, "POST", "/admin/categories", map[string]string{"name": "Teatro"}, http.StatusForbidden},
+		{"unauthenticated", "", "POST", "/admin/categories", map[string]string{"name": "Teatro"}, http.StatusUnauthorized},
+		{"rename", admin.Token, "PUT", path, map[string]string{"name": "Música", "slug": "musica"}, http.StatusOK},
+		{"rename to a taken slug", admin.Token, "PUT", path, map[string]string{"name": "Desporto", "slug": "desporto"}, http.StatusConflict},
+		{"rename unknown category", admin.Token, "PUT", "/admin/categories/" + uuid.NewString(), map[string]string{"name": "Teatro"}, http.StatusNotFound},
+		{"invalid ID", admin.Token, "DELETE", "/admin/categories/nao-e-uuid", nil, http.StatusBadRequest},
+		{"organizer cannot delete", organizer.Token, "DELETE", path, nil, http.StatusForbidden},
+		{"delete", admin.Token, "DELETE", path, nil, http.StatusNoContent},
+		{"already deleted", admin.Token, "DELETE", path, nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do(tt.method, tt.path, tt.token, tt.body), tt.want)
+		})
+	}
+}
+
+func TestEventCategoriesAndTags(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.newAdmin()
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	music := s.createCategory(admin, "Música")
+	sport := s.createCategory(admin, "Desporto")
+
+	body := map[string]any{
+		"name":        "Festival de Jazz",
+		"location":    "Maputo",
+		"date":        time.Now().Add(48 * time.Hour),
+		"category_id": music.ID,
+		"tags":        []string{"Jazz", " ao-vivo ", "jazz"},
+	}
+	rec := s.do("POST", "/events", organizer.Token, body)
+	expectStatus(t, rec, http.StatusOK)
+	event := decode[dto.Event](t, rec)
+	if event.Category == nil || event.Category.Slug != "musica" {
+		t.Fatalf("event category = %+v, want musica", event.Category)
+	}
+	if len(event.Tags) != 2 || event.Tags[0] != "ao-vivo" || event.Tags[1] != "jazz" {
+		t.Fatalf("event tags = %v, want [ao-vivo jazz]", event.Tags)
+	}
+	s.setEventStatus(organizer, event.ID, database.EventPublished)
+	s.createEvent(organizer, "Sem Categoria")
+
+	t.Run("invalid input", func(t *testing.T) {
+		unknown := map[string]any{"name": "Jogo", "location": "Maputo", "date": time.Now().Add(48 * time.Hour), "category_id": uuid.New()}
+		expectError(t, s.do("POST", "/events", organizer.Token, unknown), http.StatusBadRequest, "unknown_category")
+
+		tooMany := map[string]any{"name": "Jogo", "location": "Maputo", "date": time.Now().Add(48 * time.Hour), "tags": []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}}
+		body := expectError(t, s.do("POST", "/events", organizer.Token, tooMany), http.StatusBadRequest, "validation_failed")
+		if body.Fields["tags"] == "" {
+			t.Fatalf("fields = %v, want an error for tags", body.Fields)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		for query, want := range map[string]int{
+			"?category=musica":   1,
+			"?category=desporto": 0,
+			"?tag=JAZZ":          1,
+			"?tag=rock":          0,
+			"":                   2,
+		} {
+			rec := s.do("GET", "/events/future"+query, buyer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			if events := decode[[]dto.Event](t, rec); len(events) != want {
+				t.Fatalf("%q returned %d events, want %d", query, len(events), want)
+			}
+		}
+	})
+
+	t.Run("counts per category", func(t *testing.T) {
+		rec := s.do("GET", "/categories", buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+
+		counts := map[string]int64{}
+		for _, category := range decode[[]dto.CategoryCount](t, rec) {
+			counts[category.Slug] = category.Events
+		}
+		if len(counts) != 2 || counts[music.Slug] != 1 || counts[sport.Slug] != 0 {
+			t.Fatalf("category counts = %v", counts)
+		}
+	})
+
+	t.Run("search facets", func(t *testing.T) {
+		rec := s.do("GET", "/events/search?q=jazz", buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		facets := decode[dto.EventSearch](t, rec).Facets.Categories
+		if len(facets) != 1 || facets[0].Value != "musica" || facets[0].Count != 1 {
+			t.Fatalf("category facets = %+v", facets)
+		}
+	})
+
+	t.Run("update replaces tags", func(t *testing.T) {
+		body["tags"] = []string{"blues"}
+		body["category_id"] = sport.ID
+		rec := s.do("PUT", "/events/"+event.ID.String(), organizer.Token, body)
+		expectStatus(t, rec, http.StatusOK)
+
+		got := decode[dto.Event](t, s.do("GET", "/events/"+event.ID.String(), organizer.Token, nil))
+		if got.Category == nil || got.Category.ID != sport.ID || len(got.Tags) != 1 || got.Tags[0] != "blues" {
+			t.Fatalf("event after update = %+v", got)
+		}
+	})
+
+	t.Run("deleting the category keeps the event", func(t *testing.T) {
+		expectStatus(t, s.do("DELETE", "/admin/categories/"+sport.ID.String(), admin.Token, nil), http.StatusNoContent)
+
+		rec := s.do("GET", "/events/"+event.ID.String(), organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		if got := decode[dto.Event](t, rec); got.Category != nil {
+			t.Fatalf("event category after delete = %+v, want none", got.Category)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestErrorEnvelope(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   any
+		want   int
+		code   string
+	}{
+		{"missing token", "GET", "/user", "", nil, http.StatusUnauthorized, "missing_token"},
+		{"invalid token", "GET", "/user", "nao-e-jwt", nil, http.StatusUnauthorized, "invalid_token"},
+		{"malformed JSON", "POST", "/events", organizer.Token, `{"name":`, http.StatusBadRequest, "invalid_request_body"},
+		{"unknown event", "GET", "/events/" + uuid.NewString(), buyer.Token, nil, http.StatusNotFound, "event_not_found"},
+		{"not on the team", "DELETE", "/events/" + event.ID.String(), buyer.Token, nil, http.StatusForbidden, "event_permission_denied"},
+		{"admin only", "GET", "/admin/login-attempts", organizer.Token, nil, http.StatusForbidden, "admin_only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(tt.method, tt.path, tt.token, tt.body)
+			expectError(t, rec, tt.want, tt.code)
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Fatalf("Content-Type = %q, want application/json", contentType)
+			}
+		})
+	}
+
+	t.Run("invalid ID lists the field", func(t *testing.T) {
+		body := expectError(t, s.do("GET", "/events/nao-e-uuid", buyer.Token, nil), http.StatusBadRequest, "invalid_id")
+		if body.Fields["event_id"] == "" {
+			t.Fatalf("fields = %v, want an entry for event_id", body.Fields)
+		}
+	})
+}
+
+func TestValidationErrorsListEveryField(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := s.do("POST", "/register", "", map[string]string{"name": " ", "email": "nao-e-email", "password": "curta", "role": "admin"})
+	body := expectError(t, rec, http.StatusBadRequest, "validation_failed")
+
+	for _, field := range []string{"name", "email", "password", "role"} {
+		if body.Fields[field] == "" {
+			t.Errorf("fields = %v, missing %q", body.Fields, field)
+		}
+	}
+	if len(body.Fields) != 4 {
+		t.Fatalf("fields = %v, want exactly name, email, password and role", body.Fields)
+	}
+}
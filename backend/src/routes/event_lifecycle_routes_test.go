@@ -0,0 +1,184 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"testing"
+	"time"
+)
+
+func TestEventLifecycle(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	finance := s.newUser("buyer")
+	buyer := s.newUser("buyer")
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{"name": "Concerto", "location": "Maputo", "date": time.Now().Add(30 * 24 * time.Hour)})
+	expectStatus(t, rec, http.StatusOK)
+	event := decode[dto.Event](t, rec)
+	if event.Status != database.EventDraft {
+		t.Fatalf("new event status = %q, want draft", event.Status)
+	}
+	s.addTeamMember(organizer, event.ID, finance, "finance")
+	path := "/events/" + event.ID.String()
+
+	// O que o comprador vê: o evento (os cancelados continuam acessíveis), a
+	// listagem dos eventos futuros e a compra de tickets
+	expectBuyerView := func(t *testing.T, get int, listed bool, tickets int) {
+		t.Helper()
+		expectStatus(t, s.do("GET", path, buyer.Token, nil), get)
+
+		rec := s.do("GET", "/events/future", buyer.Token, nil)
+		if events := decode[[]dto.Event](t, rec); (len(events) == 1) != listed {
+			t.Fatalf("future events = %+v, want listed=%v", events, listed)
+		}
+		expectStatus(t, s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": event.ID}), tickets)
+	}
+
+	t.Run("draft is private", func(t *testing.T) {
+		expectBuyerView(t, http.StatusNotFound, false, http.StatusNotFound)
+		expectStatus(t, s.do("GET", path, organizer.Token, nil), http.StatusOK)
+		expectStatus(t, s.do("GET", path, finance.Token, nil), http.StatusOK)
+	})
+
+	steps := []struct {
+		name    string
+		token   string
+		status  string
+		want    int
+		code    string
+		get     int
+		listed  bool
+		tickets int
+	}{
+		{"cannot skip to closed sales", organizer.Token, "sales_closed", http.StatusConflict, "invalid_status_transition", http.StatusNotFound, false, http.StatusNotFound},
+		{"unknown status", organizer.Token, "archived", http.StatusBadRequest, "validation_failed", http.StatusNotFound, false, http.StatusNotFound},
+		{"finance cannot publish", finance.Token, "published", http.StatusForbidden, "event_permission_denied", http.StatusNotFound, false, http.StatusNotFound},
+		{"publish", organizer.Token, "published", http.StatusOK, "", http.StatusOK, true, http.StatusConflict},
+		{"open sales", organizer.Token, "sales_open", http.StatusOK, "", http.StatusOK, true, http.StatusOK},
+		{"close sales", organizer.Token, "sales_closed", http.StatusOK, "", http.StatusOK, true, http.StatusConflict},
+		{"not over yet", organizer.Token, "finished", http.StatusConflict, "event_not_over", http.StatusOK, true, http.StatusConflict},
+		{"cancel", organizer.Token, "cancelled", http.StatusOK, "", http.StatusOK, false, http.StatusConflict},
+		{"cancelled is final", organizer.Token, "sales_open", http.StatusConflict, "invalid_status_transition", http.StatusOK, false, http.StatusConflict},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			rec := s.do("POST", path+"/status", step.token, map[string]string{"status": step.status})
+			if step.code != "" {
+				expectError(t, rec, step.want, step.code)
+			} else {
+				expectStatus(t, rec, step.want)
+				if got := decode[dto.Event](t, rec).Status; got != step.status {
+					t.Fatalf("status = %q, want %q", got, step.status)
+				}
+			}
+			expectBuyerView(t, step.get, step.listed, step.tickets)
+		})
+	}
+
+	t.Run("finish after the event", func(t *testing.T) {
+		past := database.Event{Name: "Ontem", Location: "Maputo", Date: time.Now().Add(-time.Hour), OrganizerID: organizer.ID, Status: database.EventSalesClosed}
+		if err := s.repos.Events.Create(&past); err != nil {
+			t.Fatal(err)
+		}
+		rec := s.do("POST", "/events/"+past.ID.String()+"/status", organizer.Token, map[string]string{"status": "finished"})
+		expectStatus(t, rec, http.StatusOK)
+		if got := decode[dto.Event](t, rec).Status; got != database.EventFinished {
+			t.Fatalf("status = %q, want finished", got)
+		}
+	})
+}
+
+func TestCancelledEventRefusesEntry(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	ticket := s.buyTicket(buyer, event.ID)
+
+	if status := s.setEventStatus(organizer, event.ID, database.EventCancelled); status != database.EventCancelled {
+		t.Fatalf("status = %q, want cancelled", status)
+	}
+	expectError(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": ticket.Token}), http.StatusConflict, "event_cancelled")
+}
+
+func TestEventSchedule(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	now := time.Now()
+	date := now.Add(30 * 24 * time.Hour)
+
+	create := func(t *testing.T, schedule map[string]any) dto.Event {
+		t.Helper()
+		body := map[string]any{"name": "Concerto", "location": "Maputo", "date": date}
+		for key, value := range schedule {
+			body[key] = value
+		}
+		rec := s.do("POST", "/events", organizer.Token, body)
+		expectStatus(t, rec, http.StatusOK)
+		return decode[dto.Event](t, rec)
+	}
+	buy := func(event dto.Event) int {
+		return s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": event.ID}).Code
+	}
+
+	tests := []struct {
+		name     string
+		schedule map[string]any
+		status   string
+		tickets  int
+	}{
+		{"publish later", map[string]any{"publish_at": now.Add(time.Hour)}, database.EventDraft, http.StatusNotFound},
+		{"already published", map[string]any{"publish_at": now.Add(-time.Minute)}, database.EventPublished, http.StatusConflict},
+		{"sales opened", map[string]any{"publish_at": now.Add(-time.Hour), "sales_start_at": now.Add(-time.Minute)}, database.EventSalesOpen, http.StatusOK},
+		{"sales not started", map[string]any{"publish_at": now.Add(-time.Hour), "sales_start_at": now.Add(time.Hour)}, database.EventPublished, http.StatusConflict},
+		{"sales ended", map[string]any{"publish_at": now.Add(-2 * time.Hour), "sales_start_at": now.Add(-2 * time.Hour), "sales_end_at": now.Add(-time.Hour)}, database.EventSalesClosed, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := create(t, tt.schedule)
+			if event.Status != tt.status {
+				t.Fatalf("status = %q, want %q", event.Status, tt.status)
+			}
+			if got := buy(event); got != tt.tickets {
+				t.Fatalf("buying a ticket returned %d, want %d", got, tt.tickets)
+			}
+		})
+	}
+
+	t.Run("reopening drops the past sales end", func(t *testing.T) {
+		event := create(t, map[string]any{"sales_start_at": now.Add(-2 * time.Hour), "sales_end_at": now.Add(-time.Hour)})
+		s.setEventStatus(organizer, event.ID, database.EventPublished)
+		if status := s.setEventStatus(organizer, event.ID, database.EventSalesOpen); status != database.EventSalesOpen {
+			t.Fatalf("status after reopening = %q", status)
+		}
+		if got := buy(event); got != http.StatusOK {
+			t.Fatalf("buying a ticket returned %d, want 200", got)
+		}
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		for field, schedule := range map[string]map[string]any{
+			"publish_at":     {"publish_at": date.Add(time.Hour)},
+			"sales_start_at": {"sales_start_at": date},
+			"sales_end_at":   {"sales_start_at": now.Add(2 * time.Hour), "sales_end_at": now.Add(time.Hour)},
+		} {
+			body := map[string]any{"name": "Concerto", "location": "Maputo", "date": date}
+			for key, value := range schedule {
+				body[key] = value
+			}
+			rec := expectError(t, s.do("POST", "/events", organizer.Token, body), http.StatusBadRequest, "validation_failed")
+			if rec.Fields[field] == "" {
+				t.Fatalf("fields = %v, want an error for %s", rec.Fields, field)
+			}
+		}
+		body := map[string]any{"name": "Concerto", "location": "Maputo", "date": date, "sales_end_at": date.Add(time.Minute)}
+		if fields := expectError(t, s.do("POST", "/events", organizer.Token, body), http.StatusBadRequest, "validation_failed").Fields; fields["sales_end_at"] == "" {
+			t.Fatalf("fields = %v, want an error for sales_end_at", fields)
+		}
+	})
+}
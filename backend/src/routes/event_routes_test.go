@@ -0,0 +1,208 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateEvent(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"happy path", organizer.Token, map[string]any{"name": "Festival", "location": "Maputo", "date": time.Now().Add(48 * time.Hour)}, http.StatusOK},
+		{"malformed JSON", organizer.Token, `{"name":`, http.StatusBadRequest},
+		{"invalid date", organizer.Token, map[string]any{"name": "Festival", "location": "Maputo", "date": "amanhã"}, http.StatusBadRequest},
+		{"date in the past", organizer.Token, map[string]any{"name": "Festival", "location": "Maputo", "date": time.Now().Add(-time.Hour)}, http.StatusBadRequest},
+		{"missing date", organizer.Token, map[string]any{"name": "Festival", "location": "Maputo"}, http.StatusBadRequest},
+		{"blank location", organizer.Token, map[string]any{"name": "Festival", "location": "  ", "date": time.Now().Add(48 * time.Hour)}, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]any{"name": "Festival", "location": "Maputo", "date": time.Now()}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("POST", "/events", tt.token, tt.body)
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusOK {
+				event := decode[database.Event](t, rec)
+				if event.ID == uuid.Nil || event.OrganizerID != organizer.ID || event.Organizer.ID != organizer.ID || event.Organizer.Name != organizer.Name {
+					t.Fatalf("unexpected event: %+v", event)
+				}
+			}
+		})
+	}
+}
+
+func TestListOrganizerEvents(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	other := s.newUser("organizer")
+	s.createEvent(organizer, "Concerto")
+	s.createEvent(organizer, "Teatro")
+	s.createEvent(other, "Outro")
+
+	rec := s.do("GET", "/events", organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	events := decode[[]database.Event](t, rec)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want only the organizer's 2", len(events))
+	}
+	for _, event := range events {
+		if event.OrganizerID != organizer.ID {
+			t.Fatalf("event %s belongs to another organizer", event.Name)
+		}
+	}
+
+	expectStatus(t, s.do("GET", "/events", "", nil), http.StatusUnauthorized)
+}
+
+func TestListFutureEvents(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	upcoming := s.createEvent(organizer, "Próximo")
+
+	// Eventos no passado não podem ser criados pela rota, então vão direto ao repositório
+	past := database.Event{Name: "Passado", Location: "Maputo", Date: time.Now().Add(-24 * time.Hour), OrganizerID: organizer.ID}
+	if err := s.repos.Events.Create(&past); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := s.do("GET", "/events/future", buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	events := decode[[]database.Event](t, rec)
+	if len(events) != 1 || events[0].ID != upcoming.ID {
+		t.Fatalf("future events = %+v, want only %s", events, upcoming.Name)
+	}
+
+	expectStatus(t, s.do("GET", "/events/future", "", nil), http.StatusUnauthorized)
+}
+
+func TestGetEvent(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"any authenticated user", buyer.Token, "/events/" + event.ID.String(), http.StatusOK},
+		{"invalid ID", buyer.Token, "/events/nao-e-uuid", http.StatusBadRequest},
+		{"unknown event", buyer.Token, "/events/" + uuid.NewString(), http.StatusNotFound},
+		{"unauthenticated", "", "/events/" + event.ID.String(), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", tt.path, tt.token, nil)
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusOK {
+				if got := decode[database.Event](t, rec); got.ID != event.ID || got.Organizer.ID != organizer.ID {
+					t.Fatalf("unexpected event: %+v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateEvent(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	intruder := s.newUser("organizer")
+	event := s.createEvent(organizer, "Concerto")
+	path := "/events/" + event.ID.String()
+	update := map[string]any{"name": "Concerto Acústico", "location": "Beira", "date": time.Now().Add(72 * time.Hour)}
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		body  any
+		want  int
+	}{
+		{"another organizer", intruder.Token, path, update, http.StatusForbidden},
+		{"invalid ID", organizer.Token, "/events/nao-e-uuid", update, http.StatusBadRequest},
+		{"malformed JSON", organizer.Token, path, `{"name":`, http.StatusBadRequest},
+		{"unauthenticated", "", path, update, http.StatusUnauthorized},
+		{"owner", organizer.Token, path, update, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("PUT", tt.path, tt.token, tt.body), tt.want)
+		})
+	}
+
+	got := decode[database.Event](t, s.do("GET", path, organizer.Token, nil))
+	if got.Name != "Concerto Acústico" || got.Location != "Beira" {
+		t.Fatalf("event after update = %+v", got)
+	}
+}
+
+func TestUpdateStartedEvent(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	started := database.Event{Name: "Feira", Location: "Maputo", Date: time.Now().Add(-time.Hour).UTC().Truncate(time.Second), OrganizerID: organizer.ID}
+	if err := s.repos.Events.Create(&started); err != nil {
+		t.Fatalf("create started event: %v", err)
+	}
+	path := "/events/" + started.ID.String()
+
+	// Sem mudar a data, o evento já iniciado continua editável
+	rec := s.do("PUT", path, organizer.Token, map[string]any{"name": "Feira do Livro", "location": "Maputo", "date": started.Date})
+	expectStatus(t, rec, http.StatusOK)
+	if updated := decode[database.Event](t, rec); updated.Name != "Feira do Livro" || !updated.Date.Equal(started.Date) {
+		t.Fatalf("updated event = %+v", updated)
+	}
+
+	// Uma nova data precisa estar no futuro
+	body := expectError(t, s.do("PUT", path, organizer.Token, map[string]any{"name": "Feira do Livro", "location": "Maputo", "date": started.Date.Add(-24 * time.Hour)}), http.StatusBadRequest, "validation_failed")
+	if body.Fields["date"] != "must be in the future" {
+		t.Fatalf("fields = %v, want date", body.Fields)
+	}
+	expectStatus(t, s.do("PUT", path, organizer.Token, map[string]any{"name": "Feira do Livro", "location": "Maputo", "date": time.Now().Add(24 * time.Hour)}), http.StatusOK)
+}
+
+func TestDeleteEvent(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	intruder := s.newUser("organizer")
+	event := s.createEvent(organizer, "Concerto")
+	path := "/events/" + event.ID.String()
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"another organizer", intruder.Token, path, http.StatusForbidden},
+		{"invalid ID", organizer.Token, "/events/nao-e-uuid", http.StatusBadRequest},
+		{"unauthenticated", "", path, http.StatusUnauthorized},
+		{"owner", organizer.Token, path, http.StatusNoContent},
+		{"already deleted", organizer.Token, path, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("DELETE", tt.path, tt.token, nil), tt.want)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"src/apperrors"
+	"src/config"
+	"src/database"
+	"src/dto"
+	"src/mail"
+	"src/repository"
+	"src/repository/memory"
+	pgrepo "src/repository/postgres"
+	"src/routes"
+	"src/services"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Os testes sobem as rotas reais (routes.SetupRoutes) sobre um banco descartável:
+// um schema PostgreSQL novo por teste, com as migrações aplicadas e removido no fim
+// (ex: TEST_DATABASE_URL="host=localhost user=postgres password=postgres
+// dbname=ticketing_test sslmode=disable"). Sem TEST_DATABASE_URL os testes são
+// pulados, a não ser que TEST_REPOSITORIES=memory peça os repositórios em memória,
+// mais rápidos para o desenvolvimento mas sem as consultas SQL reais.
+
+const testPassword = "s3nha-de-teste"
+
+// Chave dos tokens dos tickets nos servidores de teste
+const testTicketSecret = "test-ticket-secret"
+
+// Formato dos hashes bcrypt guardados em User.Password
+var bcryptHash = regexp.MustCompile(`\$2[abxy]?\$\d{2}\$`)
+
+// Servidor de teste com as rotas, os serviços, os repositórios e os emails enviados
+type testServer struct {
+	t       *testing.T
+	handler http.Handler
+	svc     *services.Services
+	repos   repository.Repositories
+	outbox  *mail.Outbox
+}
+
+// Cria um servidor isolado; options permitem ajustar a configuração (2FA, OIDC, ...)
+func newTestServer(t *testing.T, options ...func(*config.Config)) *testServer {
+	t.Helper()
+
+	cfg := &config.Config{
+		JWTSecret:    "test-jwt-secret",
+		TicketSecret: testTicketSecret,
+		MediaDir:     t.TempDir(),
+		MediaBaseURL: "/media",
+		AppURL:       "http://app.test",
+	}
+	for _, option := range options {
+		option(cfg)
+	}
+
+	repos := newTestRepositories(t)
+	outbox := &mail.Outbox{}
+	svc := services.New(repos, cfg, outbox)
+
+	return &testServer{
+		t:       t,
+		handler: routes.SetupRoutes(svc),
+		svc:     svc,
+		repos:   repos,
+		outbox:  outbox,
+	}
+}
+
+func newTestRepositories(t *testing.T) repository.Repositories {
+	t.Helper()
+
+	if os.Getenv("TEST_REPOSITORIES") == "memory" {
+		return memory.New()
+	}
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set: point it to a PostgreSQL database to run the route tests, or set TEST_REPOSITORIES=memory to use the in-memory repositories")
+	}
+	return pgrepo.New(newTestSchema(t, dsn))
+}
+
+// Cria um schema vazio, aplica as migrações e remove o schema no fim do teste
+func newTestSchema(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+
+	gormConfig := &gorm.Config{TranslateError: true, Logger: logger.Discard}
+
+	admin, err := gorm.Open(postgres.Open(dsn), gormConfig)
+	if err != nil {
+		t.Fatalf("connecting to TEST_DATABASE_URL: %v", err)
+	}
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("creating schema %s: %v", schema, err)
+	}
+
+	db, err := gorm.Open(postgres.Open(withSearchPath(dsn, schema)), gormConfig)
+	if err != nil {
+		t.Fatalf("connecting to schema %s: %v", schema, err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatalf("applying migrations: %v", err)
+	}
+	return db
+}
+
+// Acrescenta o search_path ao DSN, no formato URL ou chave=valor
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		return dsn + separator + "search_path=" + schema
+	}
+	return dsn + " search_path=" + schema
+}
+
+// Cabeçalhos extras de uma requisição de teste
+type header map[string]string
+
+// Executa uma requisição; token pode ser vazio, body é convertido em JSON
+// (string e []byte são enviados como estão)
+func (s *testServer) do(method, path, token string, body any, headers ...header) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var payload []byte
+	switch value := body.(type) {
+	case nil:
+	case string:
+		payload = []byte(value)
+	case []byte:
+		payload = value
+	default:
+		var err error
+		if payload, err = json.Marshal(value); err != nil {
+			s.t.Fatalf("encoding request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for _, h := range headers {
+		for name, value := range h {
+			req.Header.Set(name, value)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	// Nenhuma resposta pode expor o hash bcrypt de uma senha
+	if bcryptHash.Match(rec.Body.Bytes()) {
+		s.t.Errorf("%s %s leaks a password hash: %s", method, path, rec.Body.String())
+	}
+	return rec
+}
+
+// Falha o teste se o status da resposta não for o esperado
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, want, strings.TrimSpace(rec.Body.String()))
+	}
+}
+
+// Verifica o status e o código estável do envelope de erro
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, want int, code string) apperrors.Body {
+	t.Helper()
+	expectStatus(t, rec, want)
+
+	body := decode[apperrors.Response](t, rec).Error
+	if body.Code != code || body.Message == "" {
+		t.Fatalf("error = %+v, want code %q", body, code)
+	}
+	return body
+}
+
+// Decodifica o corpo JSON da resposta
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var value T
+	if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+		t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+	}
+	return value
+}
+
+// Usuário criado para um teste, com o token da sessão
+type testUser struct {
+	ID       uuid.UUID
+	Name     string
+	Email    string
+	Password string
+	Role     string
+	Token    string
+}
+
+var emailSequence atomic.Int64
+
+// Gera um email único dentro da execução dos testes
+func uniqueEmail(prefix string) string {
+	return fmt.Sprintf("%s-%d@example.com", prefix, emailSequence.Add(1))
+}
+
+// Registra um usuário, confirma o email pelo link enviado e faz login
+func (s *testServer) newUser(role string) testUser {
+	s.t.Helper()
+
+	user := s.registerUser(role)
+	s.verifyEmail(user.Email)
+	return user
+}
+
+// Registra um usuário pela rota /register e faz login pela rota /login, sem verificar o email
+func (s *testServer) registerUser(role string) testUser {
+	s.t.Helper()
+
+	user := testUser{
+		Name:     "Usuário " + role,
+		Email:    uniqueEmail(role),
+		Password: testPassword,
+		Role:     role,
+	}
+
+	rec := s.do("POST", "/register", "", map[string]string{
+		"name":     user.Name,
+		"email":    user.Email,
+		"password": user.Password,
+		"role":     role,
+	})
+	expectStatus(s.t, rec, http.StatusCreated)
+	user.ID = decode[database.User](s.t, rec).ID
+
+	user.Token = s.login(user.Email, user.Password)
+	return user
+}
+
+// Padrão dos links enviados por email, com o token na query string
+var mailLinkPattern = regexp.MustCompile(`http://app\.test(/[a-z-]+)\?token=([A-Za-z0-9_-]+)`)
+
+// Devolve o token do último link com o caminho indicado enviado para o email
+func (s *testServer) mailToken(email, path string) string {
+	s.t.Helper()
+
+	messages := s.outbox.To(email)
+	for i := len(messages) - 1; i >= 0; i-- {
+		for _, match := range mailLinkPattern.FindAllStringSubmatch(messages[i].Body, -1) {
+			if match[1] == path {
+				return match[2]
+			}
+		}
+	}
+	s.t.Fatalf("no %s link sent to %s", path, email)
+	return ""
+}
+
+// Confirma o email pelo link de verificação enviado
+func (s *testServer) verifyEmail(email string) {
+	s.t.Helper()
+
+	rec := s.do("POST", "/user/email/verify", "", map[string]string{"token": s.mailToken(email, "/verify-email")})
+	expectStatus(s.t, rec, http.StatusOK)
+}
+
+// Administradores não podem se registrar pela API, então são criados direto no repositório
+func (s *testServer) newAdmin() testUser {
+	s.t.Helper()
+
+	admin := database.User{Name: "Administrador", Email: uniqueEmail("admin"), Role: "admin"}
+	if err := admin.SetPassword(testPassword); err != nil {
+		s.t.Fatal(err)
+	}
+	if err := s.repos.Users.Create(&admin); err != nil {
+		s.t.Fatalf("creating admin: %v", err)
+	}
+
+	return testUser{
+		ID:       admin.ID,
+		Name:     admin.Name,
+		Email:    admin.Email,
+		Password: testPassword,
+		Role:     admin.Role,
+		Token:    s.login(admin.Email, testPassword),
+	}
+}
+
+// Faz login e retorna o token da sessão
+func (s *testServer) login(email, password string) string {
+	s.t.Helper()
+
+	rec := s.do("POST", "/login", "", map[string]string{"email": email, "password": password})
+	expectStatus(s.t, rec, http.StatusOK)
+	return decode[struct {
+		Token string `json:"token"`
+	}](s.t, rec).Token
+}
+
+// Cria um evento futuro do organizador
+func (s *testServer) createEvent(organizer testUser, name string) database.Event {
+	s.t.Helper()
+	return s.createEventAt(organizer, name, time.Now().Add(30*24*time.Hour))
+}
+
+// Cria um evento do organizador na data indicada, já com as vendas abertas
+func (s *testServer) createEventAt(organizer testUser, name string, date time.Time) database.Event {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{
+		"name":        name,
+		"description": "Descrição de " + name,
+		"location":    "Maputo",
+		"date":        date.UTC().Truncate(time.Second),
+	})
+	expectStatus(s.t, rec, http.StatusOK)
+	event := decode[database.Event](s.t, rec)
+	event.Status = s.setEventStatus(organizer, event.ID, database.EventSalesOpen)
+	return event
+}
+
+// Muda o estado do evento (os eventos são criados como rascunho) e devolve o estado atual
+func (s *testServer) setEventStatus(organizer testUser, eventID uuid.UUID, status string) string {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events/"+eventID.String()+"/status", organizer.Token, map[string]string{"status": status})
+	expectStatus(s.t, rec, http.StatusOK)
+	return decode[dto.Event](s.t, rec).Status
+}
+
+// Compra um ticket do evento para o usuário
+func (s *testServer) buyTicket(buyer testUser, eventID uuid.UUID) database.Ticket {
+	s.t.Helper()
+
+	rec := s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": eventID})
+	expectStatus(s.t, rec, http.StatusOK)
+	return decode[database.Ticket](s.t, rec)
+}
+
+// Convida um membro para a equipe do evento e aceita o convite em nome dele
+func (s *testServer) addTeamMember(owner testUser, eventID uuid.UUID, member testUser, role string) {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events/"+eventID.String()+"/members", owner.Token, map[string]string{
+		"email": member.Email,
+		"role":  role,
+	})
+	expectStatus(s.t, rec, http.StatusCreated)
+	invitation := decode[database.EventMember](s.t, rec)
+
+	rec = s.do("POST", "/invitations/"+invitation.ID.String()+"/accept", member.Token, nil)
+	expectStatus(s.t, rec, http.StatusOK)
+}
@@ -0,0 +1,205 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/url"
+	"src/database"
+	"src/dto"
+	"src/generator"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cria um evento com as vendas abertas, o prazo para trocar o titular e a conferência na portaria
+func (s *testServer) createNamedEvent(organizer testUser, date time.Time, cutoffHours int, checkAtGate bool) dto.Event {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{
+		"name":                 "Gala nominal",
+		"location":             "Maputo",
+		"date":                 date.UTC().Truncate(time.Second),
+		"holder_cutoff_hours":  cutoffHours,
+		"check_holder_at_gate": checkAtGate,
+	})
+	expectStatus(s.t, rec, http.StatusOK)
+	event := decode[dto.Event](s.t, rec)
+	event.Status = s.setEventStatus(organizer, event.ID, database.EventSalesOpen)
+	return event
+}
+
+// Verifica o nome do titular gravado no QR Code do ticket
+func expectTokenHolder(t *testing.T, token, want string) {
+	t.Helper()
+
+	claims, err := generator.NewTicketSigner(testTicketSecret).ParseTicketToken(token)
+	if err != nil {
+		t.Fatalf("parse ticket token: %v", err)
+	}
+	if claims.HolderName != want {
+		t.Fatalf("token holder = %q, want %q", claims.HolderName, want)
+	}
+}
+
+func TestNamedTickets(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	guest := s.newUser("buyer")
+	event := s.createNamedEvent(organizer, time.Now().Add(30*24*time.Hour), 48, false)
+	if event.HolderCutoffHours != 48 || !event.HolderChangesUntil.Equal(event.Date.Add(-48*time.Hour)) {
+		t.Fatalf("event = %+v", event)
+	}
+
+	// Sem titular informado, o titular é o próprio comprador
+	own := s.buyTicket(buyer, event.ID)
+	expectTokenHolder(t, own.Token, buyer.Name)
+
+	rec := s.do("POST", "/tickets", buyer.Token, map[string]any{
+		"event_id": event.ID, "holder_name": " Ana Macuácua ", "holder_email": guest.Email, "holder_copy": true,
+	})
+	expectStatus(t, rec, http.StatusOK)
+	named := decode[dto.Ticket](t, rec)
+	if named.HolderName != "Ana Macuácua" || named.HolderEmail != guest.Email || !named.HolderCopy || named.UserID != buyer.ID {
+		t.Fatalf("named ticket = %+v", named)
+	}
+	expectTokenHolder(t, named.Token, "Ana Macuácua")
+
+	t.Run("invalid holders", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			body  map[string]any
+			field string
+		}{
+			{"email without name", map[string]any{"holder_email": "ana@example.com"}, "holder_name"},
+			{"copy without email", map[string]any{"holder_name": "Ana", "holder_copy": true}, "holder_email"},
+			{"invalid email", map[string]any{"holder_name": "Ana", "holder_email": "ana"}, "holder_email"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.body["event_id"] = event.ID
+				body := expectError(t, s.do("POST", "/tickets", buyer.Token, tt.body), http.StatusBadRequest, "validation_failed")
+				if body.Fields[tt.field] == "" {
+					t.Fatalf("fields = %v, want %s", body.Fields, tt.field)
+				}
+			})
+		}
+	})
+
+	t.Run("shared with the holder", func(t *testing.T) {
+		rec := s.do("GET", "/tickets/shared", guest.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		expectTotal(t, rec, 1)
+		if shared := decode[[]dto.Ticket](t, rec); shared[0].ID != named.ID || shared[0].Token != named.Token {
+			t.Fatalf("shared tickets = %+v", shared)
+		}
+
+		// O ticket continua sendo do comprador
+		expectTotal(t, s.do("GET", "/tickets", guest.Token, nil), 0)
+		expectTotal(t, s.do("GET", "/tickets/shared", buyer.Token, nil), 0)
+	})
+
+	t.Run("copy requires a verified email", func(t *testing.T) {
+		// Cópia para um email que ainda não tem conta
+		rec := s.do("POST", "/tickets", buyer.Token, map[string]any{
+			"event_id": event.ID, "holder_name": "Célia Mondlane", "holder_email": uniqueEmail("titular"), "holder_copy": true,
+		})
+		expectStatus(t, rec, http.StatusOK)
+		ticket := decode[dto.Ticket](t, rec)
+
+		// Trocar o email da conta para o do titular não dá acesso à cópia
+		attacker := s.newUser("buyer")
+		expectStatus(t, s.do("PUT", "/user", attacker.Token, map[string]string{"name": attacker.Name, "email": ticket.HolderEmail}), http.StatusOK)
+		expectError(t, s.do("GET", "/tickets/shared", attacker.Token, nil), http.StatusForbidden, "account_email_not_verified")
+	})
+
+	t.Run("attendee list shows the holder", func(t *testing.T) {
+		rec := s.do("GET", "/events/"+event.ID.String()+"/attendees?search="+url.QueryEscape("macuácua"), organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		attendees := decode[[]dto.Attendee](t, rec)
+		if len(attendees) != 1 || attendees[0].HolderName != "Ana Macuácua" || attendees[0].Email != guest.Email || attendees[0].BuyerEmail != buyer.Email {
+			t.Fatalf("attendees = %+v", attendees)
+		}
+	})
+
+	t.Run("change the holder", func(t *testing.T) {
+		path := "/tickets/" + named.ID.String() + "/holder"
+		expectError(t, s.do("PUT", path, guest.Token, map[string]any{"holder_name": "Outra pessoa"}), http.StatusNotFound, "ticket_not_found")
+
+		rec := s.do("PUT", path, buyer.Token, map[string]any{"holder_name": "Bruno Sitoe"})
+		expectStatus(t, rec, http.StatusOK)
+		changed := decode[dto.Ticket](t, rec)
+		if changed.HolderName != "Bruno Sitoe" || changed.HolderEmail != "" || changed.HolderCopy || changed.Token == named.Token {
+			t.Fatalf("changed ticket = %+v", changed)
+		}
+		expectTokenHolder(t, changed.Token, "Bruno Sitoe")
+
+		// O QR Code anterior deixa de valer e a cópia do antigo titular some
+		expectError(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": named.Token}), http.StatusNotFound, "ticket_not_found")
+		expectTotal(t, s.do("GET", "/tickets/shared", guest.Token, nil), 0)
+
+		// Sem nome, o titular volta a ser o comprador
+		rec = s.do("PUT", path, buyer.Token, map[string]any{})
+		expectStatus(t, rec, http.StatusOK)
+		reset := decode[dto.Ticket](t, rec)
+		if reset.HolderName != buyer.Name || reset.HolderEmail != buyer.Email {
+			t.Fatalf("reset ticket = %+v", reset)
+		}
+		expectTokenHolder(t, reset.Token, buyer.Name)
+	})
+
+	t.Run("used tickets keep their holder", func(t *testing.T) {
+		expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": own.Token}), http.StatusOK)
+		rec := s.do("PUT", "/tickets/"+own.ID.String()+"/holder", buyer.Token, map[string]any{"holder_name": "Tarde demais"})
+		expectError(t, rec, http.StatusConflict, "ticket_already_used")
+	})
+
+	t.Run("changes close before the event", func(t *testing.T) {
+		closed := s.createNamedEvent(organizer, time.Now().Add(24*time.Hour), 48, false)
+		ticket := s.buyTicket(buyer, closed.ID)
+		rec := s.do("PUT", "/tickets/"+ticket.ID.String()+"/holder", buyer.Token, map[string]any{"holder_name": "Carla"})
+		expectError(t, rec, http.StatusConflict, "holder_change_closed")
+
+		expectStatus(t, s.do("PUT", "/tickets/"+uuid.NewString()+"/holder", buyer.Token, map[string]any{}), http.StatusNotFound)
+	})
+}
+
+func TestHolderCheckAtGate(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	buy := func(eventID uuid.UUID) dto.Ticket {
+		rec := s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": eventID, "holder_name": "Dércio  Cossa"})
+		expectStatus(t, rec, http.StatusOK)
+		return decode[dto.Ticket](t, rec)
+	}
+
+	t.Run("optional check", func(t *testing.T) {
+		event := s.createNamedEvent(organizer, time.Now().Add(30*24*time.Hour), 0, false)
+		ticket := buy(event.ID)
+
+		body := map[string]string{"token": ticket.Token, "holder_name": "Outra Pessoa"}
+		expectError(t, s.do("POST", "/tickets/validate", organizer.Token, body), http.StatusConflict, "holder_mismatch")
+		// Sem o nome, o ticket entra como antes
+		expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": ticket.Token}), http.StatusOK)
+	})
+
+	t.Run("required by the event", func(t *testing.T) {
+		event := s.createNamedEvent(organizer, time.Now().Add(30*24*time.Hour), 0, true)
+		ticket := buy(event.ID)
+
+		body := expectError(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": ticket.Token}), http.StatusBadRequest, "validation_failed")
+		if body.Fields["holder_name"] == "" {
+			t.Fatalf("fields = %v, want holder_name", body.Fields)
+		}
+
+		// O nome é comparado sem diferenciar maiúsculas e espaços
+		rec := s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": ticket.Token, "holder_name": " dércio cossa "})
+		expectStatus(t, rec, http.StatusOK)
+		if validated := decode[dto.Ticket](t, rec); validated.Status != "usado" {
+			t.Fatalf("status = %q, want usado", validated.Status)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package routes_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"src/dto"
+	"src/services"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// Gera uma imagem PNG de teste com as dimensões informadas
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatalf("encoding PNG: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+// Envia um arquivo como imagem do evento (multipart/form-data)
+func (s *testServer) uploadImage(user testUser, eventID uuid.UUID, kind string, content []byte) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if kind != "" {
+		form.WriteField("kind", kind)
+	}
+	part, err := form.CreateFormFile("image", "foto.png")
+	if err != nil {
+		s.t.Fatalf("creating form file: %v", err)
+	}
+	part.Write(content)
+	form.Close()
+
+	return s.do("POST", "/events/"+eventID.String()+"/images", user.Token, body.Bytes(),
+		header{"Content-Type": form.FormDataContentType()})
+}
+
+// Busca o arquivo pelo endereço devolvido na API e confere que é uma imagem válida
+func (s *testServer) fetchImage(url string) image.Config {
+	s.t.Helper()
+
+	rec := s.do("GET", url, "", nil)
+	expectStatus(s.t, rec, http.StatusOK)
+	if rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+		s.t.Fatalf("missing nosniff header on %s", url)
+	}
+	config, _, err := image.DecodeConfig(rec.Body)
+	if err != nil {
+		s.t.Fatalf("decoding %s: %v", url, err)
+	}
+	return config
+}
+
+func TestUploadEventImages(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	event := s.createEvent(organizer, "Festival de Jazz")
+
+	rec := s.uploadImage(organizer, event.ID, "cover", testPNG(t, 1600, 800))
+	expectStatus(t, rec, http.StatusCreated)
+	cover := decode[dto.Image](t, rec)
+	if cover.Width != 1600 || cover.Height != 800 {
+		t.Fatalf("cover = %+v", cover)
+	}
+
+	// O original fica como foi enviado e as miniaturas mantêm a proporção
+	if config := s.fetchImage(cover.URL); config.Width != 1600 || config.Height != 800 {
+		t.Fatalf("original is %dx%d", config.Width, config.Height)
+	}
+	if config := s.fetchImage(cover.ThumbnailURL); config.Width != 320 || config.Height != 160 {
+		t.Fatalf("thumbnail is %dx%d", config.Width, config.Height)
+	}
+	if config := s.fetchImage(cover.MediumURL); config.Width != 1024 || config.Height != 512 {
+		t.Fatalf("medium is %dx%d", config.Width, config.Height)
+	}
+
+	// Sem o campo kind, a imagem vai para a galeria; JPEG também é aceito
+	var photo bytes.Buffer
+	jpeg.Encode(&photo, image.NewGray(image.Rect(0, 0, 200, 300)), nil)
+	rec = s.uploadImage(organizer, event.ID, "", photo.Bytes())
+	expectStatus(t, rec, http.StatusCreated)
+	gallery := decode[dto.Image](t, rec)
+	if !strings.HasSuffix(gallery.URL, ".jpg") {
+		t.Fatalf("gallery URL = %s", gallery.URL)
+	}
+
+	rec = s.do("GET", "/events/"+event.ID.String(), organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	got := decode[dto.Event](t, rec)
+	if got.Cover == nil || got.Cover.ID != cover.ID || len(got.Gallery) != 1 || got.Gallery[0].ID != gallery.ID {
+		t.Fatalf("event images = %+v, %+v", got.Cover, got.Gallery)
+	}
+
+	t.Run("new cover replaces the previous one", func(t *testing.T) {
+		rec := s.uploadImage(organizer, event.ID, "cover", testPNG(t, 100, 100))
+		expectStatus(t, rec, http.StatusCreated)
+		replacement := decode[dto.Image](t, rec)
+
+		rec = s.do("GET", "/events/"+event.ID.String(), organizer.Token, nil)
+		if got := decode[dto.Event](t, rec); got.Cover == nil || got.Cover.ID != replacement.ID {
+			t.Fatalf("cover = %+v, want %s", got.Cover, replacement.ID)
+		}
+		expectError(t, s.do("GET", cover.URL, "", nil), http.StatusNotFound, "media_not_found")
+
+		// Imagens menores que a miniatura não são ampliadas
+		if config := s.fetchImage(replacement.ThumbnailURL); config.Width != 100 || config.Height != 100 {
+			t.Fatalf("thumbnail is %dx%d", config.Width, config.Height)
+		}
+	})
+
+	t.Run("delete removes the record and the files", func(t *testing.T) {
+		rec := s.do("DELETE", "/events/"+event.ID.String()+"/images/"+gallery.ID.String(), organizer.Token, nil)
+		expectStatus(t, rec, http.StatusNoContent)
+		expectError(t, s.do("GET", gallery.ThumbnailURL, "", nil), http.StatusNotFound, "media_not_found")
+
+		rec = s.do("DELETE", "/events/"+event.ID.String()+"/images/"+gallery.ID.String(), organizer.Token, nil)
+		expectError(t, rec, http.StatusNotFound, "image_not_found")
+	})
+
+	t.Run("deleting the event removes its files", func(t *testing.T) {
+		other := s.createEvent(organizer, "Concerto")
+		rec := s.uploadImage(organizer, other.ID, "cover", testPNG(t, 10, 10))
+		expectStatus(t, rec, http.StatusCreated)
+		image := decode[dto.Image](t, rec)
+
+		expectStatus(t, s.do("DELETE", "/events/"+other.ID.String(), organizer.Token, nil), http.StatusNoContent)
+		expectError(t, s.do("GET", image.URL, "", nil), http.StatusNotFound, "media_not_found")
+	})
+}
+
+func TestUploadEventImageValidation(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	event := s.createEvent(organizer, "Feira do Livro")
+
+	t.Run("unsupported type", func(t *testing.T) {
+		rec := s.uploadImage(organizer, event.ID, "cover", []byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"))
+		body := expectError(t, rec, http.StatusBadRequest, "invalid_image")
+		if body.Fields["image"] == "" {
+			t.Fatalf("fields = %v", body.Fields)
+		}
+	})
+
+	t.Run("truncated image", func(t *testing.T) {
+		content := testPNG(t, 50, 50)
+		rec := s.uploadImage(organizer, event.ID, "cover", content[:len(content)/2])
+		expectError(t, rec, http.StatusBadRequest, "invalid_image")
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		rec := s.uploadImage(organizer, event.ID, "banner", testPNG(t, 10, 10))
+		body := expectError(t, rec, http.StatusBadRequest, "validation_failed")
+		if body.Fields["kind"] == "" {
+			t.Fatalf("fields = %v", body.Fields)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		content := append(testPNG(t, 10, 10), make([]byte, services.MaxImageSize)...)
+		rec := s.uploadImage(organizer, event.ID, "gallery", content)
+		expectError(t, rec, http.StatusRequestEntityTooLarge, "image_too_large")
+	})
+
+	t.Run("only the organizer can upload", func(t *testing.T) {
+		other := s.newUser("organizer")
+		rec := s.uploadImage(other, event.ID, "cover", testPNG(t, 10, 10))
+		expectStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("media keys cannot leave the storage directory", func(t *testing.T) {
+		rec := s.do("GET", "/media/events/..%2F..%2Fetc%2Fpasswd", "", nil)
+		if rec.Code == http.StatusOK {
+			t.Fatalf("status = %d, body: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
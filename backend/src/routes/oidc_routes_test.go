@@ -0,0 +1,349 @@
+package routes_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"src/config"
+	"src/database"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const mockClientID = "ticketing-test"
+
+// Identidade devolvida pelo emissor de teste
+type mockIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Pedido de autorização aceito pelo emissor, à espera da troca do código
+type mockGrant struct {
+	identity  mockIdentity
+	nonce     string
+	challenge string
+}
+
+// Emissor OpenID Connect mínimo (descoberta, JWKS e token) para os testes
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]mockGrant
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &mockIssuer{key: key, grants: map[string]mockGrant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/token", issuer.token)
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// Configuração que registra o emissor de teste como provedor "mock"
+func (i *mockIssuer) provider(cfg *config.Config) {
+	cfg.OIDCProviders = append(cfg.OIDCProviders, config.OIDCProvider{
+		Name:         "mock",
+		IssuerURL:    i.server.URL,
+		ClientID:     mockClientID,
+		ClientSecret: "segredo",
+		RedirectURL:  "http://localhost:8080/auth/oidc/mock/callback",
+	})
+}
+
+func (i *mockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                i.server.URL,
+		"authorization_endpoint":                i.server.URL + "/authorize",
+		"token_endpoint":                        i.server.URL + "/token",
+		"jwks_uri":                              i.server.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (i *mockIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+}
+
+// Troca o código pelo ID token, verificando o PKCE como um provedor real
+func (i *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+
+	i.mu.Lock()
+	grant, ok := i.grants[r.PostForm.Get("code")]
+	delete(i.grants, r.PostForm.Get("code"))
+	i.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != grant.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            i.server.URL,
+		"aud":            mockClientID,
+		"sub":            grant.identity.Subject,
+		"email":          grant.identity.Email,
+		"email_verified": grant.identity.EmailVerified,
+		"name":           grant.identity.Name,
+		"nonce":          grant.nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	idToken.Header["kid"] = "test"
+	signed, err := idToken.SignedString(i.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+// Simula o usuário autorizando no provedor: devolve o state e o código do retorno
+func (i *mockIssuer) authorize(t *testing.T, authURL string, identity mockIdentity) (string, string) {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("client_id") != mockClientID || query.Get("code_challenge_method") != "S256" || query.Get("nonce") == "" {
+		t.Fatalf("unexpected authorization URL: %s", authURL)
+	}
+
+	code := "code-" + identity.Subject + "-" + query.Get("state")[:8]
+	i.mu.Lock()
+	i.grants[code] = mockGrant{identity: identity, nonce: query.Get("nonce"), challenge: query.Get("code_challenge")}
+	i.mu.Unlock()
+
+	return query.Get("state"), code
+}
+
+// Executa o login social completo e devolve a resposta do callback
+func (s *testServer) oidcLogin(issuer *mockIssuer, identity mockIdentity) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	rec := s.do("GET", "/auth/oidc/mock/login", "", nil)
+	expectStatus(s.t, rec, http.StatusFound)
+
+	state, code := issuer.authorize(s.t, rec.Header().Get("Location"), identity)
+	return s.do("GET", "/auth/oidc/mock/callback?"+url.Values{"state": {state}, "code": {code}}.Encode(), "", nil)
+}
+
+type oidcLoginResponse struct {
+	Token string        `json:"token"`
+	User  database.User `json:"user"`
+}
+
+func TestOIDCProviders(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s := newTestServer(t, issuer.provider)
+
+	rec := s.do("GET", "/auth/oidc/providers", "", nil)
+	expectStatus(t, rec, http.StatusOK)
+	if providers := decode[map[string][]string](t, rec)["providers"]; len(providers) != 1 || providers[0] != "mock" {
+		t.Fatalf("providers = %v, want [mock]", providers)
+	}
+
+	expectStatus(t, s.do("GET", "/auth/oidc/desconhecido/login", "", nil), http.StatusNotFound)
+}
+
+func TestOIDCLoginCreatesBuyer(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s := newTestServer(t, issuer.provider)
+	identity := mockIdentity{Subject: "sub-1", Email: uniqueEmail("social"), EmailVerified: true, Name: "Social"}
+
+	rec := s.oidcLogin(issuer, identity)
+	expectStatus(t, rec, http.StatusOK)
+	first := decode[oidcLoginResponse](t, rec)
+	if first.User.Email != identity.Email || first.User.Role != "buyer" || !first.User.EmailVerified {
+		t.Fatalf("unexpected user: %+v", first.User)
+	}
+
+	// O token emitido é uma sessão normal da API
+	expectStatus(t, s.do("GET", "/user", first.Token, nil), http.StatusOK)
+
+	// Um segundo login com a mesma identidade reutiliza o usuário
+	rec = s.oidcLogin(issuer, identity)
+	expectStatus(t, rec, http.StatusOK)
+	if second := decode[oidcLoginResponse](t, rec); second.User.ID != first.User.ID {
+		t.Fatalf("second login created another user: %s != %s", second.User.ID, first.User.ID)
+	}
+}
+
+func TestOIDCLoginLinksExistingAccount(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s := newTestServer(t, issuer.provider)
+	// A ligação automática só acontece com o email da conta verificado
+	organizer := s.newUser("organizer")
+
+	rec := s.oidcLogin(issuer, mockIdentity{Subject: "sub-2", Email: organizer.Email, EmailVerified: true})
+	expectStatus(t, rec, http.StatusOK)
+	if user := decode[oidcLoginResponse](t, rec).User; user.ID != organizer.ID || user.Role != "organizer" {
+		t.Fatalf("login did not link to the existing organizer: %+v", user)
+	}
+}
+
+func TestOIDCProviderOffline(t *testing.T) {
+	offline := httptest.NewServer(http.NotFoundHandler())
+	offline.Close()
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.OIDCProviders = append(cfg.OIDCProviders, config.OIDCProvider{Name: "offline", IssuerURL: offline.URL, ClientID: mockClientID})
+	})
+
+	// A falha da descoberta não expõe o endereço nem o erro do provedor
+	body := expectError(t, s.do("GET", "/auth/oidc/offline/login", "", nil), http.StatusBadGateway, "identity_provider_unavailable")
+	if body.Message != "identity provider is unavailable" {
+		t.Fatalf("message = %q, want the generic message", body.Message)
+	}
+}
+
+func TestOIDCLinkToUnverifiedAccount(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s := newTestServer(t, issuer.provider)
+
+	// A conta local tem o email sem verificação: quem a registrou pode não ser o dono do email
+	victimEmail := uniqueEmail("vitima")
+	rec := s.do("POST", "/register", "", map[string]string{"name": "Conta local", "email": victimEmail, "password": testPassword, "role": "buyer"})
+	expectStatus(t, rec, http.StatusCreated)
+	account := decode[database.User](t, rec)
+	identity := mockIdentity{Subject: "sub-victim", Email: victimEmail, EmailVerified: true, Name: "Vítima"}
+
+	// O login social com o mesmo email não entra na conta nem a liga automaticamente
+	linkRequired := func() string {
+		rec := s.oidcLogin(issuer, identity)
+		expectStatus(t, rec, http.StatusAccepted)
+		response := decode[struct {
+			LinkRequired bool   `json:"link_required"`
+			LinkToken    string `json:"link_token"`
+			Token        string `json:"token"`
+		}](t, rec)
+		if !response.LinkRequired || response.LinkToken == "" || response.Token != "" {
+			t.Fatalf("callback response = %+v, want a pending link without a session", response)
+		}
+		return response.LinkToken
+	}
+	linkToken := linkRequired()
+	linkRequired()
+
+	t.Run("link needs the account password", func(t *testing.T) {
+		body := map[string]string{"link_token": linkToken, "password": "senha-errada"}
+		expectError(t, s.do("POST", "/auth/oidc/link", "", body), http.StatusUnauthorized, "invalid_credentials")
+		expectError(t, s.do("POST", "/auth/oidc/link", "", map[string]string{"link_token": "forjado", "password": testPassword}), http.StatusBadRequest, "invalid_link_token")
+	})
+
+	t.Run("the account password confirms the link", func(t *testing.T) {
+		rec := s.do("POST", "/auth/oidc/link", "", map[string]string{"link_token": linkToken, "password": testPassword})
+		expectStatus(t, rec, http.StatusOK)
+		if user := decode[oidcLoginResponse](t, rec).User; user.ID != account.ID || !user.EmailVerified {
+			t.Fatalf("linked user = %+v, want the verified account %s", user, account.ID)
+		}
+
+		// O token da ligação só vale uma vez e o login social passa a entrar na conta
+		expectError(t, s.do("POST", "/auth/oidc/link", "", map[string]string{"link_token": linkToken, "password": testPassword}), http.StatusBadRequest, "invalid_link_token")
+		rec = s.oidcLogin(issuer, identity)
+		expectStatus(t, rec, http.StatusOK)
+		if user := decode[oidcLoginResponse](t, rec).User; user.ID != account.ID {
+			t.Fatalf("social login user = %s, want %s", user.ID, account.ID)
+		}
+	})
+}
+
+func TestOIDCCallbackErrors(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s := newTestServer(t, issuer.provider)
+
+	withTwoFactor := s.newUser("buyer")
+	s.enableTwoFactor(withTwoFactor)
+
+	t.Run("unverified email", func(t *testing.T) {
+		rec := s.oidcLogin(issuer, mockIdentity{Subject: "sub-3", Email: uniqueEmail("x"), EmailVerified: false})
+		expectStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("account with two-factor enabled", func(t *testing.T) {
+		rec := s.oidcLogin(issuer, mockIdentity{Subject: "sub-4", Email: withTwoFactor.Email, EmailVerified: true})
+		expectStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		expectStatus(t, s.do("GET", "/auth/oidc/mock/callback?state=forjado&code=x", "", nil), http.StatusBadRequest)
+	})
+
+	t.Run("state used twice", func(t *testing.T) {
+		rec := s.do("GET", "/auth/oidc/mock/login", "", nil)
+		state, code := issuer.authorize(t, rec.Header().Get("Location"), mockIdentity{Subject: "sub-5", Email: uniqueEmail("y"), EmailVerified: true})
+		callback := "/auth/oidc/mock/callback?" + url.Values{"state": {state}, "code": {code}}.Encode()
+
+		expectStatus(t, s.do("GET", callback, "", nil), http.StatusOK)
+		expectStatus(t, s.do("GET", callback, "", nil), http.StatusBadRequest)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		// O erro devolvido pelo provedor não é repetido na resposta
+		body := expectError(t, s.do("GET", "/auth/oidc/mock/callback?error=access_denied", "", nil), http.StatusUnauthorized, "social_login_failed")
+		if body.Message != "social login failed" {
+			t.Fatalf("message = %q, want the generic message", body.Message)
+		}
+	})
+
+	t.Run("code rejected by the provider", func(t *testing.T) {
+		rec := s.do("GET", "/auth/oidc/mock/login", "", nil)
+		state, _ := issuer.authorize(t, rec.Header().Get("Location"), mockIdentity{Subject: "sub-6", Email: uniqueEmail("z"), EmailVerified: true})
+		rec = s.do("GET", "/auth/oidc/mock/callback?"+url.Values{"state": {state}, "code": {"forjado"}}.Encode(), "", nil)
+		if body := expectError(t, rec, http.StatusUnauthorized, "social_login_failed"); body.Message != "social login failed" {
+			t.Fatalf("message = %q, want the generic message", body.Message)
+		}
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		expectStatus(t, s.do("GET", "/auth/oidc/desconhecido/callback?state=x&code=y", "", nil), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,225 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"src/database"
+	"src/dto"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Cria um evento do organizador com o local indicado
+func (s *testServer) createEventIn(organizer testUser, name, location string, date time.Time) database.Event {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{
+		"name":     name,
+		"location": location,
+		"date":     date.UTC().Truncate(time.Second),
+	})
+	expectStatus(s.t, rec, http.StatusOK)
+	event := decode[database.Event](s.t, rec)
+	event.Status = s.setEventStatus(organizer, event.ID, database.EventPublished)
+	return event
+}
+
+// Verifica o total informado no cabeçalho X-Total-Count
+func expectTotal(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if got := rec.Header().Get("X-Total-Count"); got != strconv.Itoa(want) {
+		t.Fatalf("X-Total-Count = %q, want %d", got, want)
+	}
+}
+
+// Percorre todas as páginas seguindo X-Next-Cursor e devolve os nomes dos eventos
+func (s *testServer) eventPages(path, token string) (names []string, pages int) {
+	s.t.Helper()
+
+	cursor := ""
+	for {
+		target := path
+		if cursor != "" {
+			target += "&cursor=" + url.QueryEscape(cursor)
+		}
+		rec := s.do("GET", target, token, nil)
+		expectStatus(s.t, rec, http.StatusOK)
+
+		for _, event := range decode[[]database.Event](s.t, rec) {
+			names = append(names, event.Name)
+		}
+		pages++
+
+		if cursor = rec.Header().Get("X-Next-Cursor"); cursor == "" {
+			return names, pages
+		}
+		if pages > 10 {
+			s.t.Fatalf("pagination of %s does not end", path)
+		}
+	}
+}
+
+func TestEventPagination(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	start := time.Now().Add(24 * time.Hour)
+	for i, name := range []string{"Carnaval", "Ballet", "Expo", "Auto Show", "Dança"} {
+		s.createEventAt(organizer, name, start.Add(time.Duration(i)*24*time.Hour))
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		want  []string
+		pages int
+	}{
+		{"by date", "/events?limit=2", []string{"Carnaval", "Ballet", "Expo", "Auto Show", "Dança"}, 3},
+		{"by date descending", "/events?limit=2&sort=-date", []string{"Dança", "Auto Show", "Expo", "Ballet", "Carnaval"}, 3},
+		{"by name", "/events?limit=3&sort=name", []string{"Auto Show", "Ballet", "Carnaval", "Dança", "Expo"}, 2},
+		{"single page", "/events?limit=5", []string{"Carnaval", "Ballet", "Expo", "Auto Show", "Dança"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, pages := s.eventPages(tt.path, organizer.Token)
+			if len(names) != len(tt.want) || pages != tt.pages {
+				t.Fatalf("got %v in %d pages, want %v in %d pages", names, pages, tt.want, tt.pages)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", names, tt.want)
+				}
+			}
+		})
+	}
+
+	rec := s.do("GET", "/events?limit=2", organizer.Token, nil)
+	expectTotal(t, rec, 5)
+}
+
+func TestEventFilters(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	other := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	day := time.Now().AddDate(0, 1, 0).UTC().Truncate(24 * time.Hour)
+	s.createEventIn(organizer, "Jazz", "Maputo", day.Add(20*time.Hour))
+	s.createEventIn(organizer, "Rock", "Beira", day.AddDate(0, 0, 1).Add(20*time.Hour))
+	s.createEventIn(other, "Samba", "Cidade de Maputo", day.AddDate(0, 0, 2).Add(20*time.Hour))
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"location", buyer.Token, "/events/future?location=maputo", 2},
+		{"organizer", buyer.Token, "/events/future?organizer=" + organizer.ID.String(), 2},
+		{"date range includes the whole last day", buyer.Token, "/events/future?date_from=" + day.Format("2006-01-02") + "&date_to=" + day.AddDate(0, 0, 1).Format("2006-01-02"), 2},
+		{"date from timestamp", buyer.Token, "/events/future?date_from=" + day.AddDate(0, 0, 2).Format(time.RFC3339), 1},
+		{"combined", buyer.Token, "/events/future?location=maputo&organizer=" + other.ID.String(), 1},
+		{"organizer's own events", organizer.Token, "/events?location=beira", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", tt.path, tt.token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			expectTotal(t, rec, tt.want)
+
+			if events := decode[[]database.Event](t, rec); len(events) != tt.want {
+				t.Fatalf("got %d events, want %d", len(events), tt.want)
+			}
+		})
+	}
+}
+
+func TestTicketPaginationAndFilters(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	concert := s.createEvent(organizer, "Concerto")
+	theatre := s.createEventAt(organizer, "Teatro", time.Now().Add(10*24*time.Hour))
+
+	first := s.buyTicket(buyer, concert.ID)
+	s.buyTicket(buyer, concert.ID)
+	s.buyTicket(buyer, theatre.ID)
+	expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": first.Token}), http.StatusOK)
+
+	tests := []struct {
+		name      string
+		query     string
+		want      int
+		total     int
+		hasCursor bool
+	}{
+		{"first page", "?limit=2", 2, 3, true},
+		{"by status", "?status=usado", 1, 1, false},
+		{"by event", "?event_id=" + concert.ID.String(), 2, 2, false},
+		{"by event date", "?date_to=" + time.Now().Add(20*24*time.Hour).Format(time.RFC3339), 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", "/tickets"+tt.query, buyer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			expectTotal(t, rec, tt.total)
+
+			if tickets := decode[[]dto.Ticket](t, rec); len(tickets) != tt.want {
+				t.Fatalf("got %d tickets, want %d", len(tickets), tt.want)
+			}
+			if hasCursor := rec.Header().Get("X-Next-Cursor") != ""; hasCursor != tt.hasCursor {
+				t.Fatalf("X-Next-Cursor present = %v, want %v", hasCursor, tt.hasCursor)
+			}
+		})
+	}
+
+	// Ordenados pela data do evento, o ticket do teatro vem primeiro
+	rec := s.do("GET", "/tickets?sort=event_date", buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if tickets := decode[[]dto.Ticket](t, rec); tickets[0].EventID != theatre.ID {
+		t.Fatalf("first ticket by event date is for %s, want %s", tickets[0].EventID, theatre.ID)
+	}
+}
+
+func TestInvalidListParameters(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	s.createEvent(organizer, "Concerto")
+	s.createEvent(organizer, "Teatro")
+
+	dateCursor := s.do("GET", "/events?limit=1", organizer.Token, nil).Header().Get("X-Next-Cursor")
+	if dateCursor == "" {
+		t.Fatal("first page has no cursor")
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		field string
+	}{
+		{"limit not a number", organizer.Token, "/events?limit=dez", "limit"},
+		{"limit too large", organizer.Token, "/events?limit=1000", "limit"},
+		{"unknown sort", organizer.Token, "/events?sort=price", "sort"},
+		{"malformed cursor", organizer.Token, "/events?cursor=nao-e-cursor", "cursor"},
+		{"cursor from another sort", organizer.Token, "/events?sort=name&cursor=" + url.QueryEscape(dateCursor), "cursor"},
+		{"invalid date", buyer.Token, "/events/future?date_from=amanha", "date_from"},
+		{"invalid organizer", buyer.Token, "/events/future?organizer=nao-e-uuid", "organizer"},
+		{"unknown ticket status", buyer.Token, "/tickets?status=perdido", "status"},
+		{"unknown ticket sort", buyer.Token, "/tickets?sort=name", "sort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := expectError(t, s.do("GET", tt.path, tt.token, nil), http.StatusBadRequest, "validation_failed")
+			if body.Fields[tt.field] == "" {
+				t.Fatalf("fields = %v, want an error for %q", body.Fields, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"testing"
+	"time"
+)
+
+// Cria um festival que começou ontem e termina depois de amanhã, com as vendas abertas
+func (s *testServer) createOngoingFestival(organizer testUser) database.Event {
+	s.t.Helper()
+
+	end := time.Now().Add(48 * time.Hour)
+	festival := database.Event{
+		Name:        "Festival da Marrabenta",
+		Location:    "Maputo",
+		Date:        time.Now().Add(-24 * time.Hour),
+		EndDate:     &end,
+		OrganizerID: organizer.ID,
+		Status:      database.EventSalesOpen,
+	}
+	if err := s.repos.Events.Create(&festival); err != nil {
+		s.t.Fatal(err)
+	}
+	return festival
+}
+
+func TestFestivalPasses(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	festival := s.createOngoingFestival(organizer)
+
+	t.Run("multi-day events", func(t *testing.T) {
+		date := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+		for endDate, want := range map[time.Time]int{
+			date.Add(-time.Hour):     http.StatusBadRequest,
+			date.AddDate(0, 0, 40):   http.StatusBadRequest,
+			date.Add(72 * time.Hour): http.StatusOK,
+		} {
+			body := map[string]any{"name": "Festival", "location": "Maputo", "date": date, "end_date": endDate}
+			expectStatus(t, s.do("POST", "/events", organizer.Token, body), want)
+		}
+	})
+
+	t.Run("passes need a multi-day event", func(t *testing.T) {
+		event := s.createEvent(organizer, "Concerto")
+		body := map[string]any{"event_id": event.ID, "type": "pass"}
+		expectError(t, s.do("POST", "/tickets", buyer.Token, body), http.StatusBadRequest, "pass_not_available")
+
+		body["type"] = "vip"
+		expectError(t, s.do("POST", "/tickets", buyer.Token, body), http.StatusBadRequest, "validation_failed")
+	})
+
+	t.Run("one check-in per day", func(t *testing.T) {
+		rec := s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": festival.ID, "type": "pass"})
+		expectStatus(t, rec, http.StatusOK)
+		pass := decode[dto.Ticket](t, rec)
+		if pass.Type != database.TicketPass {
+			t.Fatalf("ticket type = %q, want pass", pass.Type)
+		}
+
+		// O passe já entrou ontem; hoje ainda pode entrar uma vez
+		yesterday := database.DayIn(time.Now().Add(-24*time.Hour), time.UTC)
+		if _, err := s.repos.Tickets.CheckIn(&database.TicketCheckIn{TicketID: pass.ID, Day: yesterday}); err != nil {
+			t.Fatal(err)
+		}
+
+		rec = s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": pass.Token})
+		expectStatus(t, rec, http.StatusOK)
+		got := decode[dto.Ticket](t, rec)
+		today := database.DayIn(time.Now(), time.UTC).Format(time.DateOnly)
+		if got.Status != "valido" || len(got.CheckIns) != 2 || got.CheckIns[0] != yesterday.Format(time.DateOnly) || got.CheckIns[1] != today {
+			t.Fatalf("validated pass = %+v", got)
+		}
+
+		expectError(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": pass.Token}), http.StatusConflict, "ticket_checked_in_today")
+	})
+
+	t.Run("single tickets still enter once", func(t *testing.T) {
+		ticket := s.buyTicket(buyer, festival.ID)
+		expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": ticket.Token}), http.StatusOK)
+		expectError(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": ticket.Token}), http.StatusConflict, "ticket_already_used")
+	})
+
+	t.Run("outside the festival days", func(t *testing.T) {
+		date := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+		rec := s.do("POST", "/events", organizer.Token, map[string]any{"name": "Festival de Outono", "location": "Maputo", "date": date, "end_date": date.Add(48 * time.Hour)})
+		expectStatus(t, rec, http.StatusOK)
+		upcoming := decode[dto.Event](t, rec)
+		s.setEventStatus(organizer, upcoming.ID, database.EventSalesOpen)
+
+		rec = s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": upcoming.ID, "type": "pass"})
+		expectStatus(t, rec, http.StatusOK)
+		pass := decode[dto.Ticket](t, rec)
+		expectError(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": pass.Token}), http.StatusConflict, "ticket_not_valid_today")
+	})
+}
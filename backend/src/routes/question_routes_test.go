@@ -0,0 +1,234 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cria uma pergunta no evento pela API
+func (s *testServer) createQuestion(organizer testUser, eventID uuid.UUID, body map[string]any) dto.Question {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events/"+eventID.String()+"/questions", organizer.Token, body)
+	expectStatus(s.t, rec, http.StatusCreated)
+	return decode[dto.Question](s.t, rec)
+}
+
+func TestEventQuestions(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Conferência")
+	questionsPath := "/events/" + event.ID.String() + "/questions"
+
+	company := s.createQuestion(organizer, event.ID, map[string]any{"label": " Empresa ", "kind": "text"})
+	size := s.createQuestion(organizer, event.ID, map[string]any{
+		"label": "Tamanho da camiseta", "kind": "single_choice", "required": true, "options": []string{"P", "M", "G"},
+	})
+	if company.Label != "Empresa" || company.Position != 0 || len(company.Options) != 0 || company.Required {
+		t.Fatalf("company question = %+v", company)
+	}
+	if size.Position != 1 || strings.Join(size.Options, ",") != "P,M,G" || !size.Required {
+		t.Fatalf("size question = %+v", size)
+	}
+
+	// O comprador vê as perguntas na ordem do formulário
+	rec := s.do("GET", questionsPath, buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if questions := decode[[]dto.Question](t, rec); len(questions) != 2 || questions[0].ID != company.ID || questions[1].ID != size.ID {
+		t.Fatalf("questions = %+v", questions)
+	}
+
+	t.Run("update and reorder", func(t *testing.T) {
+		rec := s.do("PUT", questionsPath+"/"+company.ID.String(), organizer.Token, map[string]any{
+			"label": "Empresa ou instituição", "kind": "text", "required": true, "position": 5,
+		})
+		expectStatus(t, rec, http.StatusOK)
+		if updated := decode[dto.Question](t, rec); updated.Label != "Empresa ou instituição" || !updated.Required || updated.Position != 5 {
+			t.Fatalf("updated question = %+v", updated)
+		}
+
+		rec = s.do("GET", questionsPath, buyer.Token, nil)
+		if questions := decode[[]dto.Question](t, rec); questions[0].ID != size.ID || questions[1].ID != company.ID {
+			t.Fatalf("questions after reordering = %+v", questions)
+		}
+	})
+
+	t.Run("invalid questions", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			body  map[string]any
+			field string
+		}{
+			{"missing label", map[string]any{"label": " ", "kind": "text"}, "label"},
+			{"unknown kind", map[string]any{"label": "Idade", "kind": "number"}, "kind"},
+			{"choice with one option", map[string]any{"label": "Almoço", "kind": "single_choice", "options": []string{"Sim"}}, "options"},
+			{"text with options", map[string]any{"label": "Cargo", "kind": "text", "options": []string{"A", "B"}}, "options"},
+			{"repeated option", map[string]any{"label": "Dieta", "kind": "multi_choice", "options": []string{"Vegana", " Vegana"}}, "options"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				body := expectError(t, s.do("POST", questionsPath, organizer.Token, tt.body), http.StatusBadRequest, "validation_failed")
+				if body.Fields[tt.field] == "" {
+					t.Fatalf("fields = %v, want %s", body.Fields, tt.field)
+				}
+			})
+		}
+	})
+
+	t.Run("only the team that edits the event", func(t *testing.T) {
+		coOrganizer, scanner := s.newUser("buyer"), s.newUser("buyer")
+		s.addTeamMember(organizer, event.ID, coOrganizer, "co-organizer")
+		s.addTeamMember(organizer, event.ID, scanner, "scanner")
+		body := map[string]any{"label": "Cidade", "kind": "text"}
+
+		created := s.createQuestion(coOrganizer, event.ID, body)
+		expectStatus(t, s.do("POST", questionsPath, scanner.Token, body), http.StatusForbidden)
+		expectStatus(t, s.do("POST", questionsPath, buyer.Token, body), http.StatusForbidden)
+		expectStatus(t, s.do("PUT", questionsPath+"/"+created.ID.String(), buyer.Token, body), http.StatusForbidden)
+		expectStatus(t, s.do("DELETE", questionsPath+"/"+created.ID.String(), buyer.Token, nil), http.StatusForbidden)
+
+		expectStatus(t, s.do("DELETE", questionsPath+"/"+created.ID.String(), organizer.Token, nil), http.StatusNoContent)
+		expectError(t, s.do("DELETE", questionsPath+"/"+created.ID.String(), organizer.Token, nil), http.StatusNotFound, "question_not_found")
+	})
+
+	t.Run("questions belong to their event", func(t *testing.T) {
+		other := s.createEvent(organizer, "Outro evento")
+		path := "/events/" + other.ID.String() + "/questions/" + size.ID.String()
+		expectError(t, s.do("PUT", path, organizer.Token, map[string]any{"label": "X", "kind": "text"}), http.StatusNotFound, "question_not_found")
+		expectStatus(t, s.do("GET", "/events/"+uuid.NewString()+"/questions", buyer.Token, nil), http.StatusNotFound)
+	})
+
+	t.Run("drafts are hidden from buyers", func(t *testing.T) {
+		// Os eventos são criados como rascunho
+		rec := s.do("POST", "/events", organizer.Token, map[string]any{
+			"name": "Rascunho", "location": "Maputo", "date": time.Now().Add(30 * 24 * time.Hour),
+		})
+		expectStatus(t, rec, http.StatusOK)
+		draft := decode[dto.Event](t, rec)
+		if draft.Status != database.EventDraft {
+			t.Fatalf("status = %q, want draft", draft.Status)
+		}
+		expectStatus(t, s.do("GET", "/events/"+draft.ID.String()+"/questions", buyer.Token, nil), http.StatusNotFound)
+		expectStatus(t, s.do("GET", "/events/"+draft.ID.String()+"/questions", organizer.Token, nil), http.StatusOK)
+	})
+}
+
+func TestBuyTicketWithAnswers(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Conferência")
+
+	company := s.createQuestion(organizer, event.ID, map[string]any{"label": "Empresa", "kind": "text"})
+	size := s.createQuestion(organizer, event.ID, map[string]any{
+		"label": "Tamanho da camiseta", "kind": "single_choice", "required": true, "options": []string{"P", "M", "G"},
+	})
+	diet := s.createQuestion(organizer, event.ID, map[string]any{
+		"label": "Restrições alimentares", "kind": "multi_choice", "options": []string{"Vegetariana", "Sem glúten", "Sem lactose"},
+	})
+
+	t.Run("invalid answers", func(t *testing.T) {
+		unknown := uuid.New()
+		tests := []struct {
+			name     string
+			answers  []map[string]any
+			question uuid.UUID
+			message  string
+		}{
+			{"required question", nil, size.ID, "is required"},
+			{"blank required answer", []map[string]any{{"question_id": size.ID, "value": "  "}}, size.ID, "is required"},
+			{"unknown option", []map[string]any{{"question_id": size.ID, "value": "XG"}}, size.ID, "must be one of: P, M, G"},
+			{"several values for a single choice", []map[string]any{{"question_id": size.ID, "values": []string{"P", "M"}}}, size.ID, "must have a single value"},
+			{"repeated option", []map[string]any{
+				{"question_id": size.ID, "value": "M"}, {"question_id": diet.ID, "values": []string{"Sem glúten", "Sem glúten"}},
+			}, diet.ID, "must not repeat an option"},
+			{"answered twice", []map[string]any{
+				{"question_id": size.ID, "value": "M"}, {"question_id": company.ID, "value": "A"}, {"question_id": company.ID, "value": "B"},
+			}, company.ID, "must be answered only once"},
+			{"unknown question", []map[string]any{{"question_id": size.ID, "value": "M"}, {"question_id": unknown, "value": "A"}}, unknown, "is not a question of this event"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rec := s.do("POST", "/tickets", buyer.Token, map[string]any{"event_id": event.ID, "answers": tt.answers})
+				body := expectError(t, rec, http.StatusBadRequest, "validation_failed")
+				if got := body.Fields["answers["+tt.question.String()+"]"]; got != tt.message {
+					t.Fatalf("fields = %v, want %q for %s", body.Fields, tt.message, tt.question)
+				}
+			})
+		}
+
+		rec := s.do("GET", "/tickets", buyer.Token, nil)
+		expectTotal(t, rec, 0)
+	})
+
+	rec := s.do("POST", "/tickets", buyer.Token, map[string]any{
+		"event_id": event.ID,
+		"answers": []map[string]any{
+			{"question_id": company.ID, "value": " Acme "},
+			{"question_id": size.ID, "value": "M"},
+			{"question_id": diet.ID, "values": []string{"Sem lactose", "Vegetariana"}},
+		},
+	})
+	expectStatus(t, rec, http.StatusOK)
+	ticket := decode[dto.Ticket](t, rec)
+	answers := map[uuid.UUID]string{}
+	for _, answer := range ticket.Answers {
+		answers[answer.QuestionID] = strings.Join(answer.Values, ",")
+	}
+	if len(answers) != 3 || answers[company.ID] != "Acme" || answers[size.ID] != "M" || !strings.Contains(answers[diet.ID], "Sem lactose") {
+		t.Fatalf("answers = %+v", ticket.Answers)
+	}
+
+	// Só a pergunta obrigatória precisa de resposta
+	expectStatus(t, s.do("POST", "/tickets", buyer.Token, map[string]any{
+		"event_id": event.ID, "answers": []map[string]any{{"question_id": size.ID, "value": "G"}},
+	}), http.StatusOK)
+
+	t.Run("answers in the attendee list and export", func(t *testing.T) {
+		rec := s.do("GET", "/events/"+event.ID.String()+"/attendees", organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		if attendees := decode[[]dto.Attendee](t, rec); len(attendees) != 2 || len(attendees[0].Answers) != 3 || len(attendees[1].Answers) != 1 {
+			t.Fatalf("attendees = %+v", attendees)
+		}
+
+		rec = s.do("GET", "/events/"+event.ID.String()+"/attendees/export", organizer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		rows := readCSV(t, rec.Body.Bytes())
+		columns := len(dto.AttendeeColumns)
+		if len(rows) != 3 || strings.Join(rows[0][columns:], "|") != "Empresa|Tamanho da camiseta|Restrições alimentares" {
+			t.Fatalf("header = %v", rows[0])
+		}
+		// As opções marcadas seguem a ordem da pergunta
+		if got := strings.Join(rows[1][columns:], "|"); got != "Acme|M|Vegetariana, Sem lactose" {
+			t.Fatalf("first row answers = %q", got)
+		}
+		if got := strings.Join(rows[2][columns:], "|"); got != "|G|" {
+			t.Fatalf("second row answers = %q", got)
+		}
+	})
+
+	t.Run("deleting a question removes its answers", func(t *testing.T) {
+		expectStatus(t, s.do("DELETE", "/events/"+event.ID.String()+"/questions/"+diet.ID.String(), organizer.Token, nil), http.StatusNoContent)
+
+		rec := s.do("GET", "/events/"+event.ID.String()+"/attendees/export", organizer.Token, nil)
+		rows := readCSV(t, rec.Body.Bytes())
+		if len(rows[0]) != len(dto.AttendeeColumns)+2 || len(rows[1]) != len(rows[0]) {
+			t.Fatalf("rows = %v", rows)
+		}
+		rec = s.do("GET", "/tickets", buyer.Token, nil)
+		for _, ticket := range decode[[]dto.Ticket](t, rec) {
+			for _, answer := range ticket.Answers {
+				if answer.QuestionID == diet.ID {
+					t.Fatalf("answer to deleted question kept: %+v", ticket.Answers)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Percorre as respostas que incluem usuários e verifica que só os campos públicos aparecem
+func TestResponsesNeverExposePasswordHash(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	ticket := s.buyTicket(buyer, event.ID)
+	scanner := s.newUser("buyer")
+	s.addTeamMember(organizer, event.ID, scanner, "scanner")
+
+	stored, err := s.repos.Users.FindByID(organizer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   any
+	}{
+		{"register", "POST", "/register", "", map[string]string{"name": "Ana", "email": uniqueEmail("ana"), "password": testPassword, "role": "buyer"}},
+		{"login", "POST", "/login", "", map[string]string{"email": organizer.Email, "password": testPassword}},
+		{"profile", "GET", "/user", organizer.Token, nil},
+		{"profile update", "PUT", "/user", buyer.Token, map[string]string{"name": "Comprador", "email": buyer.Email}},
+		{"organizer events", "GET", "/events", organizer.Token, nil},
+		{"future events", "GET", "/events/future", buyer.Token, nil},
+		{"event", "GET", "/events/" + event.ID.String(), buyer.Token, nil},
+		{"tickets", "GET", "/tickets", buyer.Token, nil},
+		{"validate ticket", "POST", "/tickets/validate", scanner.Token, map[string]string{"token": ticket.Token}},
+		{"team", "GET", "/events/" + event.ID.String() + "/members", organizer.Token, nil},
+	}
+
+	for _, tt := range requests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(tt.method, tt.path, tt.token, tt.body)
+			if rec.Code >= 300 {
+				t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+			}
+
+			body := rec.Body.String()
+			for _, secret := range []string{stored.Password, `"Password"`, `"TOTPSecret"`} {
+				if strings.Contains(body, secret) {
+					t.Fatalf("response exposes %s: %s", secret, body)
+				}
+			}
+		})
+	}
+}
+
+func TestEventOrganizerIsTrimmed(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	rec := s.do("GET", "/events/"+event.ID.String(), buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	response := decode[struct {
+		Organizer map[string]json.RawMessage
+	}](t, rec)
+	if len(response.Organizer) != 2 || response.Organizer["ID"] == nil || response.Organizer["Name"] == nil {
+		t.Fatalf("organizer = %s, want only ID and Name", rec.Body.String())
+	}
+}
@@ -3,53 +3,159 @@ package routes
 import (
 	"github.com/gorilla/mux"
 	"src/controllers"
+	"src/services"
 )
 
 // Configura as rotas
-func SetupRoutes() *mux.Router {
+func SetupRoutes(svc *services.Services) *mux.Router {
 	router := mux.NewRouter()
+	h := controllers.New(svc)
 
 	// Rota para registrar um usuário
-	router.HandleFunc("/register", controllers.RegisterUser).Methods("POST")
+	router.HandleFunc("/register", h.RegisterUser).Methods("POST")
 
 	// Rota para fazer login
-	router.HandleFunc("/login", controllers.LoginUser).Methods("POST")
+	router.HandleFunc("/login", h.LoginUser).Methods("POST")
+
+	// Rotas de login social (OpenID Connect)
+	router.HandleFunc("/auth/oidc/providers", h.GetOIDCProviders).Methods("GET")
+	router.HandleFunc("/auth/oidc/{provider}/login", h.StartOIDCLogin).Methods("GET")
+	router.HandleFunc("/auth/oidc/{provider}/callback", h.OIDCCallback).Methods("GET")
+	router.HandleFunc("/auth/oidc/link", h.ConfirmOIDCLink).Methods("POST")
 
 	// Rota protegida: retorna o nome do usuário logado
-	router.HandleFunc("/hello", controllers.HelloHandler).Methods("GET")
+	router.HandleFunc("/hello", h.HelloHandler).Methods("GET")
 
 	// Rota para atualizar informações do usuário
-	router.HandleFunc("/user", controllers.UpdateUser).Methods("PUT")
+	router.HandleFunc("/user", h.UpdateUser).Methods("PUT")
 
 	// Rota para alterar a senha
-	router.HandleFunc("/user/password", controllers.ChangePassword).Methods("PUT")
+	router.HandleFunc("/user/password", h.ChangePassword).Methods("PUT")
 
 	// Rota para obter informações do usuário
-	router.HandleFunc("/user", controllers.GetUserInfo).Methods("GET")
+	router.HandleFunc("/user", h.GetUserInfo).Methods("GET")
+
+	// Rotas para verificar o email da conta: reenviar o link e confirmar com o token do link
+	router.HandleFunc("/user/email/verification", h.ResendEmailVerification).Methods("POST")
+	router.HandleFunc("/user/email/verify", h.VerifyEmail).Methods("POST")
+
+	// Rotas para ativar e desativar a autenticação de dois fatores (TOTP)
+	router.HandleFunc("/user/2fa/enroll", h.EnrollTwoFactor).Methods("POST")
+	router.HandleFunc("/user/2fa/confirm", h.ConfirmTwoFactor).Methods("POST")
+	router.HandleFunc("/user/2fa/disable", h.DisableTwoFactor).Methods("POST")
+
+	// Rotas para ativar e desativar a assinatura do calendário com os eventos dos tickets
+	router.HandleFunc("/user/calendar", h.EnableCalendarFeed).Methods("POST")
+	router.HandleFunc("/user/calendar", h.DisableCalendarFeed).Methods("DELETE")
+
+	// Rota pública da assinatura do calendário (webcal), protegida pelo token na URL
+	router.HandleFunc("/calendar/{token}.ics", h.GetCalendarFeed).Methods("GET")
 
 	// Rota para criar um evento (protegida)
-	router.HandleFunc("/events", controllers.CreateEvent).Methods("POST")
+	router.HandleFunc("/events", h.CreateEvent).Methods("POST")
 
 	// Rota para listar eventos de um organizador (protegida)
-	router.HandleFunc("/events", controllers.GetEvents).Methods("GET")
+	router.HandleFunc("/events", h.GetEvents).Methods("GET")
 	
 	// Rota para Obter todos eventos (protegida)
-	router.HandleFunc("/events/future", controllers.GetFutureEvents).Methods("GET")
+	router.HandleFunc("/events/future", h.GetFutureEvents).Methods("GET")
+
+	// Rota para buscar eventos futuros pelo texto (protegida)
+	router.HandleFunc("/events/search", h.SearchEvents).Methods("GET")
+
+	// Rota para buscar eventos futuros próximos a um ponto (protegida)
+	router.HandleFunc("/events/nearby", h.GetNearbyEvents).Methods("GET")
+
+	// Rota para baixar um evento no formato iCalendar (antes da rota do evento, que também casaria com ".ics")
+	router.HandleFunc("/events/{id}.ics", h.GetEventCalendar).Methods("GET")
 
 	// Rota para buscar um evento específico
-	router.HandleFunc("/events/{eventID}", controllers.GetEvent).Methods("GET")
+	router.HandleFunc("/events/{eventID}", h.GetEvent).Methods("GET")
 
 	// Rota para atualizar um evento (protegida)
-	router.HandleFunc("/events/{id}", controllers.UpdateEvent).Methods("PUT")
+	router.HandleFunc("/events/{id}", h.UpdateEvent).Methods("PUT")
+
+	// Rota para mudar o estado do evento: publicar, abrir e fechar vendas, cancelar e encerrar (protegida)
+	router.HandleFunc("/events/{id}/status", h.ChangeEventStatus).Methods("POST")
 
 	// Rota para deletar um evento (protegida)
-	router.HandleFunc("/events/{id}", controllers.DeleteEvent).Methods("DELETE")
+	router.HandleFunc("/events/{id}", h.DeleteEvent).Methods("DELETE")
+
+	// Rotas para gerir a equipe do evento (co-organizadores, financeiro e portaria)
+	router.HandleFunc("/events/{id}/members", h.InviteEventMember).Methods("POST")
+	router.HandleFunc("/events/{id}/members", h.GetEventMembers).Methods("GET")
+	router.HandleFunc("/events/{id}/members/{memberID}", h.RemoveEventMember).Methods("DELETE")
+
+	// Rota para o resumo de vendas do evento (organizador, co-organizador e financeiro)
+	router.HandleFunc("/events/{id}/summary", h.GetEventSummary).Methods("GET")
+
+	// Rota para o painel de vendas do evento (organizador, co-organizador e financeiro)
+	router.HandleFunc("/events/{id}/stats", h.GetEventStats).Methods("GET")
+
+	// Rotas para a lista de participantes do evento e a exportação em CSV ou XLSX (organizador e co-organizador)
+	router.HandleFunc("/events/{id}/attendees", h.GetEventAttendees).Methods("GET")
+	router.HandleFunc("/events/{id}/attendees/export", h.ExportEventAttendees).Methods("GET")
+
+	// Rotas para as perguntas do cadastro dos participantes, respondidas na compra do ticket
+	router.HandleFunc("/events/{id}/questions", h.GetEventQuestions).Methods("GET")
+	router.HandleFunc("/events/{id}/questions", h.CreateEventQuestion).Methods("POST")
+	router.HandleFunc("/events/{id}/questions/{questionID}", h.UpdateEventQuestion).Methods("PUT")
+	router.HandleFunc("/events/{id}/questions/{questionID}", h.DeleteEventQuestion).Methods("DELETE")
+
+	// Rotas para a capa e a galeria de imagens do evento (protegidas)
+	router.HandleFunc("/events/{id}/images", h.UploadEventImage).Methods("POST")
+	router.HandleFunc("/events/{id}/images/{imageID}", h.DeleteEventImage).Methods("DELETE")
+
+	// Rota pública para os arquivos enviados (armazenamento local)
+	router.HandleFunc("/media/{key:.+}", h.GetMedia).Methods("GET")
+
+	// Rotas para o usuário ver e aceitar convites para equipes de eventos
+	router.HandleFunc("/invitations", h.GetInvitations).Methods("GET")
+	router.HandleFunc("/invitations/{id}/accept", h.AcceptInvitation).Methods("POST")
 
 	// Rota para criar um ticket (protegida)
-	router.HandleFunc("/tickets", controllers.CreateTicket).Methods("POST")
+	router.HandleFunc("/tickets", h.CreateTicket).Methods("POST")
 
 	// Rota para obter informações de tickets (protegida)
-	router.HandleFunc("/tickets", controllers.GetTickets).Methods("GET")
+	router.HandleFunc("/tickets", h.GetTickets).Methods("GET")
+
+	// Rota para validar um ticket na entrada do evento (JWT ou chave de API)
+	router.HandleFunc("/tickets/validate", h.ValidateTicket).Methods("POST")
+
+	// Rota para listar os tickets compartilhados com o usuário como titular
+	router.HandleFunc("/tickets/shared", h.GetSharedTickets).Methods("GET")
+
+	// Rota para o comprador trocar o titular do ticket
+	router.HandleFunc("/tickets/{id}/holder", h.UpdateTicketHolder).Methods("PUT")
+
+	// Rotas para gerir as chaves de API do organizador
+	router.HandleFunc("/api-keys", h.CreateAPIKey).Methods("POST")
+	router.HandleFunc("/api-keys", h.GetAPIKeys).Methods("GET")
+	router.HandleFunc("/api-keys/{id}", h.RevokeAPIKey).Methods("DELETE")
+
+	// Rota para o administrador rever as tentativas de login falhadas
+	router.HandleFunc("/admin/login-attempts", h.GetLoginAttempts).Methods("GET")
+
+	// Rota para listar as categorias com a quantidade de eventos (protegida)
+	router.HandleFunc("/categories", h.GetCategories).Methods("GET")
+
+	// Rotas para o administrador gerir as categorias
+	router.HandleFunc("/admin/categories", h.CreateCategory).Methods("POST")
+	router.HandleFunc("/admin/categories/{id}", h.UpdateCategory).Methods("PUT")
+	router.HandleFunc("/admin/categories/{id}", h.DeleteCategory).Methods("DELETE")
+
+	// Rotas para as séries de eventos recorrentes; as ocorrências futuras são alteradas de uma vez
+	router.HandleFunc("/series", h.CreateSeries).Methods("POST")
+	router.HandleFunc("/series/{id}", h.GetSeries).Methods("GET")
+	router.HandleFunc("/series/{id}/occurrences", h.UpdateSeriesOccurrences).Methods("PUT")
+	router.HandleFunc("/series/{id}", h.DeleteSeries).Methods("DELETE")
+
+	// Rotas para gerir os locais de eventos (cadastro por organizadores)
+	router.HandleFunc("/venues", h.CreateVenue).Methods("POST")
+	router.HandleFunc("/venues", h.GetVenues).Methods("GET")
+	router.HandleFunc("/venues/{id}", h.GetVenue).Methods("GET")
+	router.HandleFunc("/venues/{id}", h.UpdateVenue).Methods("PUT")
+	router.HandleFunc("/venues/{id}", h.DeleteVenue).Methods("DELETE")
 
 	return router
 }
@@ -0,0 +1,151 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/url"
+	"src/config"
+	"src/database"
+	"src/dto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cria um evento com a descrição indicada
+func (s *testServer) createEventWithDescription(organizer testUser, name, description, location string, date time.Time) database.Event {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{
+		"name":        name,
+		"description": description,
+		"location":    location,
+		"date":        date.UTC().Truncate(time.Second),
+	})
+	expectStatus(s.t, rec, http.StatusOK)
+	event := decode[database.Event](s.t, rec)
+	event.Status = s.setEventStatus(organizer, event.ID, database.EventPublished)
+	return event
+}
+
+func TestSearchEvents(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	festival := s.createEventWithDescription(organizer, "Festival de Jazz", "Três noites de música ao vivo", "Maputo", time.Now().Add(10*24*time.Hour))
+	blues := s.createEventWithDescription(organizer, "Noite de Blues", "Blues e jazz <b>acústico</b>", "Beira", time.Now().Add(40*24*time.Hour))
+	fair := s.createEventWithDescription(organizer, "Feira do Livro", "Lançamentos e autógrafos", "Maputo", time.Now().Add(5*24*time.Hour))
+
+	past := database.Event{Name: "Jazz de Ontem", Location: "Maputo", Date: time.Now().Add(-24 * time.Hour), OrganizerID: organizer.ID}
+	if err := s.repos.Events.Create(&past); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []uuid.UUID
+	}{
+		{"ranked by relevance", "q=jazz", []uuid.UUID{festival.ID, blues.ID}},
+		{"stemming", "q=livros", []uuid.UUID{fair.ID}},
+		{"typo", "q=festivl", []uuid.UUID{festival.ID}},
+		{"filtered by location", "q=jazz&location=beira", []uuid.UUID{blues.ID}},
+		{"sorted by date", "q=jazz&sort=-date", []uuid.UUID{blues.ID, festival.ID}},
+		{"no results", "q=teatro", []uuid.UUID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", "/events/search?"+tt.query, buyer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			expectTotal(t, rec, len(tt.want))
+
+			search := decode[dto.EventSearch](t, rec)
+			if len(search.Results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d: %+v", len(search.Results), len(tt.want), search.Results)
+			}
+			for i, result := range search.Results {
+				if result.Event.ID != tt.want[i] {
+					t.Fatalf("result %d = %s, want %s", i, result.Event.Name, tt.want[i])
+				}
+			}
+
+			var faceted int64
+			for _, month := range search.Facets.Months {
+				faceted += month.Count
+			}
+			if faceted != int64(len(tt.want)) {
+				t.Fatalf("month facets = %+v, want %d events", search.Facets.Months, len(tt.want))
+			}
+		})
+	}
+
+	t.Run("highlights", func(t *testing.T) {
+		rec := s.do("GET", "/events/search?q=jazz", buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		results := decode[dto.EventSearch](t, rec).Results
+
+		if !strings.Contains(results[0].Highlight.Name, "<mark>Jazz</mark>") {
+			t.Fatalf("name highlight = %q", results[0].Highlight.Name)
+		}
+		// O texto do evento é escapado; apenas as tags <mark> são HTML
+		description := results[1].Highlight.Description
+		if !strings.Contains(description, "<mark>jazz</mark>") || !strings.Contains(description, "&lt;b&gt;") {
+			t.Fatalf("description highlight = %q", description)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		rec := s.do("GET", "/events/search?q=jazz&limit=1", buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		expectTotal(t, rec, 2)
+		first := decode[dto.EventSearch](t, rec).Results
+
+		cursor := rec.Header().Get("X-Next-Cursor")
+		rec = s.do("GET", "/events/search?q=jazz&limit=1&cursor="+url.QueryEscape(cursor), buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		second := decode[dto.EventSearch](t, rec).Results
+
+		if len(first) != 1 || len(second) != 1 || first[0].Event.ID != festival.ID || second[0].Event.ID != blues.ID {
+			t.Fatalf("pages = %+v and %+v", first, second)
+		}
+		if next := rec.Header().Get("X-Next-Cursor"); next != "" {
+			t.Fatalf("last page has cursor %q", next)
+		}
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		body := expectError(t, s.do("GET", "/events/search", buyer.Token, nil), http.StatusBadRequest, "validation_failed")
+		if body.Fields["q"] == "" {
+			t.Fatalf("fields = %v, want an error for q", body.Fields)
+		}
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		expectStatus(t, s.do("GET", "/events/search?q=jazz", "", nil), http.StatusUnauthorized)
+	})
+}
+
+func TestSearchFacetsTimeZone(t *testing.T) {
+	maputo, err := time.LoadLocation("Africa/Maputo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestServer(t, func(cfg *config.Config) { cfg.TimeZone = maputo })
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	// 00:30 do dia 1 em Maputo ainda é o mês anterior em UTC
+	now := time.Now().In(maputo)
+	date := time.Date(now.Year(), now.Month()+2, 1, 0, 30, 0, 0, maputo)
+	s.createEventWithDescription(organizer, "Réveillon", "Virada do mês", "Maputo", date)
+
+	rec := s.do("GET", "/events/search?q="+url.QueryEscape("réveillon"), buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	months := decode[dto.EventSearch](t, rec).Facets.Months
+	if len(months) != 1 || months[0].Value != date.Format("2006-01") || months[0].Count != 1 {
+		t.Fatalf("month facets = %+v, want %s", months, date.Format("2006-01"))
+	}
+}
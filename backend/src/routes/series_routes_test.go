@@ -0,0 +1,188 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cria uma série do organizador a partir do corpo informado
+func (s *testServer) createSeries(organizer testUser, body map[string]any) dto.Series {
+	s.t.Helper()
+
+	rec := s.do("POST", "/series", organizer.Token, body)
+	expectStatus(s.t, rec, http.StatusCreated)
+	return decode[dto.Series](s.t, rec)
+}
+
+// Próxima segunda-feira às 20h (UTC), a pelo menos uma semana de hoje
+func nextMonday() time.Time {
+	date := time.Now().UTC().AddDate(0, 0, 7)
+	for date.Weekday() != time.Monday {
+		date = date.AddDate(0, 0, 1)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 20, 0, 0, 0, time.UTC)
+}
+
+func TestCreateSeries(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	monday := nextMonday()
+	january := time.Date(time.Now().Year()+1, time.January, 31, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		body map[string]any
+		want []time.Time
+	}{
+		{
+			"weekly on chosen days",
+			map[string]any{"recurrence": "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4", "date": monday},
+			[]time.Time{monday, monday.AddDate(0, 0, 4), monday.AddDate(0, 0, 7), monday.AddDate(0, 0, 11)},
+		},
+		{
+			"every other day until a date",
+			map[string]any{"recurrence": "FREQ=DAILY;INTERVAL=2;UNTIL=" + monday.AddDate(0, 0, 5).Format("20060102"), "date": monday},
+			[]time.Time{monday, monday.AddDate(0, 0, 2), monday.AddDate(0, 0, 4)},
+		},
+		{
+			"monthly skipping short months",
+			map[string]any{"recurrence": "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", "date": january},
+			[]time.Time{january, january.AddDate(0, 2, 0), january.AddDate(0, 4, 0)},
+		},
+		{
+			"explicit dates",
+			map[string]any{"dates": []time.Time{monday.AddDate(0, 0, 3), monday, monday.AddDate(0, 0, 3)}},
+			[]time.Time{monday, monday.AddDate(0, 0, 3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.body["name"] = "Noite de Comédia"
+			tt.body["location"] = "Maputo"
+			tt.body["capacity"] = 50
+			series := s.createSeries(organizer, tt.body)
+
+			if len(series.Occurrences) != len(tt.want) {
+				t.Fatalf("got %d occurrences, want %d", len(series.Occurrences), len(tt.want))
+			}
+			for i, occurrence := range series.Occurrences {
+				event := occurrence.Event
+				if !event.Date.Equal(tt.want[i]) {
+					t.Fatalf("occurrence %d on %s, want %s", i, event.Date, tt.want[i])
+				}
+				if event.Status != database.EventDraft || event.Capacity != 50 || event.SeriesID == nil || *event.SeriesID != series.ID {
+					t.Fatalf("occurrence %d = %+v", i, event)
+				}
+				if occurrence.Available == nil || *occurrence.Available != 50 {
+					t.Fatalf("occurrence %d available = %v, want 50", i, occurrence.Available)
+				}
+			}
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		body  map[string]any
+		field string
+	}{
+		{"no dates", map[string]any{}, "recurrence"},
+		{"rule and dates", map[string]any{"recurrence": "FREQ=DAILY;COUNT=2", "date": monday, "dates": []time.Time{monday}}, "dates"},
+		{"rule without start", map[string]any{"recurrence": "FREQ=DAILY;COUNT=2"}, "date"},
+		{"unsupported frequency", map[string]any{"recurrence": "FREQ=YEARLY;COUNT=2", "date": monday}, "recurrence"},
+		{"endless rule", map[string]any{"recurrence": "FREQ=WEEKLY", "date": monday}, "recurrence"},
+		{"too many occurrences", map[string]any{"recurrence": "FREQ=DAILY;UNTIL=" + monday.AddDate(1, 0, 0).Format("20060102"), "date": monday}, "recurrence"},
+		{"past date", map[string]any{"dates": []time.Time{time.Now().Add(-time.Hour)}}, "dates[0]"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.body["name"] = "Noite de Comédia"
+			tt.body["location"] = "Maputo"
+			body := expectError(t, s.do("POST", "/series", organizer.Token, tt.body), http.StatusBadRequest, "validation_failed")
+			if body.Fields[tt.field] == "" {
+				t.Fatalf("fields = %v, want an error for %s", body.Fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestSeriesOccurrences(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	other := s.newUser("organizer")
+	buyers := []testUser{s.newUser("buyer"), s.newUser("buyer"), s.newUser("buyer")}
+
+	series := s.createSeries(organizer, map[string]any{
+		"name":       "Noite de Comédia",
+		"location":   "Maputo",
+		"recurrence": "FREQ=WEEKLY;COUNT=4",
+		"date":       nextMonday(),
+	})
+	path := "/series/" + series.ID.String()
+	first, second := series.Occurrences[0].Event, series.Occurrences[1].Event
+
+	// Enquanto as ocorrências são rascunhos, a série só aparece para o organizador
+	expectError(t, s.do("GET", path, buyers[0].Token, nil), http.StatusNotFound, "series_not_found")
+
+	t.Run("bulk edit", func(t *testing.T) {
+		expectError(t, s.do("PUT", path+"/occurrences", other.Token, map[string]any{"capacity": 2}), http.StatusForbidden, "series_permission_denied")
+
+		rec := s.do("PUT", path+"/occurrences", organizer.Token, map[string]any{"capacity": 2, "status": "sales_open"})
+		expectStatus(t, rec, http.StatusOK)
+		if events := decode[[]dto.Event](t, rec); len(events) != 4 || events[3].Status != database.EventSalesOpen || events[3].Capacity != 2 {
+			t.Fatalf("updated occurrences = %+v", events)
+		}
+
+		// Apenas as ocorrências a partir de from mudam
+		rec = s.do("PUT", path+"/occurrences", organizer.Token, map[string]any{"name": "Comédia de Inverno", "from": second.Date})
+		expectStatus(t, rec, http.StatusOK)
+		if events := decode[[]dto.Event](t, rec); len(events) != 3 {
+			t.Fatalf("updated %d occurrences, want 3", len(events))
+		}
+
+		rec = s.do("GET", path, buyers[0].Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		occurrences := decode[dto.Series](t, rec).Occurrences
+		if len(occurrences) != 4 || occurrences[0].Event.Name != "Noite de Comédia" || occurrences[1].Event.Name != "Comédia de Inverno" {
+			t.Fatalf("occurrences after bulk edit = %+v", occurrences)
+		}
+
+		body := expectError(t, s.do("PUT", path+"/occurrences", organizer.Token, map[string]any{"name": " "}), http.StatusBadRequest, "validation_failed")
+		if body.Fields["name"] == "" {
+			t.Fatalf("fields = %v, want an error for name", body.Fields)
+		}
+		expectError(t, s.do("PUT", path+"/occurrences", organizer.Token, map[string]any{"status": "draft"}), http.StatusConflict, "invalid_status_transition")
+	})
+
+	t.Run("capacity per occurrence", func(t *testing.T) {
+		s.buyTicket(buyers[0], first.ID)
+		s.buyTicket(buyers[1], first.ID)
+		expectError(t, s.do("POST", "/tickets", buyers[2].Token, map[string]any{"event_id": first.ID}), http.StatusConflict, "event_sold_out")
+		s.buyTicket(buyers[2], second.ID)
+
+		rec := s.do("GET", path, buyers[0].Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		occurrences := decode[dto.Series](t, rec).Occurrences
+		if *occurrences[0].Available != 0 || occurrences[1].Sold != 1 || *occurrences[1].Available != 1 {
+			t.Fatalf("occurrences after sales = %+v", occurrences)
+		}
+
+		expectError(t, s.do("PUT", path+"/occurrences", organizer.Token, map[string]any{"capacity": 1}), http.StatusConflict, "capacity_below_sold")
+		body := map[string]any{"name": first.Name, "location": "Maputo", "date": first.Date, "capacity": 1}
+		expectError(t, s.do("PUT", "/events/"+first.ID.String(), organizer.Token, body), http.StatusConflict, "capacity_below_sold")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		expectError(t, s.do("DELETE", path, other.Token, nil), http.StatusForbidden, "series_permission_denied")
+		expectStatus(t, s.do("DELETE", path, organizer.Token, nil), http.StatusNoContent)
+		expectError(t, s.do("GET", path, organizer.Token, nil), http.StatusNotFound, "series_not_found")
+		expectStatus(t, s.do("GET", "/events/"+first.ID.String(), organizer.Token, nil), http.StatusNotFound)
+		expectStatus(t, s.do("DELETE", "/series/"+uuid.NewString(), organizer.Token, nil), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,123 @@
+package routes_test
+
+import (
+	"math"
+	"net/http"
+	"src/database"
+	"src/dto"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Registra um pagamento do ticket direto no repositório (os pagamentos vêm do M-Pesa)
+func (s *testServer) addPayment(ticket database.Ticket, amount float64, status string) {
+	s.t.Helper()
+
+	payment := database.Payment{TicketID: ticket.ID, UserID: ticket.UserID, Amount: amount, Status: status}
+	if err := s.repos.Payments.Create(&payment); err != nil {
+		s.t.Fatalf("creating payment: %v", err)
+	}
+}
+
+func TestEventStats(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{
+		"name":     "Concerto",
+		"location": "Maputo",
+		"date":     time.Now().Add(30 * 24 * time.Hour),
+		"capacity": 10,
+	})
+	expectStatus(t, rec, http.StatusOK)
+	event := decode[dto.Event](t, rec)
+	s.setEventStatus(organizer, event.ID, database.EventSalesOpen)
+	statsPath := "/events/" + event.ID.String() + "/stats"
+
+	rec = s.do("GET", statsPath, organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	empty := decode[dto.EventStats](t, rec)
+	if empty.TicketsSold != 0 || empty.CheckInRate != 0 || empty.Remaining == nil || *empty.Remaining != 10 || len(empty.Sales) != 0 {
+		t.Fatalf("stats before sales = %+v", empty)
+	}
+
+	// Quatro tickets: dois pagos (um com dois pagamentos), um pendente e um pago e cancelado
+	used := s.buyTicket(buyer, event.ID)
+	paid := s.buyTicket(buyer, event.ID)
+	pending := s.buyTicket(buyer, event.ID)
+	cancelled := s.buyTicket(buyer, event.ID)
+	s.addPayment(used, 500, "pago")
+	s.addPayment(paid, 300, "pago")
+	s.addPayment(paid, 200, "pago")
+	s.addPayment(pending, 500, "pendente")
+	s.addPayment(cancelled, 500, "pago")
+	if ok, err := s.repos.Tickets.TransitionStatus(cancelled.ID, "valido", "cancelado"); !ok || err != nil {
+		t.Fatalf("cancelling ticket: %v, %v", ok, err)
+	}
+	expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": used.Token}), http.StatusOK)
+
+	rec = s.do("GET", statsPath, organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	stats := decode[dto.EventStats](t, rec)
+
+	if stats.TicketsSold != 3 || stats.SoldByType[database.TicketSingle] != 3 || stats.SoldByType[database.TicketPass] != 0 {
+		t.Fatalf("sold = %d, by type %v", stats.TicketsSold, stats.SoldByType)
+	}
+	if stats.TicketsCheckedIn != 1 || math.Abs(stats.CheckInRate-1.0/3) > 1e-9 {
+		t.Fatalf("checked in = %d, rate %v", stats.TicketsCheckedIn, stats.CheckInRate)
+	}
+	if stats.TicketsCancelled != 1 || stats.Refunds != 1 {
+		t.Fatalf("cancelled = %d, refunds %d", stats.TicketsCancelled, stats.Refunds)
+	}
+	if stats.GrossRevenue != 1500 || stats.RefundedAmount != 500 || stats.NetRevenue != 1000 {
+		t.Fatalf("revenue = %v gross, %v refunded, %v net", stats.GrossRevenue, stats.RefundedAmount, stats.NetRevenue)
+	}
+	if stats.Capacity != 10 || stats.Remaining == nil || *stats.Remaining != 7 {
+		t.Fatalf("capacity = %d, remaining %v", stats.Capacity, stats.Remaining)
+	}
+
+	// Todas as vendas foram agora: um único período do dia, sem o ticket cancelado
+	soldAt := used.CreatedAt.UTC()
+	today := soldAt.Truncate(24 * time.Hour)
+	if stats.Interval != "day" || len(stats.Sales) != 1 {
+		t.Fatalf("sales = %s %+v", stats.Interval, stats.Sales)
+	}
+	if bucket := stats.Sales[0]; !bucket.Start.Equal(today) || bucket.Tickets != 3 || bucket.Revenue != 1000 {
+		t.Fatalf("bucket = %+v, want 3 tickets on %s", bucket, today)
+	}
+
+	t.Run("buckets by hour and month", func(t *testing.T) {
+		hour := soldAt.Truncate(time.Hour)
+		month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		for interval, want := range map[string]time.Time{"hour": hour, "month": month} {
+			rec := s.do("GET", statsPath+"?interval="+interval, organizer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			stats := decode[dto.EventStats](t, rec)
+			if len(stats.Sales) != 1 || !stats.Sales[0].Start.Equal(want) {
+				t.Fatalf("%s buckets = %+v, want start %s", interval, stats.Sales, want)
+			}
+		}
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		body := expectError(t, s.do("GET", statsPath+"?interval=year", organizer.Token, nil), http.StatusBadRequest, "validation_failed")
+		if body.Fields["interval"] == "" {
+			t.Fatalf("fields = %v", body.Fields)
+		}
+	})
+
+	t.Run("only the team with reports access", func(t *testing.T) {
+		finance, scanner := s.newUser("buyer"), s.newUser("buyer")
+		s.addTeamMember(organizer, event.ID, finance, "finance")
+		s.addTeamMember(organizer, event.ID, scanner, "scanner")
+
+		expectStatus(t, s.do("GET", statsPath, finance.Token, nil), http.StatusOK)
+		expectStatus(t, s.do("GET", statsPath, scanner.Token, nil), http.StatusForbidden)
+		expectStatus(t, s.do("GET", statsPath, buyer.Token, nil), http.StatusForbidden)
+		expectStatus(t, s.do("GET", statsPath, "", nil), http.StatusUnauthorized)
+		expectStatus(t, s.do("GET", "/events/"+uuid.NewString()+"/stats", organizer.Token, nil), http.StatusNotFound)
+	})
+}
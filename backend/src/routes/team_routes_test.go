@@ -0,0 +1,251 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"src/database"
+	"src/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInviteEventMember(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	intruder := s.newUser("organizer")
+	event := s.createEvent(organizer, "Concerto")
+	path := "/events/" + event.ID.String() + "/members"
+	invitee := uniqueEmail("porteiro")
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		body  any
+		want  int
+	}{
+		{"owner invites a scanner", organizer.Token, path, map[string]string{"email": invitee, "role": "scanner"}, http.StatusCreated},
+		{"same email twice", organizer.Token, path, map[string]string{"email": invitee, "role": "finance"}, http.StatusConflict},
+		{"unknown role", organizer.Token, path, map[string]string{"email": uniqueEmail("x"), "role": "owner"}, http.StatusBadRequest},
+		{"missing email", organizer.Token, path, map[string]string{"email": " ", "role": "scanner"}, http.StatusBadRequest},
+		{"another organizer", intruder.Token, path, map[string]string{"email": uniqueEmail("x"), "role": "scanner"}, http.StatusForbidden},
+		{"unknown event", organizer.Token, "/events/" + uuid.NewString() + "/members", map[string]string{"email": uniqueEmail("x"), "role": "scanner"}, http.StatusNotFound},
+		{"invalid event ID", organizer.Token, "/events/nao-e-uuid/members", map[string]string{"email": uniqueEmail("x"), "role": "scanner"}, http.StatusBadRequest},
+		{"malformed JSON", organizer.Token, path, `{"email":`, http.StatusBadRequest},
+		{"unauthenticated", "", path, map[string]string{"email": uniqueEmail("x"), "role": "scanner"}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("POST", tt.path, tt.token, tt.body)
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusCreated {
+				if member := decode[database.EventMember](t, rec); member.Status != "pendente" || member.Email != invitee {
+					t.Fatalf("unexpected invitation: %+v", member)
+				}
+			}
+		})
+	}
+}
+
+func TestInvitationFlow(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	member := s.newUser("buyer")
+	stranger := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	rec := s.do("POST", "/events/"+event.ID.String()+"/members", organizer.Token, map[string]string{
+		"email": member.Email,
+		"role":  "co-organizer",
+	})
+	expectStatus(t, rec, http.StatusCreated)
+	invitation := decode[database.EventMember](t, rec)
+
+	// O convite aparece apenas para o dono do email
+	rec = s.do("GET", "/invitations", member.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if invitations := decode[[]database.EventMember](t, rec); len(invitations) != 1 || invitations[0].Event.Name != "Concerto" {
+		t.Fatalf("invitations = %+v, want the Concerto invitation", invitations)
+	}
+	if invitations := decode[[]database.EventMember](t, s.do("GET", "/invitations", stranger.Token, nil)); len(invitations) != 0 {
+		t.Fatalf("stranger sees %d invitations", len(invitations))
+	}
+
+	acceptPath := "/invitations/" + invitation.ID.String() + "/accept"
+	expectStatus(t, s.do("POST", acceptPath, stranger.Token, nil), http.StatusNotFound)
+	expectStatus(t, s.do("POST", "/invitations/nao-e-uuid/accept", member.Token, nil), http.StatusBadRequest)
+	expectStatus(t, s.do("POST", acceptPath, "", nil), http.StatusUnauthorized)
+
+	rec = s.do("POST", acceptPath, member.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if accepted := decode[database.EventMember](t, rec); accepted.Status != "aceito" || accepted.UserID == nil || *accepted.UserID != member.ID {
+		t.Fatalf("accepted invitation = %+v", accepted)
+	}
+
+	// Um convite só pode ser aceito uma vez
+	expectStatus(t, s.do("POST", acceptPath, member.Token, nil), http.StatusNotFound)
+}
+
+func TestInvitationRequiresVerifiedEmail(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	attacker := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	// Convite para um email que ainda não tem conta
+	invitee := uniqueEmail("convidado")
+	rec := s.do("POST", "/events/"+event.ID.String()+"/members", organizer.Token, map[string]string{"email": invitee, "role": "co-organizer"})
+	expectStatus(t, rec, http.StatusCreated)
+	invitation := decode[database.EventMember](t, rec)
+
+	// Trocar o email da conta para o do convidado não dá acesso ao convite
+	expectStatus(t, s.do("PUT", "/user", attacker.Token, map[string]string{"name": attacker.Name, "email": invitee}), http.StatusOK)
+	expectError(t, s.do("GET", "/invitations", attacker.Token, nil), http.StatusForbidden, "account_email_not_verified")
+	expectError(t, s.do("POST", "/invitations/"+invitation.ID.String()+"/accept", attacker.Token, nil), http.StatusForbidden, "account_email_not_verified")
+
+	// O convite continua pendente para o dono do email
+	rec = s.do("GET", "/events/"+event.ID.String()+"/members", organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	for _, member := range decode[[]database.EventMember](t, rec) {
+		if member.ID == invitation.ID && (member.Status != "pendente" || member.UserID != nil) {
+			t.Fatalf("invitation = %+v, want pending", member)
+		}
+	}
+}
+
+func TestListAndRemoveEventMembers(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	coOrganizer := s.newUser("buyer")
+	scanner := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	s.addTeamMember(organizer, event.ID, coOrganizer, "co-organizer")
+	s.addTeamMember(organizer, event.ID, scanner, "scanner")
+	membersPath := "/events/" + event.ID.String() + "/members"
+
+	// Gerir a equipe é exclusivo do organizador, nem o co-organizador pode
+	expectStatus(t, s.do("GET", membersPath, coOrganizer.Token, nil), http.StatusForbidden)
+	expectStatus(t, s.do("GET", membersPath, "", nil), http.StatusUnauthorized)
+
+	rec := s.do("GET", membersPath, organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	members := decode[[]database.EventMember](t, rec)
+	if len(members) != 2 {
+		t.Fatalf("got %d members, want 2", len(members))
+	}
+
+	var scannerMember database.EventMember
+	for _, member := range members {
+		if member.Email == scanner.Email {
+			scannerMember = member
+		}
+	}
+	removePath := membersPath + "/" + scannerMember.ID.String()
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"co-organizer cannot remove", coOrganizer.Token, removePath, http.StatusForbidden},
+		{"invalid member ID", organizer.Token, membersPath + "/nao-e-uuid", http.StatusBadRequest},
+		{"unauthenticated", "", removePath, http.StatusUnauthorized},
+		{"owner removes", organizer.Token, removePath, http.StatusNoContent},
+		{"already removed", organizer.Token, removePath, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("DELETE", tt.path, tt.token, nil), tt.want)
+		})
+	}
+}
+
+func TestTeamRolePermissions(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	members := map[string]testUser{}
+	for _, role := range []string{"co-organizer", "finance", "scanner"} {
+		members[role] = s.newUser("buyer")
+		s.addTeamMember(organizer, event.ID, members[role], role)
+	}
+
+	eventPath := "/events/" + event.ID.String()
+	update := map[string]any{"name": "Concerto", "location": "Maputo", "date": time.Now().Add(48 * time.Hour)}
+
+	tests := []struct {
+		role       string
+		permission string
+		allowed    bool
+	}{
+		{"co-organizer", services.PermissionUpdateEvent, true},
+		{"co-organizer", services.PermissionViewReports, true},
+		{"co-organizer", services.PermissionValidateTickets, true},
+		{"finance", services.PermissionUpdateEvent, false},
+		{"finance", services.PermissionViewReports, true},
+		{"finance", services.PermissionValidateTickets, false},
+		{"scanner", services.PermissionUpdateEvent, false},
+		{"scanner", services.PermissionViewReports, false},
+		{"scanner", services.PermissionValidateTickets, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+" "+tt.permission, func(t *testing.T) {
+			token := members[tt.role].Token
+
+			var rec *httptest.ResponseRecorder
+			switch tt.permission {
+			case services.PermissionUpdateEvent:
+				rec = s.do("PUT", eventPath, token, update)
+			case services.PermissionViewReports:
+				rec = s.do("GET", eventPath+"/summary", token, nil)
+			case services.PermissionValidateTickets:
+				ticket := s.buyTicket(buyer, event.ID)
+				rec = s.do("POST", "/tickets/validate", token, map[string]string{"token": ticket.Token})
+			}
+
+			if allowed := rec.Code == http.StatusOK; allowed != tt.allowed {
+				t.Fatalf("status = %d, want allowed = %v; body: %s", rec.Code, tt.allowed, rec.Body.String())
+			}
+			if !tt.allowed {
+				expectStatus(t, rec, http.StatusForbidden)
+			}
+		})
+	}
+
+	// O co-organizador também pode apagar o evento
+	expectStatus(t, s.do("DELETE", eventPath, members["scanner"].Token, nil), http.StatusForbidden)
+	expectStatus(t, s.do("DELETE", eventPath, members["co-organizer"].Token, nil), http.StatusNoContent)
+}
+
+func TestEventSummary(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	used := s.buyTicket(buyer, event.ID)
+	s.buyTicket(buyer, event.ID)
+	s.buyTicket(buyer, event.ID)
+	expectStatus(t, s.do("POST", "/tickets/validate", organizer.Token, map[string]string{"token": used.Token}), http.StatusOK)
+
+	summaryPath := "/events/" + event.ID.String() + "/summary"
+	expectStatus(t, s.do("GET", summaryPath, buyer.Token, nil), http.StatusForbidden)
+	expectStatus(t, s.do("GET", "/events/"+uuid.NewString()+"/summary", organizer.Token, nil), http.StatusNotFound)
+	expectStatus(t, s.do("GET", "/events/nao-e-uuid/summary", organizer.Token, nil), http.StatusBadRequest)
+	expectStatus(t, s.do("GET", summaryPath, "", nil), http.StatusUnauthorized)
+
+	rec := s.do("GET", summaryPath, organizer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	summary := decode[services.EventSummary](t, rec)
+	if summary.TicketsSold != 3 || summary.TicketsUsed != 1 || summary.TicketsCancelled != 0 {
+		t.Fatalf("summary = %+v, want 3 sold and 1 used", summary)
+	}
+}
@@ -0,0 +1,115 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"src/generator"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBuyTicket(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"happy path", buyer.Token, map[string]any{"event_id": event.ID}, http.StatusOK},
+		{"unknown event", buyer.Token, map[string]any{"event_id": uuid.New()}, http.StatusNotFound},
+		{"invalid event ID", buyer.Token, map[string]any{"event_id": "nao-e-uuid"}, http.StatusBadRequest},
+		{"malformed JSON", buyer.Token, `{"event_id":`, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]any{"event_id": event.ID}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("POST", "/tickets", tt.token, tt.body)
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusOK {
+				ticket := decode[database.Ticket](t, rec)
+				if ticket.Token == "" || ticket.Status != "valido" || ticket.EventID != event.ID || ticket.UserID != buyer.ID {
+					t.Fatalf("unexpected ticket: %+v", ticket)
+				}
+			}
+		})
+	}
+}
+
+func TestListTickets(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	other := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	s.buyTicket(buyer, event.ID)
+	s.buyTicket(buyer, event.ID)
+	s.buyTicket(other, event.ID)
+
+	rec := s.do("GET", "/tickets", buyer.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	tickets := decode[[]database.Ticket](t, rec)
+	if len(tickets) != 2 {
+		t.Fatalf("got %d tickets, want only the buyer's 2", len(tickets))
+	}
+	for _, ticket := range tickets {
+		if ticket.UserID != buyer.ID || ticket.Event.Name != "Concerto" || ticket.Event.Organizer.ID != organizer.ID {
+			t.Fatalf("ticket without the expected relations: %+v", ticket)
+		}
+	}
+
+	expectStatus(t, s.do("GET", "/tickets", "", nil), http.StatusUnauthorized)
+}
+
+func TestValidateTicket(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	otherOrganizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	event := s.createEvent(organizer, "Concerto")
+	ticket := s.buyTicket(buyer, event.ID)
+	forgedToken := s.buyTicket(buyer, s.createEvent(otherOrganizer, "Outro").ID).Token + "x"
+	// Mesmo ticket, assinado com outra chave
+	otherSecretToken, err := generator.NewTicketSigner("outra-chave").GenerateTicketToken(ticket.ID, ticket.EventID, ticket.UserID, buyer.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"buyer cannot validate", buyer.Token, map[string]string{"token": ticket.Token}, http.StatusForbidden},
+		{"organizer of another event", otherOrganizer.Token, map[string]string{"token": ticket.Token}, http.StatusForbidden},
+		{"forged token", organizer.Token, map[string]string{"token": forgedToken}, http.StatusNotFound},
+		{"signed with another secret", organizer.Token, map[string]string{"token": otherSecretToken}, http.StatusNotFound},
+		{"malformed JSON", organizer.Token, `{"token":`, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]string{"token": ticket.Token}, http.StatusUnauthorized},
+		{"event organizer", organizer.Token, map[string]string{"token": ticket.Token}, http.StatusOK},
+		{"ticket already used", organizer.Token, map[string]string{"token": ticket.Token}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("POST", "/tickets/validate", tt.token, tt.body)
+			expectStatus(t, rec, tt.want)
+
+			if tt.want == http.StatusOK {
+				if got := decode[dto.Ticket](t, rec); got.Status != "usado" {
+					t.Fatalf("validated ticket status = %q, want usado", got.Status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"src/config"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+)
+
+// Ativa o 2FA do usuário pelas rotas e devolve o segredo e os códigos de recuperação
+func (s *testServer) enableTwoFactor(user testUser) (string, []string) {
+	s.t.Helper()
+
+	rec := s.do("POST", "/user/2fa/enroll", user.Token, nil)
+	expectStatus(s.t, rec, http.StatusOK)
+	enrollment := decode[struct {
+		Secret     string `json:"secret"`
+		OTPAuthURI string `json:"otpauth_uri"`
+		QRCode     string `json:"qr_code"`
+	}](s.t, rec)
+	if !strings.HasPrefix(enrollment.OTPAuthURI, "otpauth://totp/") || !strings.HasPrefix(enrollment.QRCode, "data:image/png;base64,") {
+		s.t.Fatalf("unexpected enrollment: %+v", enrollment)
+	}
+
+	// Confirma com o código do passo anterior (aceito pela tolerância do relógio), deixando
+	// o código atual livre para o login, já que cada passo só é aceito uma vez
+	previous := totpCodeAt(s.t, enrollment.Secret, time.Now().Add(-30*time.Second))
+	rec = s.do("POST", "/user/2fa/confirm", user.Token, map[string]string{"code": previous})
+	expectStatus(s.t, rec, http.StatusOK)
+	codes := decode[struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}](s.t, rec).RecoveryCodes
+
+	return enrollment.Secret, codes
+}
+
+func totpCode(t *testing.T, secret string) string {
+	t.Helper()
+	return totpCodeAt(t, secret, time.Now())
+}
+
+func totpCodeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(secret, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("organizer")
+	secret, recoveryCodes := s.enableTwoFactor(user)
+	if len(recoveryCodes) != 10 {
+		t.Fatalf("got %d recovery codes, want 10", len(recoveryCodes))
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want int
+	}{
+		{"code missing", "", http.StatusUnauthorized},
+		{"wrong code", "000000", http.StatusUnauthorized},
+		{"TOTP code", totpCode(t, secret), http.StatusOK},
+		{"recovery code", recoveryCodes[0], http.StatusOK},
+		{"recovery code reused", recoveryCodes[0], http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": testPassword, "code": tt.code})
+			if tt.code == "" {
+				// O código do erro indica ao cliente que deve pedir o segundo fator
+				expectError(t, rec, tt.want, "two_factor_required")
+				return
+			}
+			expectStatus(t, rec, tt.want)
+		})
+	}
+}
+
+func TestTwoFactorCodeReplay(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("organizer")
+	secret, _ := s.enableTwoFactor(user)
+
+	// O código de confirmação da ativação já foi usado
+	enrollmentCode := totpCodeAt(t, secret, time.Now().Add(-30*time.Second))
+	login := func(code string) *httptest.ResponseRecorder {
+		return s.do("POST", "/login", "", map[string]string{"email": user.Email, "password": testPassword, "code": code})
+	}
+	expectError(t, login(enrollmentCode), http.StatusUnauthorized, "invalid_two_factor_code")
+
+	// O mesmo código não pode ser reutilizado dentro da sua validade
+	code := totpCode(t, secret)
+	expectStatus(t, login(code), http.StatusOK)
+	expectError(t, login(code), http.StatusUnauthorized, "invalid_two_factor_code")
+	expectError(t, s.do("POST", "/user/2fa/disable", user.Token, map[string]string{"password": testPassword, "code": code}), http.StatusUnauthorized, "invalid_two_factor_code")
+}
+
+func TestTwoFactorEnrollment(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+
+	// Confirmar sem ter iniciado a ativação
+	expectStatus(t, s.do("POST", "/user/2fa/confirm", user.Token, map[string]string{"code": "123456"}), http.StatusBadRequest)
+
+	expectStatus(t, s.do("POST", "/user/2fa/enroll", user.Token, nil), http.StatusOK)
+	expectStatus(t, s.do("POST", "/user/2fa/confirm", user.Token, map[string]string{"code": "000000"}), http.StatusBadRequest)
+	expectStatus(t, s.do("POST", "/user/2fa/confirm", user.Token, `{"code":`), http.StatusBadRequest)
+	expectStatus(t, s.do("POST", "/user/2fa/enroll", "", nil), http.StatusUnauthorized)
+
+	other := s.newUser("buyer")
+	s.enableTwoFactor(other)
+	expectStatus(t, s.do("POST", "/user/2fa/enroll", other.Token, nil), http.StatusConflict)
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+	withoutTwoFactor := s.newUser("buyer")
+	secret, _ := s.enableTwoFactor(user)
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"not enabled", withoutTwoFactor.Token, map[string]string{"password": testPassword, "code": "000000"}, http.StatusConflict},
+		{"wrong password", user.Token, map[string]string{"password": "errada", "code": totpCode(t, secret)}, http.StatusUnauthorized},
+		{"wrong code", user.Token, map[string]string{"password": testPassword, "code": "000000"}, http.StatusUnauthorized},
+		{"malformed JSON", user.Token, `{"password":`, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]string{"password": testPassword, "code": totpCode(t, secret)}, http.StatusUnauthorized},
+		{"password and code", user.Token, map[string]string{"password": testPassword, "code": totpCode(t, secret)}, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("POST", "/user/2fa/disable", tt.token, tt.body), tt.want)
+		})
+	}
+
+	// Sem 2FA o login volta a pedir apenas a senha
+	s.login(user.Email, testPassword)
+}
+
+func TestTwoFactorRequiredForRole(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.TOTPRequiredRoles = []string{"organizer"} })
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	// O token limitado só serve para ativar o 2FA
+	expectStatus(t, s.do("GET", "/events", organizer.Token, nil), http.StatusForbidden)
+	expectStatus(t, s.do("GET", "/events/future", buyer.Token, nil), http.StatusOK)
+
+	secret, _ := s.enableTwoFactor(organizer)
+
+	rec := s.do("POST", "/login", "", map[string]string{"email": organizer.Email, "password": testPassword, "code": totpCode(t, secret)})
+	expectStatus(t, rec, http.StatusOK)
+	response := decode[struct {
+		Token                  string `json:"token"`
+		TwoFactorSetupRequired bool   `json:"two_factor_setup_required"`
+	}](t, rec)
+	if response.TwoFactorSetupRequired {
+		t.Fatal("two_factor_setup_required is still true after enabling 2FA")
+	}
+	expectStatus(t, s.do("GET", "/events", response.Token, nil), http.StatusOK)
+}
@@ -0,0 +1,126 @@
+package routes_test
+
+import (
+	"net/http"
+	"src/database"
+	"src/dto"
+	"testing"
+)
+
+func TestGetUserInfo(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+
+	rec := s.do("GET", "/user", user.Token, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if got := decode[database.User](t, rec); got.ID != user.ID || got.Email != user.Email {
+		t.Fatalf("GET /user returned %+v, want user %s", got, user.Email)
+	}
+
+	expectStatus(t, s.do("GET", "/user", "", nil), http.StatusUnauthorized)
+}
+
+func TestUpdateUser(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+	other := s.newUser("buyer")
+	newEmail := uniqueEmail("novo")
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"updates name and email", user.Token, map[string]string{"name": "Novo Nome", "email": newEmail}, http.StatusOK},
+		{"email of another user", user.Token, map[string]string{"name": "Novo Nome", "email": other.Email}, http.StatusConflict},
+		{"invalid email", user.Token, map[string]string{"name": "Novo Nome", "email": "nao-e-email"}, http.StatusBadRequest},
+		{"malformed JSON", user.Token, `{"name":`, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]string{"name": "X", "email": uniqueEmail("x")}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("PUT", "/user", tt.token, tt.body), tt.want)
+		})
+	}
+
+	// O papel (role) nunca é alterado pela rota
+	got := decode[database.User](t, s.do("GET", "/user", user.Token, nil))
+	if got.Email != newEmail || got.Name != "Novo Nome" || got.Role != "buyer" {
+		t.Fatalf("user after update = %+v", got)
+	}
+}
+
+func TestEmailVerification(t *testing.T) {
+	s := newTestServer(t)
+	user := s.registerUser("buyer")
+
+	rec := s.do("GET", "/user", user.Token, nil)
+	if got := decode[dto.User](t, rec); got.EmailVerified {
+		t.Fatalf("new user = %+v, want an unverified email", got)
+	}
+
+	// Um novo link invalida o anterior
+	first := s.mailToken(user.Email, "/verify-email")
+	expectStatus(t, s.do("POST", "/user/email/verification", user.Token, nil), http.StatusNoContent)
+	expectError(t, s.do("POST", "/user/email/verify", "", map[string]string{"token": first}), http.StatusBadRequest, "invalid_verification_token")
+
+	token := s.mailToken(user.Email, "/verify-email")
+	rec = s.do("POST", "/user/email/verify", "", map[string]string{"token": token})
+	expectStatus(t, rec, http.StatusOK)
+	if got := decode[dto.User](t, rec); got.ID != user.ID || !got.EmailVerified {
+		t.Fatalf("verified user = %+v", got)
+	}
+
+	// O token só pode ser usado uma vez
+	expectError(t, s.do("POST", "/user/email/verify", "", map[string]string{"token": token}), http.StatusBadRequest, "invalid_verification_token")
+	expectError(t, s.do("POST", "/user/email/verification", user.Token, nil), http.StatusConflict, "email_already_verified")
+	expectStatus(t, s.do("POST", "/user/email/verification", "", nil), http.StatusUnauthorized)
+
+	t.Run("changing the email requires a new verification", func(t *testing.T) {
+		newEmail := uniqueEmail("novo")
+		rec := s.do("PUT", "/user", user.Token, map[string]string{"name": user.Name, "email": newEmail})
+		expectStatus(t, rec, http.StatusOK)
+		if got := decode[dto.User](t, rec); got.EmailVerified {
+			t.Fatalf("user after changing the email = %+v, want an unverified email", got)
+		}
+
+		s.verifyEmail(newEmail)
+		if got := decode[dto.User](t, s.do("GET", "/user", user.Token, nil)); !got.EmailVerified {
+			t.Fatalf("user after verifying the new email = %+v", got)
+		}
+
+		// Mudar só o nome mantém a verificação
+		expectStatus(t, s.do("PUT", "/user", user.Token, map[string]string{"name": "Outro Nome", "email": newEmail}), http.StatusOK)
+		if got := decode[dto.User](t, s.do("GET", "/user", user.Token, nil)); !got.EmailVerified {
+			t.Fatalf("user after changing the name = %+v", got)
+		}
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	s := newTestServer(t)
+	user := s.newUser("buyer")
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"wrong old password", user.Token, map[string]string{"old_password": "errada", "new_password": "nova-senha-1"}, http.StatusBadRequest},
+		{"weak new password", user.Token, map[string]string{"old_password": testPassword, "new_password": "curta"}, http.StatusBadRequest},
+		{"malformed JSON", user.Token, `{"old_password":`, http.StatusBadRequest},
+		{"unauthenticated", "", map[string]string{"old_password": testPassword, "new_password": "nova-senha-1"}, http.StatusUnauthorized},
+		{"changes the password", user.Token, map[string]string{"old_password": testPassword, "new_password": "nova-senha-1"}, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, s.do("PUT", "/user/password", tt.token, tt.body), tt.want)
+		})
+	}
+
+	s.login(user.Email, "nova-senha-1")
+}
@@ -0,0 +1,215 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/url"
+	"src/database"
+	"src/dto"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cadastra um local nas coordenadas indicadas
+func (s *testServer) createVenue(organizer testUser, name, city string, latitude, longitude float64) dto.Venue {
+	s.t.Helper()
+
+	rec := s.do("POST", "/venues", organizer.Token, map[string]any{
+		"name":      name,
+		"address":   "Av. 25 de Setembro",
+		"city":      city,
+		"latitude":  latitude,
+		"longitude": longitude,
+	})
+	expectStatus(s.t, rec, http.StatusCreated)
+	return decode[dto.Venue](s.t, rec)
+}
+
+// Cria e publica um evento futuro no local, sem informar o local em texto
+func (s *testServer) createEventAtVenue(organizer testUser, name string, venueID uuid.UUID, date time.Time) dto.Event {
+	s.t.Helper()
+
+	rec := s.do("POST", "/events", organizer.Token, map[string]any{"name": name, "date": date, "venue_id": venueID})
+	expectStatus(s.t, rec, http.StatusOK)
+	event := decode[dto.Event](s.t, rec)
+	event.Status = s.setEventStatus(organizer, event.ID, database.EventPublished)
+	return event
+}
+
+func TestVenueManagement(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	other := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+	admin := s.newAdmin()
+
+	venue := s.createVenue(organizer, "Centro Cultural", "Maputo", -25.9692, 32.5732)
+	s.createVenue(other, "Casa do Artista", "Beira", -19.8436, 34.8389)
+	path := "/venues/" + venue.ID.String()
+	valid := map[string]any{"name": "Centro Cultural Universitário", "address": "Av. Julius Nyerere", "city": "Maputo", "latitude": -25.95, "longitude": 32.6, "capacity": 800, "accessibility": "Rampas e lugares reservados"}
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		body   any
+		want   int
+		field  string
+	}{
+		{"buyer cannot register", buyer.Token, "POST", "/venues", valid, http.StatusForbidden, ""},
+		{"latitude out of range", organizer.Token, "POST", "/venues", map[string]any{"name": "X", "address": "Y", "city": "Z", "latitude": 91, "longitude": 0}, http.StatusBadRequest, "latitude"},
+		{"missing longitude", organizer.Token, "POST", "/venues", map[string]any{"name": "X", "address": "Y", "city": "Z", "latitude": 0}, http.StatusBadRequest, "longitude"},
+		{"negative capacity", organizer.Token, "POST", "/venues", map[string]any{"name": "X", "address": "Y", "city": "Z", "latitude": 0, "longitude": 0, "capacity": -1}, http.StatusBadRequest, "capacity"},
+		{"get", buyer.Token, "GET", path, nil, http.StatusOK, ""},
+		{"get unknown", buyer.Token, "GET", "/venues/" + uuid.NewString(), nil, http.StatusNotFound, ""},
+		{"other organizer cannot update", other.Token, "PUT", path, valid, http.StatusForbidden, ""},
+		{"owner updates", organizer.Token, "PUT", path, valid, http.StatusOK, ""},
+		{"admin updates", admin.Token, "PUT", path, valid, http.StatusOK, ""},
+		{"invalid ID", organizer.Token, "DELETE", "/venues/nao-e-uuid", nil, http.StatusBadRequest, ""},
+		{"other organizer cannot delete", other.Token, "DELETE", path, nil, http.StatusForbidden, ""},
+		{"unauthenticated", "", "GET", "/venues", nil, http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(tt.method, tt.path, tt.token, tt.body)
+			expectStatus(t, rec, tt.want)
+			if tt.field != "" {
+				if body := expectError(t, rec, tt.want, "validation_failed"); body.Fields[tt.field] == "" {
+					t.Fatalf("fields = %v, want an error for %s", body.Fields, tt.field)
+				}
+			}
+		})
+	}
+
+	t.Run("list filtered by city", func(t *testing.T) {
+		rec := s.do("GET", "/venues?city=maputo", buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		expectTotal(t, rec, 1)
+		venues := decode[[]dto.Venue](t, rec)
+		if len(venues) != 1 || venues[0].ID != venue.ID || venues[0].Capacity != 800 || venues[0].Accessibility == "" {
+			t.Fatalf("venues = %+v", venues)
+		}
+	})
+
+	t.Run("event at the venue", func(t *testing.T) {
+		event := s.createEventAtVenue(organizer, "Concerto", venue.ID, time.Now().Add(24*time.Hour))
+		if event.Venue == nil || event.Venue.ID != venue.ID || event.Location != "Centro Cultural Universitário, Maputo" {
+			t.Fatalf("event = %+v", event)
+		}
+
+		unknown := map[string]any{"name": "Concerto", "date": time.Now().Add(24 * time.Hour), "venue_id": uuid.New()}
+		expectError(t, s.do("POST", "/events", organizer.Token, unknown), http.StatusBadRequest, "unknown_venue")
+
+		// Sem local cadastrado o local em texto continua obrigatório
+		body := expectError(t, s.do("POST", "/events", organizer.Token, map[string]any{"name": "Concerto", "date": time.Now().Add(24 * time.Hour)}), http.StatusBadRequest, "validation_failed")
+		if body.Fields["location"] == "" {
+			t.Fatalf("fields = %v, want an error for location", body.Fields)
+		}
+
+		// Remover o local não remove o evento, que fica sem local
+		expectStatus(t, s.do("DELETE", path, organizer.Token, nil), http.StatusNoContent)
+		expectStatus(t, s.do("DELETE", path, organizer.Token, nil), http.StatusNotFound)
+		got := decode[dto.Event](t, s.do("GET", "/events/"+event.ID.String(), organizer.Token, nil))
+		if got.Venue != nil || got.Location != event.Location {
+			t.Fatalf("event after venue delete = %+v", got)
+		}
+	})
+}
+
+func TestNearbyEvents(t *testing.T) {
+	s := newTestServer(t)
+	organizer := s.newUser("organizer")
+	buyer := s.newUser("buyer")
+
+	maputo := s.createVenue(organizer, "Centro Cultural", "Maputo", -25.9692, 32.5732)
+	matola := s.createVenue(organizer, "Estádio da Matola", "Matola", -25.9622, 32.4589)
+	beira := s.createVenue(organizer, "Casa do Artista", "Beira", -19.8436, 34.8389)
+
+	concert := s.createEventAtVenue(organizer, "Concerto", maputo.ID, time.Now().Add(10*24*time.Hour))
+	match := s.createEventAtVenue(organizer, "Jogo", matola.ID, time.Now().Add(2*24*time.Hour))
+	s.createEventAtVenue(organizer, "Festival", beira.ID, time.Now().Add(24*time.Hour))
+	s.createEvent(organizer, "Sem Local")
+
+	past := database.Event{Name: "Ontem", Location: "Maputo", Date: time.Now().Add(-24 * time.Hour), OrganizerID: organizer.ID, VenueID: &maputo.ID}
+	if err := s.repos.Events.Create(&past); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ponto na Baixa de Maputo, a cerca de 1 km do Centro Cultural e 12 km da Matola
+	const point = "lat=-25.9653&lng=32.5830"
+	tests := []struct {
+		name  string
+		query string
+		want  []uuid.UUID
+	}{
+		{"default radius", point, []uuid.UUID{concert.ID}},
+		{"sorted by distance", point + "&radius=20", []uuid.UUID{concert.ID, match.ID}},
+		{"sorted by date", point + "&radius=20&sort=date", []uuid.UUID{match.ID, concert.ID}},
+		{"filtered by date", point + "&radius=20&date_to=" + time.Now().Add(5*24*time.Hour).Format("2006-01-02"), []uuid.UUID{match.ID}},
+		{"nothing nearby", "lat=0&lng=0&radius=500", []uuid.UUID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do("GET", "/events/nearby?"+tt.query, buyer.Token, nil)
+			expectStatus(t, rec, http.StatusOK)
+			expectTotal(t, rec, len(tt.want))
+
+			results := decode[[]dto.EventDistance](t, rec)
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d events, want %d: %+v", len(results), len(tt.want), results)
+			}
+			for i, result := range results {
+				if result.Event.ID != tt.want[i] {
+					t.Fatalf("event %d = %s, want %s", i, result.Event.Name, tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("distances", func(t *testing.T) {
+		rec := s.do("GET", "/events/nearby?"+point+"&radius=20", buyer.Token, nil)
+		results := decode[[]dto.EventDistance](t, rec)
+		if d := results[0].Distance; d < 0.5 || d > 1.5 {
+			t.Fatalf("distance to the cultural centre = %v km", d)
+		}
+		if d := results[1].Distance; d < 11 || d > 14 {
+			t.Fatalf("distance to Matola = %v km", d)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		rec := s.do("GET", "/events/nearby?"+point+"&radius=20&limit=1", buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		cursor := rec.Header().Get("X-Next-Cursor")
+
+		rec = s.do("GET", "/events/nearby?"+point+"&radius=20&limit=1&cursor="+url.QueryEscape(cursor), buyer.Token, nil)
+		expectStatus(t, rec, http.StatusOK)
+		if results := decode[[]dto.EventDistance](t, rec); len(results) != 1 || results[0].Event.ID != match.ID {
+			t.Fatalf("second page = %+v", results)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for query, field := range map[string]string{
+			"lng=32.5":                        "lat",
+			"lat=-95&lng=32.5":                "lat",
+			"lat=-25.9&lng=abc":               "lng",
+			"lat=-25.9&lng=32.5&radius=1000":  "radius",
+			"lat=-25.9&lng=32.5&sort=name":    "sort",
+			"lat=-25.9&lng=32.5&radius=0.001": "radius",
+		} {
+			body := expectError(t, s.do("GET", "/events/nearby?"+query, buyer.Token, nil), http.StatusBadRequest, "validation_failed")
+			if body.Fields[field] == "" {
+				t.Fatalf("%s: fields = %v, want an error for %s", query, body.Fields, field)
+			}
+		}
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		expectStatus(t, s.do("GET", "/events/nearby?"+point, "", nil), http.StatusUnauthorized)
+	})
+}
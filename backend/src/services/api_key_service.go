@@ -0,0 +1,160 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Escopos que podem ser concedidos a uma chave de API
+const (
+	ScopeEventsRead      = "events:read"
+	ScopeTicketsValidate = "tickets:validate"
+)
+
+var availableScopes = map[string]bool{
+	ScopeEventsRead:      true,
+	ScopeTicketsValidate: true,
+}
+
+// Prefixo das chaves, facilita identificá-las em logs e scanners de segredos
+const apiKeyPrefix = "tk_"
+
+var (
+	ErrAPIKeyOrganizerOnly = apperrors.NewForbidden("api_key_organizer_only", "only organizers can manage API keys")
+	ErrAPIKeyNameRequired  = apperrors.NewValidation("api_key_name_required", "API key name is required")
+	ErrInvalidAPIKeyScope  = apperrors.NewValidation("invalid_api_key_scope", "invalid API key scope")
+	ErrAPIKeyNotFound      = apperrors.NewNotFound("api_key_not_found", "API key not found")
+
+	errMissingScope  = apperrors.NewForbidden("missing_scope", "API key does not have the required scope")
+	errInvalidAPIKey = apperrors.NewUnauthorized("invalid_api_key", "invalid API key")
+)
+
+// Serviço das chaves de API dos organizadores
+type APIKeyService struct {
+	apiKeys repository.APIKeyRepository
+	users   repository.UserRepository
+	auth    *AuthService
+}
+
+// Função para criar o serviço das chaves de API
+func NewAPIKeyService(repos repository.Repositories, auth *AuthService) *APIKeyService {
+	return &APIKeyService{apiKeys: repos.APIKeys, users: repos.Users, auth: auth}
+}
+
+// Função para criar uma chave de API; a chave em claro só é devolvida aqui
+func (s *APIKeyService) CreateAPIKey(userID uuid.UUID, name string, scopes []string) (*database.APIKey, string, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, "", ErrUserNotFound
+	}
+
+	if user.Role != "organizer" {
+		return nil, "", ErrAPIKeyOrganizerOnly
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, "", ErrAPIKeyNameRequired
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", ErrInvalidAPIKeyScope
+	}
+	for _, scope := range scopes {
+		if !availableScopes[scope] {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidAPIKeyScope, scope)
+		}
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	plainKey := apiKeyPrefix + secret
+
+	apiKey := database.APIKey{
+		UserID:  userID,
+		Name:    strings.TrimSpace(name),
+		Prefix:  plainKey[:len(apiKeyPrefix)+8],
+		KeyHash: hashAPIKey(plainKey),
+		Scopes:  strings.Join(scopes, ","),
+	}
+
+	if err := s.apiKeys.Create(&apiKey); err != nil {
+		return nil, "", err
+	}
+
+	return &apiKey, plainKey, nil
+}
+
+// Função para listar as chaves de API de um organizador
+func (s *APIKeyService) GetAPIKeys(userID uuid.UUID) ([]database.APIKey, error) {
+	return s.apiKeys.ListByUser(userID)
+}
+
+// Função para revogar uma chave de API
+func (s *APIKeyService) RevokeAPIKey(id, userID uuid.UUID) error {
+	revoked, err := s.apiKeys.Revoke(id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// Função para autenticar com o JWT do usuário ou com uma chave de API que
+// tenha o escopo indicado (cabeçalhos "Authorization: ApiKey <chave>" ou "X-API-Key")
+func (s *APIKeyService) VerifyTokenOrAPIKey(w http.ResponseWriter, r *http.Request, scope string) (*database.User, error) {
+	plainKey := r.Header.Get("X-API-Key")
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "ApiKey ") {
+		plainKey = strings.TrimPrefix(authorization, "ApiKey ")
+	}
+
+	// Sem chave de API: segue o fluxo normal do JWT
+	if plainKey == "" {
+		return s.auth.VerifyToken(w, r)
+	}
+
+	apiKey, err := s.apiKeys.FindActiveByHash(hashAPIKey(strings.TrimSpace(plainKey)))
+	if err != nil {
+		apperrors.Write(w, errInvalidAPIKey)
+		return nil, errInvalidAPIKey
+	}
+
+	if !hasScope(apiKey.Scopes, scope) {
+		err := fmt.Errorf("%w: %s", errMissingScope, scope)
+		apperrors.Write(w, err)
+		return nil, err
+	}
+
+	// Atualiza a data do último uso sem alterar os demais campos
+	s.apiKeys.TouchLastUsed(apiKey.ID, time.Now())
+
+	return &apiKey.User, nil
+}
+
+func hasScope(scopes, scope string) bool {
+	for _, granted := range strings.Split(scopes, ",") {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// As chaves são aleatórias e longas, um hash SHA-256 é suficiente
+func hashAPIKey(plainKey string) string {
+	hash := sha256.Sum256([]byte(plainKey))
+	return hex.EncodeToString(hash[:])
+}
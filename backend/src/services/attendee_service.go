@@ -0,0 +1,131 @@
+package services
+
+import (
+	"src/database"
+	"src/repository"
+
+	"github.com/google/uuid"
+)
+
+// Quantidade de tickets buscada por vez na exportação da lista de participantes
+const attendeeExportBatch = 500
+
+// Participante do evento: o ticket com o comprador e a referência do pedido
+type Attendee struct {
+	Ticket         database.Ticket
+	OrderReference string // Transações M-Pesa dos pagamentos confirmados; vazia sem pagamento
+}
+
+// Serviço da lista de participantes dos eventos
+type AttendeeService struct {
+	tickets   repository.TicketRepository
+	payments  repository.PaymentRepository
+	events    repository.EventRepository
+	questions repository.EventQuestionRepository
+	access    *eventAccess
+}
+
+// Função para criar o serviço da lista de participantes
+func NewAttendeeService(repos repository.Repositories, access *eventAccess) *AttendeeService {
+	return &AttendeeService{
+		tickets:   repos.Tickets,
+		payments:  repos.Payments,
+		events:    repos.Events,
+		questions: repos.Questions,
+		access:    access,
+	}
+}
+
+// Função para verificar se o usuário pode ver os participantes (organizador ou co-organizador)
+func (s *AttendeeService) authorizedEvent(eventID, userID uuid.UUID) (*database.Event, error) {
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+	if err := s.access.authorize(event, userID, PermissionViewAttendees); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Função para listar uma página dos participantes do evento, com busca pelo nome ou email
+func (s *AttendeeService) GetEventAttendees(eventID, userID uuid.UUID, filter TicketFilter, request PageRequest) (*Page[Attendee], error) {
+	if _, err := s.authorizedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+	page, err := newPage(request, repository.SortByCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.EventID = &eventID
+	tickets, err := s.tickets.List(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.tickets.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newPageResult(tickets, total, page, ticketSortKey(page.Sort.Field))
+	attendees, err := s.withOrderReferences(result.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &Page[Attendee]{Items: attendees, Total: result.Total, NextCursor: result.NextCursor}, nil
+}
+
+// Função para exportar todos os participantes do evento em lotes, na ordem de
+// compra; write recebe as perguntas do evento (uma coluna para cada) e cada lote
+// (o primeiro sempre, mesmo vazio), para a resposta ser enviada aos poucos sem
+// carregar a lista inteira
+func (s *AttendeeService) ExportEventAttendees(eventID, userID uuid.UUID, filter TicketFilter, write func([]database.EventQuestion, []Attendee) error) error {
+	if _, err := s.authorizedEvent(eventID, userID); err != nil {
+		return err
+	}
+	questions, err := s.questions.ListByEvent(eventID)
+	if err != nil {
+		return err
+	}
+
+	filter.EventID = &eventID
+	page := repository.Page{Limit: attendeeExportBatch, Sort: repository.Sort{Field: repository.SortByCreatedAt}}
+	for {
+		tickets, err := s.tickets.List(filter, page)
+		if err != nil {
+			return err
+		}
+		attendees, err := s.withOrderReferences(tickets)
+		if err != nil {
+			return err
+		}
+		if err := write(questions, attendees); err != nil {
+			return err
+		}
+		if len(tickets) < attendeeExportBatch {
+			return nil
+		}
+
+		last := tickets[len(tickets)-1]
+		page.After = &repository.Cursor{Value: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// Função para juntar aos tickets as referências dos pagamentos, com uma consulta por lote
+func (s *AttendeeService) withOrderReferences(tickets []database.Ticket) ([]Attendee, error) {
+	ids := make([]uuid.UUID, 0, len(tickets))
+	for _, ticket := range tickets {
+		ids = append(ids, ticket.ID)
+	}
+	references, err := s.payments.TransactionsByTickets(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	attendees := make([]Attendee, 0, len(tickets))
+	for _, ticket := range tickets {
+		attendees = append(attendees, Attendee{Ticket: ticket, OrderReference: references[ticket.ID]})
+	}
+	return attendees, nil
+}
@@ -1,23 +1,87 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/bcrypt"
+	"log"
 	"net/http"
+	"src/apperrors"
+	"src/config"
+	"src/database"
+	"src/repository"
 	"strings"
 	"time"
-	"github.com/golang-jwt/jwt/v4"
-	"src/database"
+
+	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidCredentials = apperrors.NewUnauthorized("invalid_credentials", "invalid credentials")
+	ErrEmailInUse         = apperrors.NewConflict("email_in_use", "email already in use")
+	ErrInvalidRole        = apperrors.NewValidation("invalid_role", "invalid role")
+	ErrUserNotFound       = apperrors.NewNotFound("user_not_found", "user not found")
+
+	errMissingToken           = apperrors.NewUnauthorized("missing_token", "authorization header missing or malformed")
+	errInvalidToken           = apperrors.NewUnauthorized("invalid_token", "invalid token")
+	errTwoFactorSetupRequired = apperrors.NewForbidden("two_factor_setup_required", "two-factor authentication must be enabled for this account")
+)
+
+// Hash usado quando o email não existe, para igualar o tempo de resposta
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// Serviço de autenticação: registro, login, tokens JWT e 2FA
+type AuthService struct {
+	users         repository.UserRepository
+	recoveryCodes repository.RecoveryCodeRepository
+	loginAttempts repository.LoginAttemptRepository
+
+	jwtSecret              []byte
+	trustProxyHeaders      bool
+	twoFactorRequiredRoles map[string]bool
+	guard                  *loginGuard
+	verifier               *emailVerifier
+}
+
+// Função para criar o serviço de autenticação
+func NewAuthService(repos repository.Repositories, cfg *config.Config, verifier *emailVerifier) *AuthService {
+	requiredRoles := map[string]bool{}
+	for _, role := range cfg.TOTPRequiredRoles {
+		requiredRoles[role] = true
+	}
+
+	return &AuthService{
+		users:                  repos.Users,
+		recoveryCodes:          repos.RecoveryCodes,
+		loginAttempts:          repos.LoginAttempts,
+		jwtSecret:              []byte(cfg.JWTSecret),
+		trustProxyHeaders:      cfg.TrustProxyHeaders,
+		twoFactorRequiredRoles: requiredRoles,
+		guard:                  newLoginGuard(),
+		verifier:               verifier,
+	}
+}
+
 // Função para verificar o token JWT
-func VerifyToken(w http.ResponseWriter, r *http.Request) (*database.User, error) {
+func (s *AuthService) VerifyToken(w http.ResponseWriter, r *http.Request) (*database.User, error) {
+	return s.verifyToken(w, r, false)
+}
+
+// Função para verificar o token JWT nas rotas de ativação do 2FA, aceitando
+// também os tokens limitados emitidos para usuários que ainda precisam ativá-lo
+func (s *AuthService) VerifyEnrollmentToken(w http.ResponseWriter, r *http.Request) (*database.User, error) {
+	return s.verifyToken(w, r, true)
+}
+
+func (s *AuthService) verifyToken(w http.ResponseWriter, r *http.Request, allowSetup bool) (*database.User, error) {
 	// Obtém o token da autorização no cabeçalho da requisição
 	tokenString := r.Header.Get("Authorization")
 
 	// Verifica se o token tem o prefixo "Bearer "
 	if !strings.HasPrefix(tokenString, "Bearer ") {
-		http.Error(w, "Authorization header missing or malformed", http.StatusUnauthorized)
-		return nil, fmt.Errorf("authorization header missing or malformed")
+		apperrors.Write(w, errMissingToken)
+		return nil, errMissingToken
 	}
 
 	// Remove "Bearer " da string para obter o token
@@ -31,35 +95,52 @@ func VerifyToken(w http.ResponseWriter, r *http.Request) (*database.User, error)
 		}
 
 		// Retorna a chave secreta para verificar a assinatura
-		return []byte("your-secret-key"), nil
+		return s.jwtSecret, nil
 	})
 	if err != nil {
-		http.Error(w, "Invalid token", http.StatusUnauthorized)
-		return nil, err
+		apperrors.Write(w, errInvalidToken)
+		return nil, errInvalidToken
 	}
 
 	// Verifica se o token é válido e extrai as claims
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		// Tokens limitados só dão acesso à ativação do 2FA
+		if setup, _ := claims["mfa_setup"].(bool); setup && !allowSetup {
+			apperrors.Write(w, errTwoFactorSetupRequired)
+			return nil, errTwoFactorSetupRequired
+		}
+
 		// Procura o usuário no banco de dados baseado no ID (sub) do token
-		var user database.User
-		if err := database.DB.First(&user, "id = ?", claims["sub"]).Error; err != nil {
-			http.Error(w, "User not found", http.StatusUnauthorized)
-			return nil, err
+		subject, _ := claims["sub"].(string)
+		userID, err := uuid.Parse(subject)
+		if err != nil {
+			apperrors.Write(w, errInvalidToken)
+			return nil, errInvalidToken
+		}
+		user, err := s.users.FindByID(userID)
+		if err != nil {
+			// O usuário do token pode ter sido removido
+			apperrors.Write(w, errInvalidToken)
+			return nil, errInvalidToken
 		}
-		return &user, nil
+		return user, nil
 	}
 
 	// Caso o token não seja válido
-	http.Error(w, "Invalid token", http.StatusUnauthorized)
-	return nil, fmt.Errorf("invalid token")
+	apperrors.Write(w, errInvalidToken)
+	return nil, errInvalidToken
 }
 
 // Função para registrar um novo usuário
-func RegisterUser(name, email, password, role string) (*database.User, error) {
+func (s *AuthService) RegisterUser(name, email, password, role string) (*database.User, error) {
+	// Administradores não podem ser criados pelo registro público
+	if role == "admin" {
+		return nil, ErrInvalidRole
+	}
+
 	// Verifica se o email já está cadastrado
-	var existingUser database.User
-	if err := database.DB.Where("email = ?", email).First(&existingUser).Error; err == nil {
-		return nil, fmt.Errorf("email already in use")
+	if _, err := s.users.FindByEmail(email); err == nil {
+		return nil, ErrEmailInUse
 	}
 
 	// Cria o modelo de usuário
@@ -75,51 +156,88 @@ func RegisterUser(name, email, password, role string) (*database.User, error) {
 	}
 
 	// Salva o usuário no banco de dados
-	if err := database.DB.Create(&user).Error; err != nil {
+	if err := s.users.Create(&user); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrEmailInUse
+		}
 		return nil, err
 	}
 
+	// Envia o link de verificação; se o envio falhar, o usuário pode pedir outro
+	if err := s.verifier.send(&user); err != nil {
+		log.Println("Erro ao enviar o email de verificação:", err)
+	}
+
 	return &user, nil
 }
 
 // Função para autenticar um usuário e gerar o token JWT
-func LoginUser(email, password string) (string, *database.User, error) {
+func (s *AuthService) LoginUser(email, password, code string, client LoginClient) (string, *database.User, error) {
+	// Recusa a tentativa se a conta ou o IP estiverem bloqueados ou em espera;
+	// senão a tentativa fica reservada até terminar com falha, sucesso ou liberação
+	if err := s.guard.check(email, client.IP); err != nil {
+		return "", nil, err
+	}
+
 	// Busca o usuário no banco de dados
-	var user database.User
-	if err := database.DB.Where("email = ?", email).First(&user).Error; err != nil {
-		return "", nil, fmt.Errorf("user not found")
+	user, err := s.users.FindByEmail(email)
+	if err != nil {
+		// Compara com um hash fictício para não revelar pelo tempo de resposta que o email não existe
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		s.recordFailedLogin(email, nil, client, "unknown_email")
+		return "", nil, ErrInvalidCredentials
 	}
 
 	// Verifica se a senha está correta
 	if !user.CheckPassword(password) {
-		return "", nil, fmt.Errorf("invalid credentials")
+		s.recordFailedLogin(email, &user.ID, client, "invalid_password")
+		return "", nil, ErrInvalidCredentials
+	}
+
+	// Verifica o segundo fator, caso o 2FA esteja ativo
+	if user.TOTPEnabled {
+		if err := s.verifySecondFactor(user, code); err != nil {
+			if errors.Is(err, ErrInvalidTwoFactorCode) {
+				s.recordFailedLogin(email, &user.ID, client, "invalid_2fa_code")
+			} else {
+				// Sem o código a senha estava certa: a tentativa não conta como falha
+				s.guard.release(email, client.IP)
+			}
+			return "", nil, err
+		}
 	}
 
+	s.guard.reset(email, client.IP)
+
 	// Gera o token JWT
-	token, err := generateJWT(&user)
+	token, err := s.generateJWT(user)
 	if err != nil {
 		return "", nil, err
 	}
 
-	return token, &user, nil
+	return token, user, nil
 }
 
 // Função para gerar o token JWT
-func generateJWT(user *database.User) (string, error) {
+func (s *AuthService) generateJWT(user *database.User) (string, error) {
 	// Define as claims (informações do token)
 	claims := jwt.MapClaims{
-		"sub": user.ID,               // ID do usuário
-		"name": user.Name,            // Nome do usuário
-		"role": user.Role,            // Função do usuário
-		"exp": time.Now().Add(time.Hour * 24).Unix(), // Expiração do token (24 horas)
+		"sub":  user.ID,                               // ID do usuário
+		"name": user.Name,                             // Nome do usuário
+		"role": user.Role,                             // Função do usuário
+		"exp":  time.Now().Add(time.Hour * 24).Unix(), // Expiração do token (24 horas)
+	}
+
+	// Papéis com 2FA obrigatório recebem um token limitado até ativarem o 2FA
+	if s.TwoFactorRequired(user.Role) && !user.TOTPEnabled {
+		claims["mfa_setup"] = true
 	}
 
 	// Cria o token com a chave secreta
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// Assina o token com a chave secreta
-	secretKey := []byte("your-secret-key")
-	signedToken, err := token.SignedString(secretKey)
+	signedToken, err := token.SignedString(s.jwtSecret)
 	if err != nil {
 		return "", err
 	}
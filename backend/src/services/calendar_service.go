@@ -0,0 +1,138 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"src/apperrors"
+	"src/database"
+	"src/icalendar"
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Intervalo sugerido aos aplicativos para buscar de novo a assinatura, para as
+// mudanças de data e de local chegarem ao calendário do comprador
+const calendarRefreshInterval = time.Hour
+
+var ErrCalendarNotFound = apperrors.NewNotFound("calendar_not_found", "calendar not found")
+
+// Serviço dos calendários: o arquivo .ics de um evento e a assinatura com os
+// eventos dos tickets de cada usuário
+type CalendarService struct {
+	users   repository.UserRepository
+	events  *EventService
+	tickets *TicketService
+}
+
+// Função para criar o serviço dos calendários
+func NewCalendarService(repos repository.Repositories, events *EventService, tickets *TicketService) *CalendarService {
+	return &CalendarService{users: repos.Users, events: events, tickets: tickets}
+}
+
+// Função para gerar o calendário de um evento, visível para o usuário
+func (s *CalendarService) EventCalendar(eventID, userID uuid.UUID) (*database.Event, []byte, error) {
+	event, err := s.events.GetEvent(eventID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	calendar := icalendar.Calendar{Events: []icalendar.Event{calendarEvent(*event)}}
+	return event, calendar.Encode(time.Now()), nil
+}
+
+// Função para ativar a assinatura do calendário do usuário; um novo token
+// invalida a URL anterior. O token em claro só é devolvido aqui
+func (s *CalendarService) EnableCalendarFeed(userID uuid.UUID) (string, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return "", ErrUserNotFound
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	hash := hashCalendarToken(token)
+	user.CalendarTokenHash = &hash
+	if err := s.users.Update(user); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Função para desativar a assinatura do calendário do usuário
+func (s *CalendarService) DisableCalendarFeed(userID uuid.UUID) error {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	user.CalendarTokenHash = nil
+	return s.users.Update(user)
+}
+
+// Função para gerar a assinatura com os eventos dos tickets do dono do token.
+// O calendário é montado a cada busca, então as mudanças do organizador
+// aparecem na próxima atualização do aplicativo
+func (s *CalendarService) FeedCalendar(token string) ([]byte, error) {
+	user, err := s.users.FindByCalendarToken(hashCalendarToken(token))
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	calendar := icalendar.Calendar{Name: "Meus eventos", Refresh: calendarRefreshInterval}
+	seen := map[uuid.UUID]bool{}
+
+	// Percorre todas as páginas dos tickets; vários tickets do mesmo evento viram um só evento
+	request := PageRequest{Limit: maxPageSize, Sort: repository.SortByEventDate}
+	for {
+		page, err := s.tickets.GetTicketsByUser(user.ID, TicketFilter{}, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, ticket := range page.Items {
+			if ticket.Status == "cancelado" || seen[ticket.EventID] {
+				continue
+			}
+			seen[ticket.EventID] = true
+			calendar.Events = append(calendar.Events, calendarEvent(ticket.Event))
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		request.Cursor = page.NextCursor
+	}
+
+	return calendar.Encode(time.Now()), nil
+}
+
+// Função para converter o evento no evento do calendário; o UID é o ID do
+// evento, para o aplicativo atualizar o mesmo item quando algo mudar
+func calendarEvent(event database.Event) icalendar.Event {
+	entry := icalendar.Event{
+		UID:         event.ID.String() + "@ticketing-system",
+		Start:       event.Date,
+		End:         event.EndDate,
+		Summary:     event.Name,
+		Description: event.Description,
+		Location:    event.Location,
+		Status:      icalendar.StatusConfirmed,
+	}
+	if event.Venue != nil {
+		entry.Latitude, entry.Longitude = &event.Venue.Latitude, &event.Venue.Longitude
+		if event.Venue.Address != "" {
+			entry.Location = event.Venue.Name + ", " + event.Venue.Address + ", " + event.Venue.City
+		}
+	}
+	if event.Status == database.EventCancelled {
+		entry.Status = icalendar.StatusCancelled
+	}
+	return entry
+}
+
+func hashCalendarToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCategoryNotFound  = apperrors.NewNotFound("category_not_found", "category not found")
+	ErrCategorySlugInUse = apperrors.NewConflict("category_slug_in_use", "category slug already in use")
+	ErrUnknownCategory   = &apperrors.Error{
+		Kind:    apperrors.Validation,
+		Code:    "unknown_category",
+		Message: "category does not exist",
+		Fields:  map[string]string{"category_id": "must be an existing category"},
+	}
+
+	// Nome sem letras nem números, do qual não dá para gerar o slug
+	errEmptyCategorySlug = apperrors.InvalidFields(map[string]string{"slug": "is required when the name has no letters or numbers"})
+)
+
+// Letras acentuadas do português e as letras sem acento, para gerar os slugs
+var unaccent = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e",
+	"í", "i", "ì", "i", "î", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c",
+)
+
+// Função para gerar o slug de um nome (ex: "Música ao Vivo" → "musica-ao-vivo")
+func slugify(name string) string {
+	words := strings.FieldsFunc(unaccent.Replace(strings.ToLower(name)), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || unicode.IsDigit(r))
+	})
+	return strings.Join(words, "-")
+}
+
+// Categoria com a quantidade de eventos futuros dela
+type CategoryCount struct {
+	Category database.Category
+	Events   int64
+}
+
+// Serviço das categorias de evento
+type CategoryService struct {
+	categories repository.CategoryRepository
+	events     repository.EventRepository
+}
+
+// Função para criar o serviço das categorias
+func NewCategoryService(repos repository.Repositories) *CategoryService {
+	return &CategoryService{categories: repos.Categories, events: repos.Events}
+}
+
+// Função para listar as categorias com a quantidade de eventos futuros de cada uma
+func (s *CategoryService) ListCategories() ([]CategoryCount, error) {
+	categories, err := s.categories.List()
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.events.CountByCategory(futureOnly(EventFilter{}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]CategoryCount, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, CategoryCount{Category: category, Events: counts[category.ID]})
+	}
+	return result, nil
+}
+
+// Função para criar uma categoria; sem slug, ele é gerado a partir do nome
+func (s *CategoryService) CreateCategory(name, slug string) (*database.Category, error) {
+	category := database.Category{Name: strings.TrimSpace(name), Slug: categorySlug(name, slug)}
+	if category.Slug == "" {
+		return nil, errEmptyCategorySlug
+	}
+	if err := s.categories.Create(&category); err != nil {
+		return nil, categoryError(err)
+	}
+	return &category, nil
+}
+
+// Função para renomear uma categoria
+func (s *CategoryService) UpdateCategory(id uuid.UUID, name, slug string) (*database.Category, error) {
+	category, err := s.categories.FindByID(id)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+
+	category.Name = strings.TrimSpace(name)
+	category.Slug = categorySlug(name, slug)
+	if category.Slug == "" {
+		return nil, errEmptyCategorySlug
+	}
+	if err := s.categories.Update(category); err != nil {
+		return nil, categoryError(err)
+	}
+	return category, nil
+}
+
+// Função para remover uma categoria; os eventos dela ficam sem categoria
+func (s *CategoryService) DeleteCategory(id uuid.UUID) error {
+	deleted, err := s.categories.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+func categorySlug(name, slug string) string {
+	if slug != "" {
+		return slug
+	}
+	return slugify(name)
+}
+
+// Converte os erros do repositório nos erros da API
+func categoryError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrCategoryNotFound
+	case errors.Is(err, repository.ErrDuplicate):
+		return ErrCategorySlugInUse
+	default:
+		return err
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"src/apperrors"
+	"src/database"
+	"src/mail"
+	"src/repository"
+	"time"
+)
+
+// Validade do link de verificação do email
+const emailVerificationTTL = 48 * time.Hour
+
+var (
+	ErrInvalidVerificationToken = apperrors.NewValidation("invalid_verification_token", "invalid or expired email verification link")
+	ErrEmailAlreadyVerified     = apperrors.NewConflict("email_already_verified", "email is already verified")
+	ErrAccountEmailNotVerified  = apperrors.NewForbidden("account_email_not_verified", "verify your email before accessing what was sent to it")
+)
+
+// Função para exigir o email verificado antes de entregar o que foi enviado para ele
+// (convites e cópias de tickets); o email da conta pode ser trocado a qualquer momento
+func requireVerifiedEmail(user *database.User) error {
+	if !user.EmailVerified {
+		return ErrAccountEmailNotVerified
+	}
+	return nil
+}
+
+// Envio e confirmação do link de verificação do email, usados no registro e na troca do email
+type emailVerifier struct {
+	users  repository.UserRepository
+	mailer mail.Sender
+	appURL string // Endereço do aplicativo, que abre o link e chama POST /user/email/verify
+}
+
+// Função para gerar um novo token de verificação (o anterior deixa de valer) e
+// enviar o link para o email atual do usuário
+func (v *emailVerifier) send(user *database.User) error {
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	hash := hashVerificationToken(token)
+	expiresAt := time.Now().Add(emailVerificationTTL)
+	user.EmailVerificationHash, user.EmailVerificationExpiresAt = &hash, &expiresAt
+	if err := v.users.Update(user); err != nil {
+		return err
+	}
+
+	return v.mailer.Send(mail.Message{
+		To:      user.Email,
+		Subject: "Confirme o seu email",
+		Body: fmt.Sprintf("Olá, %s!\n\nPara confirmar o email da sua conta no Ticketing System, abra o link abaixo (válido por %d horas):\n\n%s/verify-email?token=%s\n\nSe você não criou esta conta nem alterou o seu email, ignore esta mensagem.\n",
+			user.Name, int(emailVerificationTTL.Hours()), v.appURL, token),
+	})
+}
+
+// Função para confirmar o email pelo token do link; o token só pode ser usado uma vez
+func (v *emailVerifier) verify(token string) (*database.User, error) {
+	user, err := v.users.FindByEmailVerificationToken(hashVerificationToken(token))
+	if err != nil || user.EmailVerificationExpiresAt == nil || time.Now().After(*user.EmailVerificationExpiresAt) {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user.EmailVerified = true
+	user.EmailVerificationHash, user.EmailVerificationExpiresAt = nil, nil
+	if err := v.users.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// O token tem entropia suficiente para um hash SHA-256 simples
+func hashVerificationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"src/apperrors"
+	"src/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidEventTransition = apperrors.NewConflict("invalid_status_transition", "the event cannot change to this status from its current status")
+	ErrEventNotOver           = apperrors.NewConflict("event_not_over", "an event can only be finished after its date")
+	ErrEventNotOnSale         = apperrors.NewConflict("event_not_on_sale", "tickets for this event are not on sale")
+)
+
+// Transições permitidas a partir de cada estado; cancelado e encerrado são finais
+var eventTransitions = map[string][]string{
+	database.EventDraft:       {database.EventPublished, database.EventSalesOpen, database.EventCancelled},
+	database.EventPublished:   {database.EventDraft, database.EventSalesOpen, database.EventCancelled, database.EventFinished},
+	database.EventSalesOpen:   {database.EventSalesClosed, database.EventCancelled, database.EventFinished},
+	database.EventSalesClosed: {database.EventSalesOpen, database.EventCancelled, database.EventFinished},
+}
+
+// Duração máxima de um evento de vários dias
+const maxEventDays = 31
+
+// Função para validar a data, a data de fim e os agendamentos informados para o evento;
+// current é a data atual do evento (zero na criação): a data só precisa estar no futuro
+// quando muda, para que eventos já iniciados continuem editáveis
+func validateSchedule(input EventInput, current time.Time) error {
+	fields := map[string]string{}
+	if !input.Date.Equal(current) && !input.Date.After(time.Now()) {
+		fields["date"] = "must be in the future"
+	}
+
+	end := input.Date
+	switch {
+	case input.EndDate == nil:
+	case !input.EndDate.After(input.Date):
+		fields["end_date"] = "must be after the event date"
+	case input.EndDate.After(input.Date.AddDate(0, 0, maxEventDays)):
+		fields["end_date"] = fmt.Sprintf("must be at most %d days after the event date", maxEventDays)
+	default:
+		end = *input.EndDate
+	}
+
+	if input.PublishAt != nil && !input.PublishAt.Before(input.Date) {
+		fields["publish_at"] = "must be before the event date"
+	}
+	if input.SalesStartAt != nil && !input.SalesStartAt.Before(input.Date) {
+		fields["sales_start_at"] = "must be before the event date"
+	}
+	switch {
+	case input.SalesEndAt == nil:
+	case input.SalesEndAt.After(end):
+		fields["sales_end_at"] = "must not be after the end of the event"
+	case input.SalesStartAt != nil && !input.SalesEndAt.After(*input.SalesStartAt):
+		fields["sales_end_at"] = "must be after sales_start_at"
+	}
+
+	if len(fields) > 0 {
+		return apperrors.InvalidFields(fields)
+	}
+	return nil
+}
+
+// Função para mudar o estado de um evento, seguindo as transições permitidas a
+// partir do estado atual (já considerando os agendamentos)
+func (s *EventService) ChangeEventStatus(id uuid.UUID, status string, userID uuid.UUID) (*database.Event, error) {
+	event, err := s.events.FindByID(id)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Verifica se o usuário é o organizador ou um membro da equipe com permissão
+	if err := s.access.authorize(event, userID, PermissionUpdateEvent); err != nil {
+		return nil, err
+	}
+
+	if err := transitionEvent(event, status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.events.Update(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Função para mudar o estado do evento em memória, validando a transição a partir
+// do estado atual (também usada na edição em massa das ocorrências de uma série)
+func transitionEvent(event *database.Event, status string, now time.Time) error {
+	allowed := eventTransitions[event.StatusAt(now)]
+	if !slices.Contains(allowed, status) {
+		return ErrInvalidEventTransition
+	}
+	if status == database.EventFinished && event.Date.After(now) {
+		return ErrEventNotOver
+	}
+
+	// A mudança manual vale a partir de agora: descarta os agendamentos que a contrariam
+	switch status {
+	case database.EventDraft, database.EventPublished:
+		event.PublishAt = nil
+	case database.EventSalesOpen:
+		event.PublishAt = nil
+		if event.SalesStartAt != nil && event.SalesStartAt.After(now) {
+			event.SalesStartAt = nil
+		}
+		if event.SalesEndAt != nil && !event.SalesEndAt.After(now) {
+			event.SalesEndAt = nil
+		}
+	}
+	event.Status = status
+	return nil
+}
@@ -1,134 +1,354 @@
 package services
 
 import (
-	"errors"
-	"fmt"
+	"slices"
 	"src/database"
+	"src/repository"
+	"src/storage"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Serviço dos eventos
+type EventService struct {
+	events     repository.EventRepository
+	users      repository.UserRepository
+	categories repository.CategoryRepository
+	venues     repository.VenueRepository
+	tickets    repository.TicketRepository
+	storage    storage.Storage // Arquivos das imagens, removidos junto com o evento
+	access     *eventAccess
+	timeZone   *time.Location // Fuso dos meses nas facetas da busca
+}
+
+// Função para criar o serviço dos eventos
+func NewEventService(repos repository.Repositories, access *eventAccess, store storage.Storage, timeZone *time.Location) *EventService {
+	if timeZone == nil {
+		timeZone = time.UTC
+	}
+	return &EventService{
+		events:     repos.Events,
+		users:      repos.Users,
+		categories: repos.Categories,
+		venues:     repos.Venues,
+		tickets:    repos.Tickets,
+		storage:    store,
+		access:     access,
+		timeZone:   timeZone,
+	}
+}
+
+// Dados de um evento informados pelo organizador
+type EventInput struct {
+	Name        string
+	Description string
+	Location    string // Sem local, usa o nome e a cidade do local cadastrado
+	Date        time.Time
+	EndDate     *time.Time // Fim dos eventos de vários dias
+	CategoryID  *uuid.UUID
+	Tags        []string
+	VenueID     *uuid.UUID
+	Capacity    int // Lugares à venda; 0 = sem limite
+
+	// Agendamentos do ciclo de vida (opcionais)
+	PublishAt    *time.Time
+	SalesStartAt *time.Time
+	SalesEndAt   *time.Time
+
+	// Tickets nominais: prazo para trocar o titular (horas antes do início) e conferência na portaria
+	HolderCutoffHours int
+	CheckHolderAtGate bool
+}
+
+// Função para aplicar os dados informados ao evento, validando a categoria e o local
+func (s *EventService) apply(event *database.Event, input EventInput) error {
+	if err := validateSchedule(input, event.Date); err != nil {
+		return err
+	}
+
+	if input.CategoryID != nil {
+		category, err := s.categories.FindByID(*input.CategoryID)
+		if err != nil {
+			return ErrUnknownCategory
+		}
+		event.Category = category
+	} else {
+		event.Category = nil
+	}
+
+	if input.VenueID != nil {
+		venue, err := s.venues.FindByID(*input.VenueID)
+		if err != nil {
+			return ErrUnknownVenue
+		}
+		event.Venue = venue
+		if input.Location == "" {
+			input.Location = venue.Name + ", " + venue.City
+		}
+	} else {
+		event.Venue = nil
+	}
+
+	event.Name = input.Name
+	event.Description = input.Description
+	event.Location = input.Location
+	event.Date = input.Date
+	event.EndDate = input.EndDate
+	event.CategoryID = input.CategoryID
+	event.Tags = normalizeTags(input.Tags)
+	event.VenueID = input.VenueID
+	event.Capacity = input.Capacity
+	event.PublishAt = input.PublishAt
+	event.SalesStartAt = input.SalesStartAt
+	event.SalesEndAt = input.SalesEndAt
+	event.HolderCutoffHours = input.HolderCutoffHours
+	event.CheckHolderAtGate = input.CheckHolderAtGate
+	return nil
+}
+
+// Função para normalizar as tags: minúsculas, sem espaços nas pontas, sem repetições e em ordem
+func normalizeTags(tags []string) []database.EventTag {
+	seen := map[string]bool{}
+	normalized := []database.EventTag{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, database.EventTag{Tag: tag})
+	}
+	slices.SortFunc(normalized, func(a, b database.EventTag) int { return strings.Compare(a.Tag, b.Tag) })
+	return normalized
+}
+
 // Função para criar um evento
-func CreateEvent(name, description, location string, date time.Time, organizerID uuid.UUID) (*database.Event, error) {
+func (s *EventService) CreateEvent(input EventInput, organizerID uuid.UUID) (*database.Event, error) {
 	// Buscar o organizador no banco de dados
-	var organizer database.User
-	if err := database.DB.First(&organizer, "id = ?", organizerID).Error; err != nil {
-		return nil, errors.New("organizador não encontrado")
+	organizer, err := s.users.FindByID(organizerID)
+	if err != nil {
+		return nil, ErrUserNotFound
 	}
 
-	// Criar o evento com o organizador setado
+	// Criar o evento com o organizador setado; ele começa como rascunho
 	event := database.Event{
-		Name:        name,
-		Description: description,
-		Location:    location,
-		Date:        date,
 		OrganizerID: organizerID,
-		Organizer:   organizer, // Definir o organizador corretamente
+		Organizer:   *organizer, // Definir o organizador corretamente
+		Status:      database.EventDraft,
+	}
+	if err := s.apply(&event, input); err != nil {
+		return nil, err
 	}
 
 	// Salvar o evento no banco de dados
-	if err := database.DB.Create(&event).Error; err != nil {
+	if err := s.events.Create(&event); err != nil {
 		return nil, err
 	}
 
 	return &event, nil
 }
 
+// Valor de ordenação de um evento, usado no cursor da próxima página
+func eventSortKey(field string) func(database.Event) (any, uuid.UUID) {
+	if field == repository.SortByName {
+		return func(event database.Event) (any, uuid.UUID) { return event.Name, event.ID }
+	}
+	return func(event database.Event) (any, uuid.UUID) { return event.Date, event.ID }
+}
 
-// Função para listar eventos de um organizador
-func GetEvents(organizerID uuid.UUID) ([]database.Event, error) {
-	var events []database.Event
+// Função para listar uma página dos eventos que atendem ao filtro
+func (s *EventService) listEvents(filter EventFilter, request PageRequest) (*Page[database.Event], error) {
+	page, err := newPage(request, repository.SortByDate, repository.SortByName)
+	if err != nil {
+		return nil, err
+	}
 
-	// Busca todos os eventos do organizador e carrega o organizador associado
-	if err := database.DB.Preload("Organizer").Where("organizer_id = ?", organizerID).Find(&events).Error; err != nil {
+	events, err := s.events.List(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.events.Count(filter)
+	if err != nil {
 		return nil, err
 	}
 
-	return events, nil
+	return newPageResult(events, total, page, eventSortKey(page.Sort.Field)), nil
+}
+
+// Função para listar eventos de um organizador
+func (s *EventService) GetEvents(organizerID uuid.UUID, filter EventFilter, request PageRequest) (*Page[database.Event], error) {
+	// Busca apenas os eventos do organizador, com o organizador associado
+	filter.OrganizerID = &organizerID
+	return s.listEvents(filter, request)
 }
 
+// Restringe o filtro aos eventos futuros e visíveis aos compradores
+// (o filtro por data e por estado é feito no banco)
+func futureOnly(filter EventFilter) EventFilter {
+	now := time.Now()
+	if filter.DateFrom == nil || filter.DateFrom.Before(now) {
+		filter.DateFrom = &now
+	}
+	filter.VisibleAt = &now
+	return filter
+}
 
-// Função para buscar todos os eventos futuros
-func GetFutureEvents() ([]database.Event, error) {
-	var events []database.Event
+// Função para buscar os eventos futuros
+func (s *EventService) GetFutureEvents(filter EventFilter, request PageRequest) (*Page[database.Event], error) {
+	return s.listEvents(futureOnly(filter), request)
+}
 
-	// Busca todos os eventos no banco de dados e carrega o organizador
-	if err := database.DB.Preload("Organizer").Find(&events).Error; err != nil {
-		return nil, err
+// Resultados da busca textual
+type (
+	EventSearchHit = repository.EventSearchHit
+	EventFacets    = repository.EventFacets
+)
+
+// Função para buscar eventos futuros pelo texto, com as facetas dos resultados
+func (s *EventService) SearchEvents(text string, filter EventFilter, request PageRequest) (*Page[EventSearchHit], *EventFacets, error) {
+	// A relevância é sempre dos mais relevantes para os menos; a data aceita as duas ordens
+	if request.Sort == "" || strings.TrimPrefix(request.Sort, "-") == repository.SortByRelevance {
+		request.Sort = "-" + repository.SortByRelevance
+	}
+	page, err := newPage(request, repository.SortByRelevance, repository.SortByDate)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Filtra eventos cuja data é no futuro
-	var futureEvents []database.Event
-	currentTime := time.Now()
-	for _, event := range events {
-		if event.Date.After(currentTime) {
-			futureEvents = append(futureEvents, event)
-		}
+	search := repository.EventSearch{Query: text, Filter: futureOnly(filter)}
+	hits, err := s.events.Search(search, page)
+	if err != nil {
+		return nil, nil, err
+	}
+	facets, err := s.events.SearchFacets(search, s.timeZone)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return futureEvents, nil
+	// Cada evento encontrado cai em exatamente um mês, então as facetas dão o total
+	var total int64
+	for _, month := range facets.Months {
+		total += month.Count
+	}
+
+	key := func(hit EventSearchHit) (any, uuid.UUID) { return hit.Rank, hit.Event.ID }
+	if page.Sort.Field == repository.SortByDate {
+		key = func(hit EventSearchHit) (any, uuid.UUID) { return hit.Event.Date, hit.Event.ID }
+	}
+	return newPageResult(hits, total, page, key), facets, nil
 }
 
-// Função para buscar um evento específico
-func GetEvent(eventID uuid.UUID) (*database.Event, error) {
-	var event database.Event
+// Resultado da busca por proximidade
+type EventDistance = repository.EventDistance
 
-	// Busca o evento no banco de dados pelo ID e carrega o organizador
-	if err := database.DB.Preload("Organizer").First(&event, "id = ?", eventID).Error; err != nil {
-		return nil, fmt.Errorf("evento não encontrado")
+// Função para buscar os eventos futuros em locais a até radiusKm do ponto,
+// ordenados pela distância (padrão) ou pela data
+func (s *EventService) GetNearbyEvents(latitude, longitude, radiusKm float64, filter EventFilter, request PageRequest) (*Page[EventDistance], error) {
+	page, err := newPage(request, repository.SortByDistance, repository.SortByDate)
+	if err != nil {
+		return nil, err
 	}
 
-	return &event, nil
+	search := repository.NearbySearch{Latitude: latitude, Longitude: longitude, RadiusKm: radiusKm, Filter: futureOnly(filter)}
+	events, err := s.events.Nearby(search, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.events.CountNearby(search)
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(result EventDistance) (any, uuid.UUID) { return result.Distance, result.Event.ID }
+	if page.Sort.Field == repository.SortByDate {
+		key = func(result EventDistance) (any, uuid.UUID) { return result.Event.Date, result.Event.ID }
+	}
+	return newPageResult(events, total, page, key), nil
 }
 
+// Função para buscar um evento específico; os rascunhos só são visíveis para a equipe
+func (s *EventService) GetEvent(eventID, userID uuid.UUID) (*database.Event, error) {
+	// Busca o evento no banco de dados pelo ID com o organizador
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
 
-// Função para atualizar um evento
-func UpdateEvent(id uuid.UUID, name, description, location string, date time.Time, organizerID uuid.UUID) (*database.Event, error) {
-	var event database.Event
+	if event.StatusAt(time.Now()) == database.EventDraft && !s.access.isTeamMember(event, userID) {
+		return nil, ErrEventNotFound
+	}
+
+	return event, nil
+}
 
+// Função para atualizar um evento
+func (s *EventService) UpdateEvent(id uuid.UUID, input EventInput, userID uuid.UUID) (*database.Event, error) {
 	// Verifica se o evento existe
-	if err := database.DB.First(&event, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("event not found")
+	event, err := s.events.FindByID(id)
+	if err != nil {
+		return nil, ErrEventNotFound
 	}
 
-	// Verifica se o organizador é o mesmo do evento
-	if event.OrganizerID != organizerID {
-		return nil, fmt.Errorf("you are not authorized to update this event")
+	// Verifica se o usuário é o organizador ou um membro da equipe com permissão
+	if err := s.access.authorize(event, userID, PermissionUpdateEvent); err != nil {
+		return nil, err
 	}
 
 	// Atualiza os dados do evento
-	event.Name = name
-	event.Description = description
-	event.Location = location
-	event.Date = date
+	if err := s.apply(event, input); err != nil {
+		return nil, err
+	}
+	if err := s.checkCapacity([]database.Event{*event}); err != nil {
+		return nil, err
+	}
 
 	// Salva as alterações no banco
-	if err := database.DB.Save(&event).Error; err != nil {
+	if err := s.events.Update(event); err != nil {
 		return nil, err
 	}
 
-	return &event, nil
+	return event, nil
 }
 
-// Função para deletar um evento
-func DeleteEvent(id uuid.UUID, organizerID uuid.UUID) error {
-	var event database.Event
+// Função para garantir que a capacidade dos eventos não fica abaixo dos tickets já vendidos
+func (s *EventService) checkCapacity(events []database.Event) error {
+	ids := []uuid.UUID{}
+	for _, event := range events {
+		if event.Capacity > 0 {
+			ids = append(ids, event.ID)
+		}
+	}
+	sold, err := s.tickets.CountSoldByEvents(ids)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if event.Capacity > 0 && sold[event.ID] > int64(event.Capacity) {
+			return ErrCapacityBelowSold
+		}
+	}
+	return nil
+}
 
+// Função para deletar um evento
+func (s *EventService) DeleteEvent(id uuid.UUID, userID uuid.UUID) error {
 	// Verifica se o evento existe
-	if err := database.DB.First(&event, "id = ?", id).Error; err != nil {
-		return fmt.Errorf("event not found")
+	event, err := s.events.FindByID(id)
+	if err != nil {
+		return ErrEventNotFound
 	}
 
-	// Verifica se o organizador é o mesmo do evento
-	if event.OrganizerID != organizerID {
-		return fmt.Errorf("you are not authorized to delete this event")
+	// Verifica se o usuário é o organizador ou um membro da equipe com permissão
+	if err := s.access.authorize(event, userID, PermissionDeleteEvent); err != nil {
+		return err
 	}
 
-	// Deleta o evento
-	if err := database.DB.Delete(&event).Error; err != nil {
+	// Deleta o evento e, em seguida, os arquivos das imagens dele
+	if err := s.events.Delete(event.ID); err != nil {
 		return err
 	}
-
+	removeImageFiles(s.storage, event.Images...)
 	return nil
 }
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"slices"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Períodos aceitos no agrupamento das vendas
+var salesBuckets = []string{repository.BucketHour, repository.BucketDay, repository.BucketWeek, repository.BucketMonth}
+
+// Painel de vendas de um evento: os números agregados e as vendas por período
+type EventStats struct {
+	Event   database.Event
+	Tickets repository.TicketStats
+	Bucket  string
+	Sales   []repository.SalesBucket
+}
+
+// Serviço do painel de vendas dos organizadores
+type StatsService struct {
+	events   repository.EventRepository
+	tickets  repository.TicketRepository
+	access   *eventAccess
+	timeZone *time.Location // Fuso em que as vendas são agrupadas por dia, semana e mês
+}
+
+// Função para criar o serviço do painel de vendas
+func NewStatsService(repos repository.Repositories, access *eventAccess, timeZone *time.Location) *StatsService {
+	if timeZone == nil {
+		timeZone = time.UTC
+	}
+	return &StatsService{events: repos.Events, tickets: repos.Tickets, access: access, timeZone: timeZone}
+}
+
+// Função para montar o painel de vendas do evento (organizador, co-organizador ou
+// financeiro); os números são agregados no banco, sem carregar os tickets
+func (s *StatsService) GetEventStats(eventID, userID uuid.UUID, bucket string) (*EventStats, error) {
+	if bucket == "" {
+		bucket = repository.BucketDay
+	}
+	if !slices.Contains(salesBuckets, bucket) {
+		return nil, apperrors.InvalidFields(map[string]string{"interval": "must be one of: hour, day, week, month"})
+	}
+
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Verifica se o usuário é o organizador ou um membro da equipe com acesso aos relatórios
+	if err := s.access.authorize(event, userID, PermissionViewReports); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.tickets.Stats(eventID)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.tickets.SalesOverTime(eventID, bucket, s.timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventStats{Event: *event, Tickets: *tickets, Bucket: bucket, Sales: sales}, nil
+}
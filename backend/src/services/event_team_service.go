@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ações sobre um evento que dependem do papel na equipe
+const (
+	PermissionUpdateEvent     = "event:update"
+	PermissionDeleteEvent     = "event:delete"
+	PermissionManageTeam      = "team:manage"
+	PermissionValidateTickets = "tickets:validate"
+	PermissionViewReports     = "reports:view"
+	PermissionViewAttendees   = "attendees:view"
+)
+
+// Permissões de cada papel; o organizador do evento tem todas
+var teamRolePermissions = map[string]map[string]bool{
+	"co-organizer": {
+		PermissionUpdateEvent:     true,
+		PermissionDeleteEvent:     true,
+		PermissionValidateTickets: true,
+		PermissionViewReports:     true,
+		PermissionViewAttendees:   true,
+	},
+	"finance": {
+		PermissionViewReports: true,
+	},
+	"scanner": {
+		PermissionValidateTickets: true,
+	},
+}
+
+var (
+	ErrEventNotFound         = apperrors.NewNotFound("event_not_found", "event not found")
+	ErrEventPermissionDenied = apperrors.NewForbidden("event_permission_denied", "you are not authorized to perform this action on this event")
+	ErrMemberEmailRequired   = apperrors.NewValidation("email_required", "email is required")
+	ErrInvalidTeamRole       = apperrors.NewValidation("invalid_team_role", "invalid team role")
+	ErrMemberAlreadyInvited  = apperrors.NewConflict("member_already_invited", "this email has already been invited to the event")
+	ErrMemberNotFound        = apperrors.NewNotFound("member_not_found", "team member not found")
+	ErrInvitationNotFound    = apperrors.NewNotFound("invitation_not_found", "invitation not found")
+)
+
+// Verificação das permissões da equipe, partilhada pelos serviços de eventos e tickets
+type eventAccess struct {
+	members repository.EventMemberRepository
+}
+
+// Função para verificar se o usuário pode executar uma ação sobre o evento
+func (a *eventAccess) authorize(event *database.Event, userID uuid.UUID, permission string) error {
+	if event.OrganizerID == userID {
+		return nil
+	}
+
+	member, err := a.members.FindAccepted(event.ID, userID)
+	if err == nil && teamRolePermissions[member.Role][permission] {
+		return nil
+	}
+
+	return ErrEventPermissionDenied
+}
+
+// Função para verificar se o usuário é o organizador ou um membro da equipe do evento
+func (a *eventAccess) isTeamMember(event *database.Event, userID uuid.UUID) bool {
+	if event.OrganizerID == userID {
+		return true
+	}
+	_, err := a.members.FindAccepted(event.ID, userID)
+	return err == nil
+}
+
+// Serviço da equipe dos eventos (convites, membros e relatórios)
+type TeamService struct {
+	events  repository.EventRepository
+	members repository.EventMemberRepository
+	tickets repository.TicketRepository
+	access  *eventAccess
+}
+
+// Função para criar o serviço da equipe dos eventos
+func NewTeamService(repos repository.Repositories, access *eventAccess) *TeamService {
+	return &TeamService{
+		events:  repos.Events,
+		members: repos.EventMembers,
+		tickets: repos.Tickets,
+		access:  access,
+	}
+}
+
+// Busca o evento e verifica a permissão do usuário sobre ele
+func (s *TeamService) authorizedEvent(eventID, userID uuid.UUID, permission string) (*database.Event, error) {
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if err := s.access.authorize(event, userID, permission); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Função para convidar um membro para a equipe do evento pelo email
+func (s *TeamService) InviteEventMember(eventID, inviterID uuid.UUID, email, role string) (*database.EventMember, error) {
+	if _, err := s.authorizedEvent(eventID, inviterID, PermissionManageTeam); err != nil {
+		return nil, err
+	}
+
+	if _, ok := teamRolePermissions[role]; !ok {
+		return nil, ErrInvalidTeamRole
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, ErrMemberEmailRequired
+	}
+
+	if _, err := s.members.FindByEventAndEmail(eventID, email); err == nil {
+		return nil, ErrMemberAlreadyInvited
+	}
+
+	// O convite fica pendente até o usuário com este email aceitá-lo
+	member := database.EventMember{
+		EventID:     eventID,
+		Email:       email,
+		Role:        role,
+		Status:      "pendente",
+		InvitedByID: inviterID,
+	}
+	if err := s.members.Create(&member); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrMemberAlreadyInvited
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// Função para listar a equipe de um evento
+func (s *TeamService) GetEventMembers(eventID, userID uuid.UUID) ([]database.EventMember, error) {
+	if _, err := s.authorizedEvent(eventID, userID, PermissionManageTeam); err != nil {
+		return nil, err
+	}
+
+	return s.members.ListByEvent(eventID)
+}
+
+// Função para remover um membro (ou cancelar um convite) da equipe do evento
+func (s *TeamService) RemoveEventMember(eventID, memberID, userID uuid.UUID) error {
+	if _, err := s.authorizedEvent(eventID, userID, PermissionManageTeam); err != nil {
+		return err
+	}
+
+	removed, err := s.members.Delete(memberID, eventID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// Função para listar os convites pendentes enviados para o email do usuário,
+// que precisa estar verificado
+func (s *TeamService) GetPendingInvitations(user *database.User) ([]database.EventMember, error) {
+	if err := requireVerifiedEmail(user); err != nil {
+		return nil, err
+	}
+	return s.members.ListPendingByEmail(strings.ToLower(user.Email))
+}
+
+// Função para aceitar um convite para a equipe de um evento
+func (s *TeamService) AcceptInvitation(invitationID uuid.UUID, user *database.User) (*database.EventMember, error) {
+	if err := requireVerifiedEmail(user); err != nil {
+		return nil, err
+	}
+	member, err := s.members.FindPending(invitationID, strings.ToLower(user.Email))
+	if err != nil {
+		return nil, ErrInvitationNotFound
+	}
+
+	now := time.Now()
+	member.UserID = &user.ID
+	member.Status = "aceito"
+	member.AcceptedAt = &now
+	if err := s.members.Update(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// Resumo das vendas de um evento
+type EventSummary struct {
+	EventID          uuid.UUID `json:"event_id"`
+	TicketsSold      int64     `json:"tickets_sold"`
+	TicketsUsed      int64     `json:"tickets_used"`
+	TicketsCancelled int64     `json:"tickets_cancelled"`
+}
+
+// Função para obter o resumo das vendas de um evento (organizador, co-organizador ou financeiro)
+func (s *TeamService) GetEventSummary(eventID, userID uuid.UUID) (*EventSummary, error) {
+	if _, err := s.authorizedEvent(eventID, userID, PermissionViewReports); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.tickets.CountByStatus(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventSummary{
+		EventID:          eventID,
+		TicketsSold:      counts["valido"] + counts["usado"],
+		TicketsUsed:      counts["usado"],
+		TicketsCancelled: counts["cancelado"],
+	}, nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"src/apperrors"
+	"src/database"
+	"src/imaging"
+	"src/repository"
+	"src/storage"
+
+	"github.com/google/uuid"
+)
+
+// Limites das imagens dos eventos
+const (
+	MaxImageSize     = 5 << 20 // Tamanho máximo do arquivo enviado (5 MB)
+	MaxGalleryImages = 20
+)
+
+// Dimensões máximas das miniaturas (em JPEG, mantendo a proporção)
+const (
+	thumbnailSize = 320
+	mediumSize    = 1024
+)
+
+var (
+	ErrImageNotFound = apperrors.NewNotFound("image_not_found", "image not found")
+	ErrMediaNotFound = apperrors.NewNotFound("media_not_found", "file not found")
+	ErrGalleryFull   = apperrors.NewConflict("gallery_full", fmt.Sprintf("an event can have at most %d gallery images", MaxGalleryImages))
+	ErrImageTooLarge = &apperrors.Error{
+		Kind:    apperrors.TooLarge,
+		Code:    "image_too_large",
+		Message: "image is too large",
+		Fields:  map[string]string{"image": fmt.Sprintf("must be at most %d MB", MaxImageSize>>20)},
+	}
+)
+
+// Erro de validação do arquivo enviado
+func invalidImage(message string) error {
+	return &apperrors.Error{
+		Kind:    apperrors.Validation,
+		Code:    "invalid_image",
+		Message: "invalid image",
+		Fields:  map[string]string{"image": message},
+	}
+}
+
+// Serviço das imagens dos eventos (capa e galeria)
+type ImageService struct {
+	images  repository.EventImageRepository
+	events  repository.EventRepository
+	storage storage.Storage
+	access  *eventAccess
+}
+
+// Função para criar o serviço das imagens
+func NewImageService(repos repository.Repositories, store storage.Storage, access *eventAccess) *ImageService {
+	return &ImageService{images: repos.EventImages, events: repos.Events, storage: store, access: access}
+}
+
+// Função para enviar uma imagem do evento: valida o tipo pelo conteúdo e o tamanho,
+// guarda o original e as miniaturas e registra a imagem; uma nova capa substitui a anterior
+func (s *ImageService) UploadEventImage(eventID uuid.UUID, kind string, data []byte, userID uuid.UUID) (*database.EventImage, error) {
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Verifica se o usuário é o organizador ou um membro da equipe com permissão
+	if err := s.access.authorize(event, userID, PermissionUpdateEvent); err != nil {
+		return nil, err
+	}
+
+	if len(data) > MaxImageSize {
+		return nil, ErrImageTooLarge
+	}
+	if kind == database.ImageGallery {
+		count, err := s.images.CountByEvent(eventID, database.ImageGallery)
+		if err != nil {
+			return nil, err
+		}
+		if count >= MaxGalleryImages {
+			return nil, ErrGalleryFull
+		}
+	}
+
+	decoded, err := imaging.Decode(data)
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		return nil, invalidImage("must be a JPEG, PNG or GIF image")
+	case errors.Is(err, imaging.ErrTooManyPixels):
+		return nil, invalidImage(fmt.Sprintf("must have at most %d megapixels", imaging.MaxPixels/1_000_000))
+	case err != nil:
+		return nil, invalidImage("could not be read as an image")
+	}
+
+	image := database.EventImage{
+		ID:          uuid.New(),
+		EventID:     eventID,
+		Kind:        kind,
+		ContentType: decoded.ContentType,
+		Size:        int64(len(data)),
+		Width:       decoded.Width,
+		Height:      decoded.Height,
+	}
+
+	// Em caso de falha, remove os arquivos que já foram guardados
+	if err := s.storeFiles(&image, data, decoded); err != nil {
+		removeImageFiles(s.storage, image)
+		return nil, err
+	}
+	image.URL = s.storage.URL(image.Key)
+	image.ThumbnailURL = s.storage.URL(image.ThumbnailKey)
+	image.MediumURL = s.storage.URL(image.MediumKey)
+
+	replaced, err := s.images.Create(&image)
+	if err != nil {
+		removeImageFiles(s.storage, image)
+		return nil, err
+	}
+	if replaced != nil {
+		removeImageFiles(s.storage, *replaced)
+	}
+	return &image, nil
+}
+
+// Função para remover uma imagem do evento e os arquivos dela
+func (s *ImageService) DeleteEventImage(eventID, imageID, userID uuid.UUID) error {
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	// Verifica se o usuário é o organizador ou um membro da equipe com permissão
+	if err := s.access.authorize(event, userID, PermissionUpdateEvent); err != nil {
+		return err
+	}
+
+	image, err := s.images.FindByID(imageID)
+	if err != nil || image.EventID != eventID {
+		return ErrImageNotFound
+	}
+	deleted, err := s.images.Delete(imageID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrImageNotFound
+	}
+
+	removeImageFiles(s.storage, *image)
+	return nil
+}
+
+// Função para abrir um arquivo do armazenamento (servido pela API no armazenamento local)
+func (s *ImageService) OpenMedia(key string) (io.ReadCloser, error) {
+	file, err := s.storage.Open(key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrMediaNotFound
+	}
+	return file, err
+}
+
+// Função para guardar o original como foi enviado e as miniaturas, preenchendo as chaves da imagem
+func (s *ImageService) storeFiles(image *database.EventImage, data []byte, decoded *imaging.Image) error {
+	prefix := fmt.Sprintf("events/%s/%s/", image.EventID, image.ID)
+
+	image.Key = prefix + "original." + decoded.Extension
+	if err := s.storage.Put(image.Key, bytes.NewReader(data), decoded.ContentType); err != nil {
+		image.Key = ""
+		return err
+	}
+
+	var err error
+	if image.ThumbnailKey, err = s.storeResized(prefix+"thumb.jpg", decoded, thumbnailSize); err != nil {
+		return err
+	}
+	image.MediumKey, err = s.storeResized(prefix+"medium.jpg", decoded, mediumSize)
+	return err
+}
+
+// Função para guardar a imagem reduzida para caber em size x size; retorna a chave
+func (s *ImageService) storeResized(key string, decoded *imaging.Image, size int) (string, error) {
+	resized, err := imaging.EncodeJPEG(imaging.Fit(decoded, size, size))
+	if err != nil {
+		return "", err
+	}
+	if err := s.storage.Put(key, bytes.NewReader(resized), "image/jpeg"); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Função para remover os arquivos das imagens; as falhas só são registradas no log,
+// pois o registro no banco já foi removido e o arquivo órfão não é exposto pela API
+func removeImageFiles(store storage.Storage, images ...database.EventImage) {
+	for _, image := range images {
+		for _, key := range []string{image.Key, image.ThumbnailKey, image.MediumKey} {
+			if key == "" {
+				continue
+			}
+			if err := store.Delete(key); err != nil {
+				log.Println("Erro ao remover arquivo", key+":", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Parâmetros da proteção contra força bruta no login
+const (
+	loginFreeAttempts      = 3                // Falhas permitidas antes de aplicar atrasos
+	loginMaxDelay          = time.Minute      // Atraso máximo entre tentativas
+	loginFailureWindow     = 15 * time.Minute // Falhas mais antigas que isso são esquecidas
+	loginLockoutDuration   = 15 * time.Minute // Duração do bloqueio temporário
+	accountLockoutFailures = 10               // Falhas por conta até o bloqueio
+	ipLockoutFailures      = 50               // Falhas por IP até o bloqueio
+)
+
+// Função para criar o erro devolvido quando o login está temporariamente bloqueado ou atrasado
+func loginThrottledError(retryAfter time.Duration) error {
+	return &apperrors.Error{
+		Kind:       apperrors.TooManyRequests,
+		Code:       "login_throttled",
+		Message:    "too many failed login attempts, try again later",
+		RetryAfter: retryAfter,
+	}
+}
+
+// Origem de uma tentativa de login
+type LoginClient struct {
+	IP        string
+	UserAgent string
+}
+
+// Função para obter a origem da tentativa de login a partir da requisição
+func (s *AuthService) NewLoginClient(r *http.Request) LoginClient {
+	return LoginClient{IP: clientIP(r, s.trustProxyHeaders), UserAgent: r.UserAgent()}
+}
+
+// Só confia em X-Forwarded-For quando a API está atrás de um proxy conhecido
+func clientIP(r *http.Request, trustProxyHeaders bool) string {
+	if trustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Contador de falhas de uma chave (conta ou IP); pending são as tentativas já
+// liberadas por check e ainda sem resultado, que contam como falhas até terminarem
+type loginFailures struct {
+	count       int
+	pending     int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// Guarda em memória os contadores de falhas por conta e por IP
+type loginGuard struct {
+	mu      sync.Mutex
+	entries map[string]*loginFailures
+}
+
+func newLoginGuard() *loginGuard {
+	return &loginGuard{entries: map[string]*loginFailures{}}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Verifica se a conta ou o IP podem tentar um novo login agora e, se puderem,
+// reserva a tentativa na mesma seção crítica: assim uma rajada de requisições
+// simultâneas não passa toda pela verificação antes de a primeira falha ser
+// registrada. Toda tentativa liberada termina com fail, reset ou release
+func (g *loginGuard) check(email, ip string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	keys := []string{accountKey(email), ipKey(ip)}
+	var retryAfter time.Duration
+	for _, key := range keys {
+		entry, ok := g.entries[key]
+		if !ok || entry.expired(now) {
+			continue
+		}
+
+		wait := entry.lockedUntil.Sub(now)
+
+		// Atraso progressivo: 1s, 2s, 4s, ... após as tentativas livres
+		if attempts := entry.count + entry.pending; attempts >= loginFreeAttempts {
+			delay := loginMaxDelay
+			if shift := attempts - loginFreeAttempts; shift < 6 {
+				delay = min(time.Second<<shift, loginMaxDelay)
+			}
+			if d := entry.last.Add(delay).Sub(now); d > wait {
+				wait = d
+			}
+		}
+
+		if wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return loginThrottledError(retryAfter.Round(time.Second) + time.Second)
+	}
+
+	for _, key := range keys {
+		entry, ok := g.entries[key]
+		if !ok || entry.expired(now) {
+			entry = &loginFailures{}
+			g.entries[key] = entry
+		}
+		entry.pending++
+		entry.last = now
+	}
+	return nil
+}
+
+// As falhas antigas são esquecidas quando não há bloqueio nem tentativas em andamento
+func (entry *loginFailures) expired(now time.Time) bool {
+	return entry.pending == 0 && now.Sub(entry.last) > loginFailureWindow && now.After(entry.lockedUntil)
+}
+
+// Regista como falha a tentativa reservada para a conta e para o IP
+func (g *loginGuard) fail(email, ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	g.increment(accountKey(email), accountLockoutFailures, now)
+	g.increment(ipKey(ip), ipLockoutFailures, now)
+
+	// Evita que o mapa cresça indefinidamente
+	if len(g.entries) > 10000 {
+		for key, entry := range g.entries {
+			if entry.expired(now) {
+				delete(g.entries, key)
+			}
+		}
+	}
+}
+
+func (g *loginGuard) increment(key string, lockoutFailures int, now time.Time) {
+	entry, ok := g.entries[key]
+	if !ok {
+		entry = &loginFailures{}
+		g.entries[key] = entry
+	}
+
+	entry.pending = max(entry.pending-1, 0)
+	entry.count++
+	entry.last = now
+	if entry.count >= lockoutFailures {
+		entry.lockedUntil = now.Add(loginLockoutDuration)
+	}
+}
+
+// Limpa as falhas da conta após um login bem-sucedido e libera a tentativa reservada para o IP
+func (g *loginGuard) reset(email, ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.entries, accountKey(email))
+	g.releaseKey(ipKey(ip))
+}
+
+// Libera a tentativa reservada sem contá-la como falha (ex: o código do 2FA foi pedido)
+func (g *loginGuard) release(email, ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.releaseKey(accountKey(email))
+	g.releaseKey(ipKey(ip))
+}
+
+func (g *loginGuard) releaseKey(key string) {
+	if entry, ok := g.entries[key]; ok {
+		entry.pending = max(entry.pending-1, 0)
+	}
+}
+
+// Função para registrar uma tentativa de login falhada para consulta do administrador
+func (s *AuthService) recordFailedLogin(email string, userID *uuid.UUID, client LoginClient, reason string) {
+	s.guard.fail(email, client.IP)
+
+	attempt := database.LoginAttempt{
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		UserID:    userID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Reason:    reason,
+	}
+	if err := s.loginAttempts.Create(&attempt); err != nil {
+		log.Println("Erro ao registrar tentativa de login:", err)
+	}
+}
+
+// Função para listar as tentativas de login falhadas (mais recentes primeiro)
+func (s *AuthService) GetFailedLoginAttempts(filter LoginAttemptFilter, request PageRequest) (*Page[database.LoginAttempt], error) {
+	// A listagem só é ordenada pela data, da mais recente para a mais antiga
+	if request.Sort == "" {
+		request.Sort = "-" + repository.SortByCreatedAt
+	}
+	page, err := newPage(request, repository.SortByCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Email = strings.ToLower(strings.TrimSpace(filter.Email))
+	attempts, err := s.loginAttempts.List(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.loginAttempts.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPageResult(attempts, total, page, func(attempt database.LoginAttempt) (any, uuid.UUID) {
+		return attempt.CreatedAt, attempt.ID
+	}), nil
+}
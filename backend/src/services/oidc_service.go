@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log"
+	"sort"
+	"src/apperrors"
+	"src/config"
+	"src/database"
+	"src/repository"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// Tempo máximo entre o início do login social e o retorno do provedor
+const oidcStateTTL = 10 * time.Minute
+
+var (
+	ErrUnknownOIDCProvider     = apperrors.NewNotFound("unknown_identity_provider", "unknown identity provider")
+	ErrInvalidOIDCState        = apperrors.NewValidation("invalid_login_state", "invalid or expired login state")
+	ErrOIDCEmailNotVerified    = apperrors.NewForbidden("email_not_verified", "the identity provider did not return a verified email")
+	ErrOIDCTwoFactorEnabled    = apperrors.NewForbidden("two_factor_enabled", "two-factor authentication is enabled for this account, sign in with email and password")
+	ErrOIDCLoginFailed         = apperrors.NewUnauthorized("social_login_failed", "social login failed")
+	ErrIdentityProviderOffline = apperrors.New(apperrors.Unavailable, "identity_provider_unavailable", "identity provider is unavailable")
+	ErrInvalidOIDCLink         = apperrors.NewValidation("invalid_link_token", "invalid or expired account link")
+)
+
+// Provedor configurado; a descoberta (.well-known) é feita no primeiro uso
+type oidcProvider struct {
+	config   config.OIDCProvider
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// Estado de um login em andamento (proteção CSRF, nonce e verificador PKCE)
+type oidcLoginState struct {
+	provider  string
+	nonce     string
+	verifier  string
+	expiresAt time.Time
+}
+
+// Identidade externa à espera da confirmação da senha da conta local com o mesmo email
+type oidcPendingLink struct {
+	provider  string
+	subject   string
+	email     string
+	userID    uuid.UUID
+	expiresAt time.Time
+}
+
+// Resultado do login social: a sessão ou, quando o email pertence a uma conta
+// local ainda não verificada, o token para confirmar a ligação com a senha
+type OIDCLogin struct {
+	Token     string
+	User      *database.User
+	LinkToken string
+}
+
+// Serviço de login social via OpenID Connect
+type OIDCService struct {
+	users      repository.UserRepository
+	identities repository.IdentityRepository
+	auth       *AuthService
+
+	providers map[string]*oidcProvider
+
+	statesMu sync.Mutex
+	states   map[string]oidcLoginState
+	links    map[string]oidcPendingLink // Ligações pendentes, pelo token devolvido ao cliente
+}
+
+// Função para criar o serviço de login social, sem provedores registrados
+func NewOIDCService(repos repository.Repositories, auth *AuthService) *OIDCService {
+	return &OIDCService{
+		users:      repos.Users,
+		identities: repos.Identities,
+		auth:       auth,
+		providers:  map[string]*oidcProvider{},
+		states:     map[string]oidcLoginState{},
+		links:      map[string]oidcPendingLink{},
+	}
+}
+
+// Função para registrar um provedor OIDC (ex: um emissor local de teste)
+func (s *OIDCService) RegisterProvider(provider config.OIDCProvider) {
+	s.providers[provider.Name] = &oidcProvider{config: provider}
+}
+
+// Função para listar os nomes dos provedores configurados
+func (s *OIDCService) ProviderNames() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Faz a descoberta do provedor apenas uma vez
+func (p *oidcProvider) discover() (*oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	provider, err := oidc.NewProvider(ctx, p.config.IssuerURL)
+	if err != nil {
+		// Os detalhes ficam só no log do servidor, não na resposta
+		log.Printf("OIDC: falha na descoberta do provedor %s: %v", p.config.Name, err)
+		return nil, ErrIdentityProviderOffline
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+func (p *oidcProvider) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+}
+
+// Função para iniciar o login social: retorna a URL de autorização do provedor
+func (s *OIDCService) StartLogin(providerName string) (string, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return "", ErrUnknownOIDCProvider
+	}
+
+	provider, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	s.statesMu.Lock()
+	s.purgeExpiredStates()
+	s.states[state] = oidcLoginState{
+		provider:  providerName,
+		nonce:     nonce,
+		verifier:  verifier,
+		expiresAt: time.Now().Add(oidcStateTTL),
+	}
+	s.statesMu.Unlock()
+
+	// Authorization code + PKCE (S256)
+	return p.oauth2Config(provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Função para concluir o login social: troca o código, valida o ID token e emite o
+// nosso JWT (ou o token da ligação pendente com uma conta local não verificada)
+func (s *OIDCService) FinishLogin(ctx context.Context, providerName, state, code string) (*OIDCLogin, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownOIDCProvider
+	}
+
+	// O estado só pode ser usado uma vez
+	s.statesMu.Lock()
+	loginState, found := s.states[state]
+	delete(s.states, state)
+	s.statesMu.Unlock()
+
+	if !found || loginState.provider != providerName || time.Now().After(loginState.expiresAt) {
+		return nil, ErrInvalidOIDCState
+	}
+
+	provider, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	// Troca o código de autorização pelos tokens, enviando o verificador PKCE
+	oauthToken, err := p.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(loginState.verifier))
+	if err != nil {
+		return nil, loginFailed(providerName, "failed to exchange authorization code", err)
+	}
+
+	rawIDToken, ok := oauthToken.Extra("id_token").(string)
+	if !ok {
+		return nil, loginFailed(providerName, "identity provider did not return an id_token", nil)
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.config.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, loginFailed(providerName, "invalid id_token", err)
+	}
+	if idToken.Nonce != loginState.nonce {
+		return nil, loginFailed(providerName, "invalid id_token nonce", nil)
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, loginFailed(providerName, "invalid id_token claims", err)
+	}
+
+	user, linkToken, err := s.linkOrCreateUser(providerName, idToken.Subject, claims.Email, claims.EmailVerified, claims.Name)
+	if err != nil {
+		return nil, err
+	}
+	if linkToken != "" {
+		return &OIDCLogin{LinkToken: linkToken}, nil
+	}
+
+	// O login social não substitui o segundo fator
+	if user.TOTPEnabled {
+		return nil, ErrOIDCTwoFactorEnabled
+	}
+
+	token, err := s.auth.generateJWT(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCLogin{Token: token, User: user}, nil
+}
+
+// Função para registrar no log o motivo da falha do login social e devolver o erro
+// genérico, sem expor ao cliente os detalhes do provedor
+func loginFailed(provider, reason string, err error) error {
+	if err != nil {
+		reason += ": " + err.Error()
+	}
+	log.Printf("OIDC: login com %s falhou: %s", provider, reason)
+	return ErrOIDCLoginFailed
+}
+
+// Procura a identidade externa; se não existir, liga-a ao usuário com o mesmo email
+// (se o email da conta local já foi verificado) ou cria um novo comprador. Com uma
+// conta local não verificada, devolve o token da ligação pendente, que só é feita
+// depois de o dono da conta confirmar a senha (ConfirmLink)
+func (s *OIDCService) linkOrCreateUser(provider, subject, email string, emailVerified bool, name string) (*database.User, string, error) {
+	identity, err := s.identities.FindByProviderSubject(provider, subject)
+	if err == nil {
+		return &identity.User, "", nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, "", err
+	}
+
+	if email == "" || !emailVerified {
+		return nil, "", ErrOIDCEmailNotVerified
+	}
+
+	identity = &database.UserIdentity{
+		Provider: provider,
+		Subject:  subject,
+		Email:    email,
+	}
+
+	user, err := s.users.FindByEmail(email)
+	if err == nil {
+		// Qualquer pessoa pode registrar uma conta com o email de outra; sem a
+		// verificação, a ligação automática daria a conta dela ao dono do email
+		if !user.EmailVerified {
+			linkToken, err := s.addPendingLink(identity, user.ID)
+			return nil, linkToken, err
+		}
+		identity.UserID = user.ID
+		if err := s.identities.Link(identity, nil); err != nil {
+			return nil, "", err
+		}
+		return user, "", nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, "", err
+	}
+
+	if name == "" {
+		name = email
+	}
+	// O provedor já confirmou o email
+	user = &database.User{Name: name, Email: email, Role: "buyer", EmailVerified: true}
+
+	// Contas criadas pelo login social não têm senha utilizável
+	password, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := user.SetPassword(password); err != nil {
+		return nil, "", err
+	}
+
+	// O usuário e a identidade são criados na mesma transação
+	if err := s.identities.Link(identity, user); err != nil {
+		return nil, "", err
+	}
+
+	return user, "", nil
+}
+
+// Função para guardar a ligação pendente e gerar o token devolvido ao cliente
+func (s *OIDCService) addPendingLink(identity *database.UserIdentity, userID uuid.UUID) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	s.statesMu.Lock()
+	defer s.statesMu.Unlock()
+
+	s.purgeExpiredStates()
+	s.links[token] = oidcPendingLink{
+		provider:  identity.Provider,
+		subject:   identity.Subject,
+		email:     identity.Email,
+		userID:    userID,
+		expiresAt: time.Now().Add(oidcStateTTL),
+	}
+	return token, nil
+}
+
+// Função para confirmar a ligação pendente com a senha (e o 2FA) da conta local,
+// como no login; depois disso o login social entra direto nessa conta
+func (s *OIDCService) ConfirmLink(linkToken, password, code string, client LoginClient) (string, *database.User, error) {
+	s.statesMu.Lock()
+	link, found := s.links[linkToken]
+	s.statesMu.Unlock()
+
+	if !found || time.Now().After(link.expiresAt) {
+		return "", nil, ErrInvalidOIDCLink
+	}
+
+	account, err := s.users.FindByID(link.userID)
+	if err != nil {
+		return "", nil, ErrInvalidOIDCLink
+	}
+
+	// Mesmas regras do login: bloqueio por tentativas, senha e segundo fator
+	token, user, err := s.auth.LoginUser(account.Email, password, code, client)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// O token só pode ser usado uma vez
+	s.statesMu.Lock()
+	_, found = s.links[linkToken]
+	delete(s.links, linkToken)
+	s.statesMu.Unlock()
+	if !found {
+		return "", nil, ErrInvalidOIDCLink
+	}
+
+	identity := &database.UserIdentity{Provider: link.provider, Subject: link.subject, Email: link.email, UserID: user.ID}
+	if err := s.identities.Link(identity, nil); err != nil {
+		return "", nil, err
+	}
+
+	// O provedor confirmou o email e o dono da conta confirmou a senha
+	if strings.EqualFold(user.Email, link.email) && !user.EmailVerified {
+		user.EmailVerified = true
+		user.EmailVerificationHash, user.EmailVerificationExpiresAt = nil, nil
+		if err := s.users.Update(user); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return token, user, nil
+}
+
+// Remove estados e ligações pendentes expirados (chamada com statesMu bloqueado)
+func (s *OIDCService) purgeExpiredStates() {
+	now := time.Now()
+	for state, loginState := range s.states {
+		if now.After(loginState.expiresAt) {
+			delete(s.states, state)
+		}
+	}
+	for token, link := range s.links {
+		if now.After(link.expiresAt) {
+			delete(s.links, token)
+		}
+	}
+}
+
+// Gera um valor aleatório seguro para URLs
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"src/apperrors"
+	"src/repository"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tamanho das páginas das listagens
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Filtros das listagens, repassados aos repositórios
+type (
+	EventFilter        = repository.EventFilter
+	TicketFilter       = repository.TicketFilter
+	LoginAttemptFilter = repository.LoginAttemptFilter
+	VenueFilter        = repository.VenueFilter
+)
+
+// Parâmetros de paginação de uma listagem
+type PageRequest struct {
+	Limit  int    // 0 = tamanho padrão
+	Cursor string // Cursor devolvido na página anterior
+	Sort   string // Campo de ordenação; com "-" na frente, decrescente
+}
+
+// Página de resultados de uma listagem
+type Page[T any] struct {
+	Items      []T
+	Total      int64  // Total de registros que atendem aos filtros
+	NextCursor string // Vazio na última página
+}
+
+// Conteúdo do cursor, opaco para o cliente (JSON em base64)
+type cursorPayload struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// Campos ordenados por data e por número; os demais são texto
+var (
+	timeSortFields = map[string]bool{
+		repository.SortByDate:      true,
+		repository.SortByCreatedAt: true,
+		repository.SortByEventDate: true,
+	}
+	numberSortFields = map[string]bool{
+		repository.SortByRelevance: true,
+		repository.SortByDistance:  true,
+	}
+)
+
+func invalidPageField(field, message string) error {
+	return apperrors.InvalidFields(map[string]string{field: message})
+}
+
+// Função para converter os parâmetros de paginação na página pedida ao
+// repositório. allowed lista os campos de ordenação aceitos; o primeiro é o
+// padrão. O limite pedido vem com um registro a mais para detectar a próxima página.
+func newPage(request PageRequest, allowed ...string) (repository.Page, error) {
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit < 0 || limit > maxPageSize {
+		return repository.Page{}, invalidPageField("limit", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+	}
+
+	sort := request.Sort
+	if sort == "" {
+		sort = allowed[0]
+	}
+	field := strings.TrimPrefix(sort, "-")
+	if !slices.Contains(allowed, field) {
+		return repository.Page{}, invalidPageField("sort", "must be one of: "+strings.Join(allowed, ", "))
+	}
+
+	page := repository.Page{
+		Limit: limit + 1,
+		Sort:  repository.Sort{Field: field, Desc: strings.HasPrefix(sort, "-")},
+	}
+	if request.Cursor != "" {
+		after, err := decodeCursor(request.Cursor, sort)
+		if err != nil {
+			return repository.Page{}, invalidPageField("cursor", "is invalid for this listing")
+		}
+		page.After = after
+	}
+	return page, nil
+}
+
+// Função para montar a página a partir dos registros buscados com newPage;
+// key devolve o valor de ordenação e o ID de cada registro
+func newPageResult[T any](items []T, total int64, page repository.Page, key func(T) (any, uuid.UUID)) *Page[T] {
+	result := &Page[T]{Items: items, Total: total}
+
+	if limit := page.Limit - 1; len(items) > limit {
+		result.Items = items[:limit]
+		value, id := key(result.Items[limit-1])
+		result.NextCursor = encodeCursor(page.Sort, value, id)
+	}
+	if result.Items == nil {
+		result.Items = []T{}
+	}
+	return result
+}
+
+// Função para codificar a posição do último registro da página
+func encodeCursor(sort repository.Sort, value any, id uuid.UUID) string {
+	payload := cursorPayload{Sort: sort.Field, ID: id}
+	if sort.Desc {
+		payload.Sort = "-" + sort.Field
+	}
+	switch v := value.(type) {
+	case time.Time:
+		payload.Value = v.UTC().Format(time.RFC3339Nano)
+	case string:
+		payload.Value = v
+	case float64:
+		payload.Value = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	encoded, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// Função para decodificar um cursor; ele só vale para a mesma ordenação
+func decodeCursor(cursor, sort string) (*repository.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Sort != sort || payload.ID == uuid.Nil {
+		return nil, fmt.Errorf("cursor for sort %q used with sort %q", payload.Sort, sort)
+	}
+
+	after := &repository.Cursor{Value: payload.Value, ID: payload.ID}
+	switch field := strings.TrimPrefix(sort, "-"); {
+	case timeSortFields[field]:
+		date, err := time.Parse(time.RFC3339Nano, payload.Value)
+		if err != nil {
+			return nil, err
+		}
+		after.Value = date
+	case numberSortFields[field]:
+		number, err := strconv.ParseFloat(payload.Value, 64)
+		if err != nil {
+			return nil, err
+		}
+		after.Value = number
+	}
+	return after, nil
+}
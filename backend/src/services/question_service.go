@@ -0,0 +1,264 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Limites das perguntas do cadastro dos participantes
+const (
+	MaxEventQuestions  = 30
+	MaxQuestionOptions = 50
+	MaxAnswerLength    = 1000 // Tamanho máximo das respostas livres, em caracteres
+)
+
+var (
+	ErrQuestionNotFound = apperrors.NewNotFound("question_not_found", "question not found")
+	ErrTooManyQuestions = apperrors.NewConflict("too_many_questions", fmt.Sprintf("an event can have at most %d questions", MaxEventQuestions))
+)
+
+// Dados de uma pergunta informados pelo organizador
+type QuestionInput struct {
+	Label    string
+	Kind     string // database.QuestionText, ...
+	Required bool
+	Options  []string // Só nas perguntas de escolha
+	Position *int     // Ordem no formulário; sem valor, a nova pergunta vai para o fim e a alterada fica onde está
+}
+
+// Resposta do comprador a uma pergunta: um valor nas perguntas livres e de
+// escolha única, nenhum, um ou vários nas de múltipla escolha
+type QuestionAnswer struct {
+	QuestionID uuid.UUID
+	Values     []string
+}
+
+// Serviço das perguntas do cadastro dos participantes
+type QuestionService struct {
+	questions repository.EventQuestionRepository
+	events    repository.EventRepository
+	access    *eventAccess
+}
+
+// Função para criar o serviço das perguntas
+func NewQuestionService(repos repository.Repositories, access *eventAccess) *QuestionService {
+	return &QuestionService{questions: repos.Questions, events: repos.Events, access: access}
+}
+
+// Função para validar o tipo e as opções e aplicar os dados informados à pergunta
+func (input QuestionInput) apply(question *database.EventQuestion) error {
+	options := make([]database.EventQuestionOption, 0, len(input.Options))
+	for _, option := range input.Options {
+		value := strings.TrimSpace(option)
+		if slices.ContainsFunc(options, func(existing database.EventQuestionOption) bool { return existing.Value == value }) {
+			return apperrors.InvalidFields(map[string]string{"options": "must not repeat an option"})
+		}
+		options = append(options, database.EventQuestionOption{Position: len(options), Value: value})
+	}
+
+	switch {
+	case input.Kind == database.QuestionText && len(options) > 0:
+		return apperrors.InvalidFields(map[string]string{"options": "must be empty for text questions"})
+	case input.Kind != database.QuestionText && len(options) < 2:
+		return apperrors.InvalidFields(map[string]string{"options": "must have at least 2 options for choice questions"})
+	}
+
+	question.Label = strings.TrimSpace(input.Label)
+	question.Kind = input.Kind
+	question.Required = input.Required
+	question.Options = options
+	if input.Position != nil {
+		question.Position = *input.Position
+	}
+	return nil
+}
+
+// Função para buscar o evento e verificar se o usuário pode alterar as perguntas dele
+func (s *QuestionService) authorizedEvent(eventID, userID uuid.UUID) (*database.Event, error) {
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Verifica se o usuário é o organizador ou um membro da equipe com permissão
+	if err := s.access.authorize(event, userID, PermissionUpdateEvent); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Função para buscar uma pergunta do evento
+func (s *QuestionService) eventQuestion(eventID, questionID uuid.UUID) (*database.EventQuestion, error) {
+	question, err := s.questions.FindByID(questionID)
+	if err != nil || question.EventID != eventID {
+		return nil, ErrQuestionNotFound
+	}
+	return question, nil
+}
+
+// Função para listar as perguntas do evento na ordem do formulário (com as
+// mesmas regras de visibilidade do evento: os rascunhos só para a equipe)
+func (s *QuestionService) GetEventQuestions(eventID, userID uuid.UUID) ([]database.EventQuestion, error) {
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+	if event.StatusAt(time.Now()) == database.EventDraft && !s.access.isTeamMember(event, userID) {
+		return nil, ErrEventNotFound
+	}
+
+	return s.questions.ListByEvent(eventID)
+}
+
+// Função para criar uma pergunta no evento
+func (s *QuestionService) CreateQuestion(eventID uuid.UUID, input QuestionInput, userID uuid.UUID) (*database.EventQuestion, error) {
+	if _, err := s.authorizedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.questions.CountByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxEventQuestions {
+		return nil, ErrTooManyQuestions
+	}
+
+	// Sem posição informada, a pergunta vai para o fim do formulário
+	question := database.EventQuestion{EventID: eventID, Position: int(count)}
+	if err := input.apply(&question); err != nil {
+		return nil, err
+	}
+	if err := s.questions.Create(&question); err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// Função para alterar uma pergunta do evento; as respostas já dadas são mantidas
+func (s *QuestionService) UpdateQuestion(eventID, questionID uuid.UUID, input QuestionInput, userID uuid.UUID) (*database.EventQuestion, error) {
+	if _, err := s.authorizedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	question, err := s.eventQuestion(eventID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := input.apply(question); err != nil {
+		return nil, err
+	}
+	if err := s.questions.Update(question); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// Função para remover uma pergunta do evento, com as respostas dadas a ela
+func (s *QuestionService) DeleteQuestion(eventID, questionID, userID uuid.UUID) error {
+	if _, err := s.authorizedEvent(eventID, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.eventQuestion(eventID, questionID); err != nil {
+		return err
+	}
+	deleted, err := s.questions.Delete(questionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrQuestionNotFound
+	}
+	return nil
+}
+
+// Nome do campo dos erros de uma resposta, pelo ID da pergunta
+func answerField(questionID uuid.UUID) string {
+	return "answers[" + questionID.String() + "]"
+}
+
+// Função para validar os valores de uma resposta conforme o tipo da pergunta;
+// retorna os valores sem espaços nas pontas ou a mensagem do erro
+func answerValues(question database.EventQuestion, given []string) ([]string, string) {
+	values := make([]string, 0, len(given))
+	for _, value := range given {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) > 1 && question.Kind != database.QuestionMultiChoice {
+		return nil, "must have a single value"
+	}
+
+	if question.Kind == database.QuestionText {
+		if len(values) == 1 && utf8.RuneCountInString(values[0]) > MaxAnswerLength {
+			return nil, fmt.Sprintf("must be at most %d characters", MaxAnswerLength)
+		}
+		return values, ""
+	}
+
+	options := make([]string, 0, len(question.Options))
+	for _, option := range question.Options {
+		options = append(options, option.Value)
+	}
+	for i, value := range values {
+		if !slices.Contains(options, value) {
+			return nil, "must be one of: " + strings.Join(options, ", ")
+		}
+		if slices.Contains(values[:i], value) {
+			return nil, "must not repeat an option"
+		}
+	}
+	return values, ""
+}
+
+// Função para validar as respostas do comprador às perguntas do evento e
+// convertê-las nas respostas guardadas com o ticket
+func ticketAnswers(questions []database.EventQuestion, answers []QuestionAnswer) ([]database.TicketAnswer, error) {
+	fields := map[string]string{}
+	given := map[uuid.UUID][]string{}
+	for _, answer := range answers {
+		field := answerField(answer.QuestionID)
+		index := slices.IndexFunc(questions, func(question database.EventQuestion) bool { return question.ID == answer.QuestionID })
+		if index < 0 {
+			fields[field] = "is not a question of this event"
+			continue
+		}
+		if _, repeated := given[answer.QuestionID]; repeated {
+			fields[field] = "must be answered only once"
+			continue
+		}
+
+		values, message := answerValues(questions[index], answer.Values)
+		if message != "" {
+			fields[field] = message
+			continue
+		}
+		given[answer.QuestionID] = values
+	}
+
+	var result []database.TicketAnswer
+	for _, question := range questions {
+		field := answerField(question.ID)
+		if question.Required && len(given[question.ID]) == 0 && fields[field] == "" {
+			fields[field] = "is required"
+		}
+		for _, value := range given[question.ID] {
+			result = append(result, database.TicketAnswer{QuestionID: question.ID, Value: value})
+		}
+	}
+
+	if len(fields) > 0 {
+		return nil, apperrors.InvalidFields(fields)
+	}
+	return result, nil
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"slices"
+	"src/apperrors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quantidade máxima de ocorrências de uma série
+const MaxSeriesOccurrences = 100
+
+// Quantidade máxima de períodos percorridos ao expandir a regra, para as regras
+// que quase nunca produzem datas (ex: BYMONTHDAY=31 a cada 12 meses a partir de fevereiro)
+const maxRecurrencePeriods = 1200
+
+// Dias da semana no formato do RRULE
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Regra de recorrência: o subconjunto do RRULE (RFC 5545) aceito nas séries
+type recurrenceRule struct {
+	Frequency string // DAILY, WEEKLY ou MONTHLY
+	Interval  int
+	Weekdays  []time.Weekday // BYDAY, apenas com WEEKLY
+	MonthDays []int          // BYMONTHDAY, apenas com MONTHLY
+	Count     int
+	Until     *time.Time
+}
+
+// Erro de validação da regra de recorrência
+func invalidRecurrence(message string) error {
+	return apperrors.InvalidFields(map[string]string{"recurrence": message})
+}
+
+// Função para interpretar a regra, como "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR,SA;COUNT=10"
+func parseRecurrence(value string) (*recurrenceRule, error) {
+	rule := recurrenceRule{Interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:"), ";") {
+		name, raw, ok := strings.Cut(part, "=")
+		if !ok || raw == "" {
+			return nil, invalidRecurrence("must be a list of NAME=VALUE parts separated by ';'")
+		}
+		if seen[name] {
+			return nil, invalidRecurrence(name + " is repeated")
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			if raw != "DAILY" && raw != "WEEKLY" && raw != "MONTHLY" {
+				return nil, invalidRecurrence("FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+			rule.Frequency = raw
+		case "INTERVAL":
+			interval, err := strconv.Atoi(raw)
+			if err != nil || interval < 1 || interval > 365 {
+				return nil, invalidRecurrence("INTERVAL must be a number between 1 and 365")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(raw)
+			if err != nil || count < 1 || count > MaxSeriesOccurrences {
+				return nil, invalidRecurrence("COUNT must be a number between 1 and " + strconv.Itoa(MaxSeriesOccurrences))
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(raw)
+			if err != nil {
+				return nil, invalidRecurrence("UNTIL must be a date like 20250131 or 20250131T235959Z")
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(raw, ",") {
+				weekday, ok := recurrenceWeekdays[day]
+				if !ok {
+					return nil, invalidRecurrence("BYDAY must list days like MO,WE,FR")
+				}
+				if !slices.Contains(rule.Weekdays, weekday) {
+					rule.Weekdays = append(rule.Weekdays, weekday)
+				}
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(raw, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay < 1 || monthDay > 31 {
+					return nil, invalidRecurrence("BYMONTHDAY must list days between 1 and 31")
+				}
+				if !slices.Contains(rule.MonthDays, monthDay) {
+					rule.MonthDays = append(rule.MonthDays, monthDay)
+				}
+			}
+		default:
+			return nil, invalidRecurrence(name + " is not supported")
+		}
+	}
+
+	switch {
+	case rule.Frequency == "":
+		return nil, invalidRecurrence("FREQ is required")
+	case rule.Count == 0 && rule.Until == nil:
+		return nil, invalidRecurrence("COUNT or UNTIL is required")
+	case rule.Count > 0 && rule.Until != nil:
+		return nil, invalidRecurrence("COUNT and UNTIL cannot be used together")
+	case rule.Weekdays != nil && rule.Frequency != "WEEKLY":
+		return nil, invalidRecurrence("BYDAY is only supported with FREQ=WEEKLY")
+	case rule.MonthDays != nil && rule.Frequency != "MONTHLY":
+		return nil, invalidRecurrence("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	return &rule, nil
+}
+
+// Função para interpretar o UNTIL, com data e hora em UTC ou apenas a data (até o fim do dia)
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return until.Add(24*time.Hour - time.Second), nil
+}
+
+// Função para expandir a regra a partir da primeira data, mantendo o horário
+// dela em todas as ocorrências
+func (rule recurrenceRule) expand(start time.Time) ([]time.Time, error) {
+	dates := []time.Time{}
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	// Datas candidatas de cada período (dia, semana ou mês), já em ordem
+	candidates := func(period int) []time.Time {
+		switch rule.Frequency {
+		case "DAILY":
+			return []time.Time{start.AddDate(0, 0, period*rule.Interval)}
+		case "WEEKLY":
+			// As semanas começam na segunda-feira, como o WKST padrão do RRULE
+			monday := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+7*period*rule.Interval)
+			weekdays := rule.Weekdays
+			if weekdays == nil {
+				weekdays = []time.Weekday{start.Weekday()}
+			}
+			week := []time.Time{}
+			for _, weekday := range weekdays {
+				week = append(week, monday.AddDate(0, 0, (int(weekday)+6)%7))
+			}
+			slices.SortFunc(week, func(a, b time.Time) int { return a.Compare(b) })
+			return week
+		default:
+			first := at(start.Year(), start.Month()+time.Month(period*rule.Interval), 1)
+			monthDays := slices.Clone(rule.MonthDays)
+			if monthDays == nil {
+				monthDays = []int{start.Day()}
+			}
+			slices.Sort(monthDays)
+			month := []time.Time{}
+			for _, day := range monthDays {
+				// Dias que não existem no mês (ex: 31 de abril) são ignorados, como no RRULE
+				if date := at(first.Year(), first.Month(), day); date.Month() == first.Month() {
+					month = append(month, date)
+				}
+			}
+			return month
+		}
+	}
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, date := range candidates(period) {
+			if date.Before(start) {
+				continue
+			}
+			if rule.Until != nil && date.After(*rule.Until) {
+				return dates, nil
+			}
+			if len(dates) == MaxSeriesOccurrences {
+				return nil, invalidRecurrence("must produce at most " + strconv.Itoa(MaxSeriesOccurrences) + " occurrences")
+			}
+			dates = append(dates, date)
+			if len(dates) == rule.Count {
+				return dates, nil
+			}
+		}
+	}
+	return dates, nil
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"slices"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSeriesNotFound         = apperrors.NewNotFound("series_not_found", "series not found")
+	ErrSeriesPermissionDenied = apperrors.NewForbidden("series_permission_denied", "only the organizer of the series can change it")
+	ErrEventSoldOut           = apperrors.NewConflict("event_sold_out", "there are no tickets left for this event")
+	ErrCapacityBelowSold      = apperrors.NewConflict("capacity_below_sold", "the capacity cannot be lower than the tickets already sold")
+)
+
+// Dados de uma série informados pelo organizador: os dados comuns às ocorrências
+// e as datas, por uma regra de recorrência ou por uma lista de datas avulsas
+type SeriesInput struct {
+	Event      EventInput  // Event.Date é a primeira data da regra
+	Recurrence string      // Subconjunto do RRULE (ver recurrence.go)
+	Dates      []time.Time // Datas avulsas, sem regra
+}
+
+// Alterações em massa das ocorrências futuras; os campos nulos ficam como estão
+type SeriesUpdateInput struct {
+	From        *time.Time // Apenas as ocorrências a partir desta data (padrão: agora)
+	Name        *string
+	Description *string
+	Location    *string
+	CategoryID  *uuid.UUID
+	VenueID     *uuid.UUID
+	Tags        []string
+	Capacity    *int
+	Status      string // Mudança de estado, seguindo as transições de cada ocorrência
+}
+
+// Série com as ocorrências e a quantidade de tickets vendidos em cada uma
+type SeriesDetails struct {
+	Series      database.EventSeries
+	Occurrences []database.Event
+	Sold        map[uuid.UUID]int64
+}
+
+// Serviço das séries de eventos recorrentes
+type SeriesService struct {
+	series  repository.SeriesRepository
+	events  repository.EventRepository
+	tickets repository.TicketRepository
+	users   repository.UserRepository
+	event   *EventService
+	access  *eventAccess
+}
+
+// Função para criar o serviço das séries
+func NewSeriesService(repos repository.Repositories, events *EventService, access *eventAccess) *SeriesService {
+	return &SeriesService{
+		series:  repos.Series,
+		events:  repos.Events,
+		tickets: repos.Tickets,
+		users:   repos.Users,
+		event:   events,
+		access:  access,
+	}
+}
+
+// Função para calcular as datas das ocorrências a partir da regra ou das datas avulsas
+func seriesDates(input SeriesInput) ([]time.Time, error) {
+	switch {
+	case input.Recurrence != "" && len(input.Dates) > 0:
+		return nil, apperrors.InvalidFields(map[string]string{"dates": "cannot be combined with recurrence"})
+	case input.Recurrence == "" && len(input.Dates) == 0:
+		return nil, apperrors.InvalidFields(map[string]string{"recurrence": "is required without dates"})
+	case input.Recurrence == "":
+		dates := slices.Clone(input.Dates)
+		slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+		return slices.CompactFunc(dates, time.Time.Equal), nil
+	case input.Event.Date.IsZero():
+		return nil, apperrors.InvalidFields(map[string]string{"date": "is required with recurrence"})
+	}
+
+	rule, err := parseRecurrence(input.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	dates, err := rule.expand(input.Event.Date)
+	if err != nil {
+		return nil, err
+	}
+	if len(dates) == 0 {
+		return nil, invalidRecurrence("must produce at least one occurrence")
+	}
+	return dates, nil
+}
+
+// Função para criar uma série com todas as ocorrências, que começam como rascunho
+func (s *SeriesService) CreateSeries(input SeriesInput, organizerID uuid.UUID) (*SeriesDetails, error) {
+	organizer, err := s.users.FindByID(organizerID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	dates, err := seriesDates(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os dados comuns são validados uma vez e copiados para cada ocorrência
+	input.Event.Date = dates[0]
+	template := database.Event{OrganizerID: organizerID, Organizer: *organizer, Status: database.EventDraft}
+	if err := s.event.apply(&template, input.Event); err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]database.Event, 0, len(dates))
+	for _, date := range dates {
+		occurrence := template
+		occurrence.Date = date
+		occurrence.Tags = slices.Clone(template.Tags)
+		occurrences = append(occurrences, occurrence)
+	}
+
+	series := database.EventSeries{
+		Name:        input.Event.Name,
+		Recurrence:  input.Recurrence,
+		OrganizerID: organizerID,
+		Organizer:   *organizer,
+	}
+	if err := s.series.Create(&series, occurrences); err != nil {
+		return nil, err
+	}
+
+	return &SeriesDetails{Series: series, Occurrences: occurrences, Sold: map[uuid.UUID]int64{}}, nil
+}
+
+// Função para listar as ocorrências da série a partir de uma data, em ordem cronológica
+func (s *SeriesService) occurrences(seriesID uuid.UUID, from *time.Time) ([]database.Event, error) {
+	filter := EventFilter{SeriesID: &seriesID, DateFrom: from}
+	return s.events.List(filter, repository.Page{Sort: repository.Sort{Field: repository.SortByDate}})
+}
+
+// Função para buscar a série do organizador
+func (s *SeriesService) managedSeries(id, userID uuid.UUID) (*database.EventSeries, error) {
+	series, err := s.series.FindByID(id)
+	if err != nil {
+		return nil, ErrSeriesNotFound
+	}
+	if series.OrganizerID != userID {
+		return nil, ErrSeriesPermissionDenied
+	}
+	return series, nil
+}
+
+// Função para buscar uma série com as ocorrências; os compradores só veem as ocorrências publicadas
+func (s *SeriesService) GetSeries(id, userID uuid.UUID) (*SeriesDetails, error) {
+	series, err := s.series.FindByID(id)
+	if err != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	all, err := s.occurrences(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	occurrences := []database.Event{}
+	ids := []uuid.UUID{}
+	for _, occurrence := range all {
+		if occurrence.VisibleAt(now) || s.access.isTeamMember(&occurrence, userID) {
+			occurrences = append(occurrences, occurrence)
+			ids = append(ids, occurrence.ID)
+		}
+	}
+	if len(occurrences) == 0 && series.OrganizerID != userID {
+		return nil, ErrSeriesNotFound
+	}
+
+	sold, err := s.tickets.CountSoldByEvents(ids)
+	if err != nil {
+		return nil, err
+	}
+	return &SeriesDetails{Series: *series, Occurrences: occurrences, Sold: sold}, nil
+}
+
+// Função para alterar de uma vez as ocorrências futuras da série; as ocorrências
+// já realizadas ficam como estão e a alteração é aplicada a todas ou a nenhuma
+func (s *SeriesService) UpdateFutureOccurrences(id uuid.UUID, input SeriesUpdateInput, userID uuid.UUID) ([]database.Event, error) {
+	if _, err := s.managedSeries(id, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now
+	if input.From != nil && input.From.After(now) {
+		from = *input.From
+	}
+	occurrences, err := s.occurrences(id, &from)
+	if err != nil {
+		return nil, err
+	}
+
+	// A categoria e o local são validados uma vez para todas as ocorrências
+	var category *database.Category
+	if input.CategoryID != nil {
+		if category, err = s.event.categories.FindByID(*input.CategoryID); err != nil {
+			return nil, ErrUnknownCategory
+		}
+	}
+	var venue *database.Venue
+	if input.VenueID != nil {
+		if venue, err = s.event.venues.FindByID(*input.VenueID); err != nil {
+			return nil, ErrUnknownVenue
+		}
+	}
+
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+		if input.Name != nil {
+			occurrence.Name = *input.Name
+		}
+		if input.Description != nil {
+			occurrence.Description = *input.Description
+		}
+		if category != nil {
+			occurrence.CategoryID, occurrence.Category = &category.ID, category
+		}
+		if venue != nil {
+			occurrence.VenueID, occurrence.Venue = &venue.ID, venue
+			occurrence.Location = venue.Name + ", " + venue.City
+		}
+		if input.Location != nil {
+			occurrence.Location = *input.Location
+		}
+		if input.Tags != nil {
+			occurrence.Tags = normalizeTags(input.Tags)
+		}
+		if input.Capacity != nil {
+			occurrence.Capacity = *input.Capacity
+		}
+		// As ocorrências que já estão no estado pedido não mudam
+		if input.Status != "" && occurrence.StatusAt(now) != input.Status {
+			if err := transitionEvent(occurrence, input.Status, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.event.checkCapacity(occurrences); err != nil {
+		return nil, err
+	}
+	if err := s.events.UpdateAll(occurrences); err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+// Função para deletar a série com todas as ocorrências e os tickets delas
+func (s *SeriesService) DeleteSeries(id, userID uuid.UUID) error {
+	if _, err := s.managedSeries(id, userID); err != nil {
+		return err
+	}
+	occurrences, err := s.occurrences(id, nil)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.series.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSeriesNotFound
+	}
+
+	// Os arquivos das imagens das ocorrências saem depois dos registros
+	for _, occurrence := range occurrences {
+		removeImageFiles(s.event.storage, occurrence.Images...)
+	}
+	return nil
+}
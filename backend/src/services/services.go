@@ -0,0 +1,62 @@
+package services
+
+import (
+	"src/config"
+	"src/generator"
+	"src/mail"
+	"src/repository"
+	"src/storage"
+)
+
+// Conjunto dos serviços da aplicação, com as dependências já ligadas
+type Services struct {
+	Auth       *AuthService
+	OIDC       *OIDCService
+	APIKeys    *APIKeyService
+	Users      *UserService
+	Events     *EventService
+	Teams      *TeamService
+	Tickets    *TicketService
+	Categories *CategoryService
+	Venues     *VenueService
+	Series     *SeriesService
+	Images     *ImageService
+	Calendars  *CalendarService
+	Stats      *StatsService
+	Attendees  *AttendeeService
+	Questions  *QuestionService
+}
+
+// Função para criar os serviços a partir dos repositórios, da configuração e do envio dos emails
+func New(repos repository.Repositories, cfg *config.Config, mailer mail.Sender) *Services {
+	access := &eventAccess{members: repos.EventMembers}
+	verifier := &emailVerifier{users: repos.Users, mailer: mailer, appURL: cfg.AppURL}
+	auth := NewAuthService(repos, cfg, verifier)
+
+	oidcService := NewOIDCService(repos, auth)
+	for _, provider := range cfg.OIDCProviders {
+		oidcService.RegisterProvider(provider)
+	}
+
+	media := storage.NewLocal(cfg.MediaDir, cfg.MediaBaseURL)
+	events := NewEventService(repos, access, media, cfg.TimeZone)
+	tickets := NewTicketService(repos, access, generator.NewTicketSigner(cfg.TicketSecret), cfg.TimeZone)
+
+	return &Services{
+		Auth:       auth,
+		OIDC:       oidcService,
+		APIKeys:    NewAPIKeyService(repos, auth),
+		Users:      NewUserService(repos, verifier),
+		Events:     events,
+		Teams:      NewTeamService(repos, access),
+		Tickets:    tickets,
+		Categories: NewCategoryService(repos),
+		Venues:     NewVenueService(repos),
+		Series:     NewSeriesService(repos, events, access),
+		Images:     NewImageService(repos, media, access),
+		Calendars:  NewCalendarService(repos, events, tickets),
+		Stats:      NewStatsService(repos, access, cfg.TimeZone),
+		Attendees:  NewAttendeeService(repos, access),
+		Questions:  NewQuestionService(repos, access),
+	}
+}
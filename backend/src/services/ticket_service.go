@@ -3,100 +3,365 @@ package services
 import (
 	"crypto/md5"
 	"encoding/hex"
-	"errors"
+	"fmt"
+	"slices"
+	"src/apperrors"
 	"src/database"
 	"src/generator"
+	"src/repository"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Serviço dos tickets
+type TicketService struct {
+	tickets   repository.TicketRepository
+	events    repository.EventRepository
+	users     repository.UserRepository
+	payments  repository.PaymentRepository
+	questions repository.EventQuestionRepository
+	access    *eventAccess
+	signer    *generator.TicketSigner // Tokens dos QR Codes
+	timeZone  *time.Location          // Fuso dos dias dos eventos, para as entradas dos passes
+}
+
+// Função para criar o serviço dos tickets
+func NewTicketService(repos repository.Repositories, access *eventAccess, signer *generator.TicketSigner, timeZone *time.Location) *TicketService {
+	if timeZone == nil {
+		timeZone = time.UTC
+	}
+	return &TicketService{
+		tickets:   repos.Tickets,
+		events:    repos.Events,
+		users:     repos.Users,
+		payments:  repos.Payments,
+		questions: repos.Questions,
+		access:    access,
+		signer:    signer,
+		timeZone:  timeZone,
+	}
+}
+
+var ErrPassNotAvailable = &apperrors.Error{
+	Kind:    apperrors.Validation,
+	Code:    "pass_not_available",
+	Message: "passes are only sold for multi-day events",
+	Fields:  map[string]string{"type": "must be single for events that last one day"},
+}
+
+// Titular do ticket (quem vai ao evento) informado pelo comprador; sem nome,
+// o titular é o próprio comprador
+type HolderInput struct {
+	Name  string
+	Email string
+	Copy  bool // Compartilha o ticket com a conta do titular (pelo email)
+}
+
+// Função para validar o titular e aplicá-lo ao ticket (nome sem espaços nas
+// pontas e email em minúsculas)
+func (input HolderInput) apply(ticket *database.Ticket) error {
+	name, email := strings.TrimSpace(input.Name), strings.ToLower(strings.TrimSpace(input.Email))
+	switch {
+	case name == "" && email != "":
+		return apperrors.InvalidFields(map[string]string{"holder_name": "is required with holder_email"})
+	case input.Copy && email == "":
+		return apperrors.InvalidFields(map[string]string{"holder_email": "is required to share the ticket with the holder"})
+	}
+
+	ticket.HolderName, ticket.HolderEmail, ticket.HolderCopy = name, email, input.Copy
+	return nil
+}
+
+// Função para gerar o token do QR Code com o nome do titular do ticket
+func (s *TicketService) ticketToken(ticket database.Ticket) (string, error) {
+	name, _ := ticket.Holder()
+	token, err := s.signer.GenerateTicketToken(ticket.ID, ticket.EventID, ticket.UserID, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ticket token: %w", err)
+	}
+	return token, nil
+}
+
 // Função para gerar o hash MD5 do QR Code
 func generateQRCodeHash(qrCode string) string {
 	hash := md5.Sum([]byte(qrCode))
 	return hex.EncodeToString(hash[:])
 }
 
-// Função para criar um ticket
-func CreateTicket(eventID uuid.UUID, userID uuid.UUID) (*database.Ticket, error) {
+// Função para criar um ticket, com o titular e as respostas do comprador às perguntas do evento
+func (s *TicketService) CreateTicket(eventID uuid.UUID, ticketType string, holder HolderInput, answers []QuestionAnswer, userID uuid.UUID) (*database.Ticket, error) {
 	// Buscar o evento no banco de dados
-	var event database.Event
-	if err := database.DB.First(&event, "id = ?", eventID).Error; err != nil {
-		return nil, errors.New("evento não encontrado")
+	event, err := s.events.FindByID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
 	}
 
-	// Buscar o usuário no banco de dados
-	var user database.User
-	if err := database.DB.First(&user, "id = ?", userID).Error; err != nil {
-		return nil, errors.New("usuário não encontrado")
+	// Só há vendas com o evento publicado e dentro da janela de vendas;
+	// os rascunhos nem aparecem para os compradores
+	switch event.StatusAt(time.Now()) {
+	case database.EventDraft:
+		return nil, ErrEventNotFound
+	case database.EventSalesOpen:
+	default:
+		return nil, ErrEventNotOnSale
 	}
 
-	// Gerar um ID único para o ticket
-	ticketID := uuid.New()
+	// Os passes (uma entrada por dia) só existem nos eventos de vários dias
+	if ticketType == "" {
+		ticketType = database.TicketSingle
+	}
+	if ticketType == database.TicketPass && event.EndDate == nil {
+		return nil, ErrPassNotAvailable
+	}
 
-	// Gerar o token JWT para o ticket
-	token, err := generator.GenerateTicketToken(ticketID, eventID, userID)
+	// Valida as respostas às perguntas do cadastro dos participantes
+	questions, err := s.questions.ListByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	validAnswers, err := ticketAnswers(questions, answers)
 	if err != nil {
-		return nil, errors.New("erro ao gerar token do ticket")
+		return nil, err
 	}
 
-	// Gerar o hash MD5 do QR Code
+	// Buscar o usuário no banco de dados
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
 
 	// Criar o ticket no banco de dados com os objetos de `User` e `Event`
 	ticket := database.Ticket{
-		ID:         ticketID,
-		EventID:    eventID,
-		Event:      event,  // Atribuir o evento
-		UserID:     userID,
-		User:       user,   // Atribuir o usuário
-		Token:      token,
-		Status:     "valido",
+		ID:      uuid.New(), // Gerar um ID único para o ticket
+		EventID: eventID,
+		Event:   *event, // Atribuir o evento
+		UserID:  userID,
+		User:    *user, // Atribuir o usuário
+		Status:  "valido",
+		Type:    ticketType,
+		Answers: validAnswers,
+	}
+	if err := holder.apply(&ticket); err != nil {
+		return nil, err
 	}
 
-	// Salvar no banco de dados
-	if err := database.DB.Create(&ticket).Error; err != nil {
+	// Gerar o token JWT para o ticket, com o nome do titular
+	if ticket.Token, err = s.ticketToken(ticket); err != nil {
 		return nil, err
 	}
 
+	// Salvar no banco de dados, desde que ainda haja lugares no evento
+	created, err := s.tickets.CreateWithinCapacity(&ticket, event.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrEventSoldOut
+	}
+
 	return &ticket, nil
 }
 
-// Função para listar tickets de um usuário
-func GetTicketsByUser(userID uuid.UUID) ([]database.Ticket, error) {
-	var tickets []database.Ticket
+// Valor de ordenação de um ticket, usado no cursor da próxima página
+func ticketSortKey(field string) func(database.Ticket) (any, uuid.UUID) {
+	if field == repository.SortByEventDate {
+		return func(ticket database.Ticket) (any, uuid.UUID) { return ticket.Event.Date, ticket.ID }
+	}
+	return func(ticket database.Ticket) (any, uuid.UUID) { return ticket.CreatedAt, ticket.ID }
+}
+
+// Função para listar uma página dos tickets de um usuário
+func (s *TicketService) GetTicketsByUser(userID uuid.UUID, filter TicketFilter, request PageRequest) (*Page[database.Ticket], error) {
+	page, err := newPage(request, repository.SortByCreatedAt, repository.SortByEventDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Carrega os detalhes do evento, o organizador do evento e o usuário
+	filter.UserID = &userID
+	tickets, err := s.tickets.List(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.tickets.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPageResult(tickets, total, page, ticketSortKey(page.Sort.Field)), nil
+}
+
+// Função para listar uma página dos tickets compartilhados com o usuário, em que
+// ele é o titular (pelo email da conta, que precisa estar verificado) e o comprador pediu a cópia
+func (s *TicketService) GetTicketsSharedWith(userID uuid.UUID, filter TicketFilter, request PageRequest) (*Page[database.Ticket], error) {
+	page, err := newPage(request, repository.SortByCreatedAt, repository.SortByEventDate)
+	if err != nil {
+		return nil, err
+	}
 
-	// Fazendo JOIN para carregar os detalhes do evento, organizador do evento e do usuário
-	err := database.DB.
-		Preload("Event").               // Carrega os detalhes do evento relacionado
-		Preload("Event.Organizer").     // Carrega o organizador do evento
-		Preload("User").                // Carrega os detalhes do usuário relacionado
-		Where("user_id = ?", userID).
-		Find(&tickets).Error
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := requireVerifiedEmail(user); err != nil {
+		return nil, err
+	}
 
+	filter.SharedWith = strings.ToLower(user.Email)
+	tickets, err := s.tickets.List(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.tickets.Count(filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return tickets, nil
+	return newPageResult(tickets, total, page, ticketSortKey(page.Sort.Field)), nil
 }
 
+// Função para trocar o titular de um ticket válido, até o prazo definido no evento;
+// o token é gerado de novo, invalidando o QR Code anterior
+func (s *TicketService) ChangeTicketHolder(ticketID uuid.UUID, holder HolderInput, userID uuid.UUID) (*database.Ticket, error) {
+	// Só o comprador pode trocar o titular; para os demais o ticket não existe
+	ticket, err := s.tickets.FindByID(ticketID)
+	if err != nil || ticket.UserID != userID {
+		return nil, ErrTicketNotFound
+	}
 
+	switch {
+	case ticket.Status == "usado" || len(ticket.CheckIns) > 0:
+		return nil, ErrTicketAlreadyUsed
+	case ticket.Status == "cancelado":
+		return nil, ErrTicketCancelled
+	case !time.Now().Before(ticket.Event.HolderChangesUntil()):
+		return nil, ErrHolderChangeClosed
+	}
+
+	if err := holder.apply(ticket); err != nil {
+		return nil, err
+	}
+	if ticket.Token, err = s.ticketToken(*ticket); err != nil {
+		return nil, err
+	}
+
+	// Só altera se o ticket continuar válido e sem entradas
+	updated, err := s.tickets.UpdateHolder(ticket)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrTicketAlreadyUsed
+	}
+	return ticket, nil
+}
 
 // Função para listar tickets de um evento
-func GetTicketsByEvent(eventID uuid.UUID) ([]database.Ticket, error) {
-	var tickets []database.Ticket
+func (s *TicketService) GetTicketsByEvent(eventID uuid.UUID) ([]database.Ticket, error) {
+	// Traz as informações completas do evento, do organizador e do comprador
+	return s.tickets.ListByEvent(eventID)
+}
+
+var (
+	ErrTicketNotFound         = apperrors.NewNotFound("ticket_not_found", "ticket not found")
+	ErrTicketAlreadyUsed      = apperrors.NewConflict("ticket_already_used", "ticket already used")
+	ErrTicketCancelled        = apperrors.NewConflict("ticket_cancelled", "ticket cancelled")
+	ErrTicketEventCancelled   = apperrors.NewConflict("event_cancelled", "the event was cancelled")
+	ErrTicketValidationDenied = apperrors.NewForbidden("ticket_validation_denied", "not allowed to validate tickets for this event")
+	ErrTicketNotValidToday    = apperrors.NewConflict("ticket_not_valid_today", "this pass is not valid today")
+	ErrTicketCheckedInToday   = apperrors.NewConflict("ticket_checked_in_today", "this pass has already been checked in today")
+	ErrHolderChangeClosed     = apperrors.NewConflict("holder_change_closed", "holder can no longer be changed")
+	ErrHolderMismatch         = apperrors.NewConflict("holder_mismatch", "name does not match the ticket holder")
+)
 
-	// JOINs para trazer informações completas
-	err := database.DB.
-		Preload("Event").
-		Preload("Event.Organizer").     // Carrega o organizador do evento
-		Preload("User").
-		Where("event_id = ?", eventID).
-		Find(&tickets).Error
+// Função para comparar dois nomes sem diferenciar maiúsculas e minúsculas nem os espaços
+func sameName(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
 
+// Função para validar um ticket na entrada do evento (lido do QR Code); com o nome
+// informado pela portaria, confere também o titular (obrigatório se o evento exigir)
+func (s *TicketService) ValidateTicket(token, holderName string, userID uuid.UUID) (*database.Ticket, error) {
+	// Verifica a assinatura do token antes de consultar o banco
+	if _, err := s.signer.ParseTicketToken(token); err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	ticket, err := s.tickets.FindByToken(token)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	// Apenas o organizador e a equipe de portaria podem validar os tickets
+	if err := s.access.authorize(&ticket.Event, userID, PermissionValidateTickets); err != nil {
+		return nil, ErrTicketValidationDenied
+	}
+
+	// Os tickets continuam válidos quando o evento é cancelado, mas ninguém entra
+	if ticket.Event.StatusAt(time.Now()) == database.EventCancelled {
+		return nil, ErrTicketEventCancelled
+	}
+
+	switch ticket.Status {
+	case "usado":
+		return nil, ErrTicketAlreadyUsed
+	case "cancelado":
+		return nil, ErrTicketCancelled
+	}
+
+	// Confere o nome do titular antes de registrar a entrada
+	if strings.TrimSpace(holderName) == "" {
+		if ticket.Event.CheckHolderAtGate {
+			return nil, apperrors.InvalidFields(map[string]string{"holder_name": "is required for this event"})
+		}
+	} else if name, _ := ticket.Holder(); !sameName(holderName, name) {
+		return nil, ErrHolderMismatch
+	}
+
+	// Os passes entram uma vez em cada dia do evento e continuam válidos
+	if ticket.Type == database.TicketPass {
+		return s.checkInPass(ticket, userID)
+	}
+
+	// Marca como usado de forma atômica, evitando duas entradas com o mesmo ticket
+	updated, err := s.tickets.TransitionStatus(ticket.ID, "valido", "usado")
 	if err != nil {
 		return nil, err
 	}
+	if !updated {
+		return nil, ErrTicketAlreadyUsed
+	}
 
-	return tickets, nil
+	// Registra o momento da entrada, exibido na lista de participantes
+	checkIn := database.TicketCheckIn{TicketID: ticket.ID, Day: database.DayIn(time.Now(), s.timeZone), CheckedInByID: &userID}
+	if _, err := s.tickets.CheckIn(&checkIn); err != nil {
+		return nil, err
+	}
+
+	ticket.Status = "usado"
+	ticket.CheckIns = append(ticket.CheckIns, checkIn)
+	return ticket, nil
 }
 
+// Função para registrar a entrada do passe no dia de hoje (no fuso configurado)
+func (s *TicketService) checkInPass(ticket *database.Ticket, userID uuid.UUID) (*database.Ticket, error) {
+	today := database.DayIn(time.Now(), s.timeZone)
+	if !slices.ContainsFunc(ticket.Event.DaysIn(s.timeZone), today.Equal) {
+		return nil, ErrTicketNotValidToday
+	}
+
+	// O índice único por dia evita duas entradas do mesmo passe no mesmo dia
+	checkIn := database.TicketCheckIn{TicketID: ticket.ID, Day: today, CheckedInByID: &userID}
+	created, err := s.tickets.CheckIn(&checkIn)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrTicketCheckedInToday
+	}
+
+	ticket.CheckIns = append(ticket.CheckIns, checkIn)
+	return ticket, nil
+}
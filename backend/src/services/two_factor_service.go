@@ -0,0 +1,236 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"src/apperrors"
+	"src/database"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/skip2/go-qrcode"
+)
+
+// Nome exibido nas aplicações autenticadoras (Google Authenticator, Authy, ...)
+const totpIssuer = "Ticketing System"
+
+// Quantidade de códigos de recuperação gerados na ativação do 2FA
+const recoveryCodeCount = 10
+
+// Duração de cada código TOTP, em segundos (o padrão das aplicações autenticadoras)
+const totpPeriod = 30
+
+var (
+	ErrTwoFactorRequired       = apperrors.NewUnauthorized("two_factor_required", "two-factor authentication code required")
+	ErrInvalidTwoFactorCode    = apperrors.NewUnauthorized("invalid_two_factor_code", "invalid two-factor authentication code")
+	ErrTwoFactorAlreadyEnabled = apperrors.NewConflict("two_factor_already_enabled", "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = apperrors.NewConflict("two_factor_not_enabled", "two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled    = apperrors.NewValidation("two_factor_not_enrolled", "two-factor enrollment has not been started")
+
+	// Na confirmação o usuário já está autenticado, então o código errado é um erro de validação
+	ErrInvalidEnrollmentCode = apperrors.NewValidation("invalid_two_factor_code", "invalid two-factor authentication code")
+)
+
+// Função para verificar se o papel do usuário exige 2FA (TOTP_REQUIRED_ROLES)
+func (s *AuthService) TwoFactorRequired(role string) bool {
+	return s.twoFactorRequiredRoles[role]
+}
+
+// Dados devolvidos ao iniciar a ativação do 2FA
+type TwoFactorEnrollment struct {
+	Secret     string
+	OTPAuthURI string
+	QRCodePNG  []byte
+}
+
+// Função para iniciar a ativação do 2FA: gera um novo segredo ainda não ativo
+func (s *AuthService) EnrollTwoFactor(userID uuid.UUID) (*TwoFactorEnrollment, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Gera o QR Code com a URI otpauth:// para ser lido pela aplicação autenticadora
+	png, err := qrcode.Encode(key.URL(), qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	// O segredo fica pendente até o usuário confirmar um código válido
+	user.TOTPSecret = key.Secret()
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:     key.Secret(),
+		OTPAuthURI: key.URL(),
+		QRCodePNG:  png,
+	}, nil
+}
+
+// Função para confirmar a ativação do 2FA e gerar os códigos de recuperação
+func (s *AuthService) ConfirmTwoFactor(userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	accepted, err := s.acceptTOTPCode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrInvalidEnrollmentCode
+	}
+
+	user.TOTPEnabled = true
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+
+	return s.generateRecoveryCodes(user.ID)
+}
+
+// Função para desativar o 2FA, exigindo novamente a senha e um código válido
+func (s *AuthService) DisableTwoFactor(userID uuid.UUID, password, code string) error {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !user.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if !user.CheckPassword(password) {
+		return ErrInvalidCredentials
+	}
+
+	if err := s.verifySecondFactor(user, code); err != nil {
+		return err
+	}
+
+	// Remove o segredo e todos os códigos de recuperação
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	if err := s.users.Update(user); err != nil {
+		return err
+	}
+
+	return s.recoveryCodes.DeleteByUser(user.ID)
+}
+
+// Função para verificar o segundo fator: código TOTP ou código de recuperação
+func (s *AuthService) verifySecondFactor(user *database.User, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return ErrTwoFactorRequired
+	}
+
+	accepted, err := s.acceptTOTPCode(user, code)
+	if err != nil {
+		return err
+	}
+	if accepted {
+		return nil
+	}
+
+	// Tenta como código de recuperação (cada código só pode ser usado uma vez)
+	used, err := s.recoveryCodes.MarkUsed(user.ID, hashRecoveryCode(code), time.Now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// Função para encontrar o passo do código TOTP, aceitando um passo antes ou
+// depois do atual para tolerar a diferença entre os relógios
+func totpStep(code, secret string, now time.Time) (int64, bool) {
+	for _, offset := range []int64{-1, 0, 1} {
+		at := now.Add(time.Duration(offset*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCode(secret, at)
+		if err == nil && subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+// Função para aceitar o código TOTP uma única vez: o passo do código precisa ser
+// posterior ao último aceito, então um código interceptado não pode ser reutilizado
+func (s *AuthService) acceptTOTPCode(user *database.User, code string) (bool, error) {
+	step, ok := totpStep(strings.TrimSpace(code), user.TOTPSecret, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return s.users.AdvanceTOTPStep(user.ID, step)
+}
+
+// Função para gerar novos códigos de recuperação, substituindo os anteriores
+func (s *AuthService) generateRecoveryCodes(userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]database.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		records = append(records, database.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		})
+	}
+
+	if err := s.recoveryCodes.Replace(userID, records); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Gera um código no formato XXXXX-XXXXX
+func randomRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)[:10]
+	return encoded[:5] + "-" + encoded[5:], nil
+}
+
+// Os códigos têm entropia suficiente para um hash SHA-256 simples
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
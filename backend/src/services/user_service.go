@@ -2,64 +2,108 @@ package services
 
 import (
 	"fmt"
+	"log"
+	"src/apperrors"
 	"src/database"
+	"src/repository"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// Função para atualizar as informações do usuário
-func UpdateUser(userID uuid.UUID, name, email string) (*database.User, error) {
-	var user database.User
+var ErrIncorrectPassword = apperrors.NewValidation("incorrect_password", "old password is incorrect")
+
+// Serviço do perfil do usuário
+type UserService struct {
+	users    repository.UserRepository
+	verifier *emailVerifier
+}
+
+// Função para criar o serviço do perfil do usuário
+func NewUserService(repos repository.Repositories, verifier *emailVerifier) *UserService {
+	return &UserService{users: repos.Users, verifier: verifier}
+}
 
+// Função para atualizar as informações do usuário
+func (s *UserService) UpdateUser(userID uuid.UUID, name, email string) (*database.User, error) {
 	// Verifica se o usuário existe
-	if err := database.DB.First(&user, "id = ?", userID).Error; err != nil {
-		return nil, fmt.Errorf("user not found")
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
 	}
 
 	// Verifica se o email já está em uso por outro usuário
-	var existingUser database.User
-	if err := database.DB.Where("email = ?", email).First(&existingUser).Error; err == nil && existingUser.ID != userID {
-		return nil, fmt.Errorf("email is already in use")
+	if existingUser, err := s.users.FindByEmail(email); err == nil && existingUser.ID != userID {
+		return nil, ErrEmailInUse
 	}
 
+	// Um novo email precisa ser verificado de novo
+	emailChanged := user.Email != email
+
 	// Atualiza os dados do usuário, mas não altera o role
 	user.Name = name
 	user.Email = email
+	if emailChanged {
+		user.EmailVerified = false
+		user.EmailVerificationHash, user.EmailVerificationExpiresAt = nil, nil
+	}
 
 	// Salva as alterações no banco
-	if err := database.DB.Save(&user).Error; err != nil {
+	if err := s.users.Update(user); err != nil {
 		return nil, err
 	}
 
-	return &user, nil
+	// Envia o link de verificação para o novo email; se falhar, o usuário pode pedir outro
+	if emailChanged {
+		if err := s.verifier.send(user); err != nil {
+			log.Println("Erro ao enviar o email de verificação:", err)
+		}
+	}
+
+	return user, nil
 }
 
+// Função para enviar de novo o link de verificação do email do usuário
+func (s *UserService) ResendEmailVerification(userID uuid.UUID) error {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	return s.verifier.send(user)
+}
+
+// Função para confirmar o email pelo token do link enviado
+func (s *UserService) VerifyEmail(token string) (*database.User, error) {
+	return s.verifier.verify(token)
+}
 
-func ChangePassword(userID uuid.UUID, oldPassword, newPassword string) error {
-	var user database.User
 
+func (s *UserService) ChangePassword(userID uuid.UUID, oldPassword, newPassword string) error {
 	// Verifica se o usuário existe
-	if err := database.DB.First(&user, "id = ?", userID).Error; err != nil {
-		return fmt.Errorf("user not found")
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return ErrUserNotFound
 	}
 
 	// Verifica se a senha antiga está correta
 	if !user.CheckPassword(oldPassword) {
-		return fmt.Errorf("old password is incorrect")
+		return ErrIncorrectPassword
 	}
 
 	// Criptografa a nova senha
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
-		return fmt.Errorf("failed to hash new password")
+		return fmt.Errorf("failed to hash new password: %w", err)
 	}
 
 	// Atualiza a senha no banco de dados
 	user.Password = string(hashedPassword)
 
 	// Salva a nova senha no banco
-	if err := database.DB.Save(&user).Error; err != nil {
+	if err := s.users.Update(user); err != nil {
 		return err
 	}
 
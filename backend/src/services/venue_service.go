@@ -0,0 +1,158 @@
+package services
+
+import (
+	"errors"
+	"src/apperrors"
+	"src/database"
+	"src/repository"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVenueNotFound         = apperrors.NewNotFound("venue_not_found", "venue not found")
+	ErrVenueOrganizerOnly    = apperrors.NewForbidden("venue_organizer_only", "only organizers can register venues")
+	ErrVenuePermissionDenied = apperrors.NewForbidden("venue_permission_denied", "only the organizer who registered the venue can change it")
+	ErrUnknownVenue          = &apperrors.Error{
+		Kind:    apperrors.Validation,
+		Code:    "unknown_venue",
+		Message: "venue does not exist",
+		Fields:  map[string]string{"venue_id": "must be an existing venue"},
+	}
+)
+
+// Dados de um local informados pelo organizador
+type VenueInput struct {
+	Name          string
+	Address       string
+	City          string
+	Latitude      float64
+	Longitude     float64
+	Capacity      int
+	Accessibility string
+}
+
+// Serviço dos locais de eventos
+type VenueService struct {
+	venues repository.VenueRepository
+	users  repository.UserRepository
+}
+
+// Função para criar o serviço dos locais
+func NewVenueService(repos repository.Repositories) *VenueService {
+	return &VenueService{venues: repos.Venues, users: repos.Users}
+}
+
+// Função para aplicar os dados informados ao local
+func (input VenueInput) apply(venue *database.Venue) {
+	venue.Name = strings.TrimSpace(input.Name)
+	venue.Address = strings.TrimSpace(input.Address)
+	venue.City = strings.TrimSpace(input.City)
+	venue.Latitude = input.Latitude
+	venue.Longitude = input.Longitude
+	venue.Capacity = input.Capacity
+	venue.Accessibility = strings.TrimSpace(input.Accessibility)
+}
+
+// Função para cadastrar um local (organizadores e administradores)
+func (s *VenueService) CreateVenue(input VenueInput, userID uuid.UUID) (*database.Venue, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role != "organizer" && user.Role != "admin" {
+		return nil, ErrVenueOrganizerOnly
+	}
+
+	venue := database.Venue{CreatedByID: userID}
+	input.apply(&venue)
+	if err := s.venues.Create(&venue); err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// Função para listar uma página dos locais, em ordem alfabética
+func (s *VenueService) GetVenues(filter VenueFilter, request PageRequest) (*Page[database.Venue], error) {
+	page, err := newPage(request, repository.SortByName)
+	if err != nil {
+		return nil, err
+	}
+
+	venues, err := s.venues.List(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.venues.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(venue database.Venue) (any, uuid.UUID) { return venue.Name, venue.ID }
+	return newPageResult(venues, total, page, key), nil
+}
+
+// Função para buscar um local específico
+func (s *VenueService) GetVenue(id uuid.UUID) (*database.Venue, error) {
+	venue, err := s.venues.FindByID(id)
+	if err != nil {
+		return nil, venueError(err)
+	}
+	return venue, nil
+}
+
+// Função para buscar um local que o usuário pode alterar: quem o cadastrou ou um administrador
+func (s *VenueService) managedVenue(id, userID uuid.UUID) (*database.Venue, error) {
+	venue, err := s.venues.FindByID(id)
+	if err != nil {
+		return nil, venueError(err)
+	}
+	if venue.CreatedByID == userID {
+		return venue, nil
+	}
+
+	user, err := s.users.FindByID(userID)
+	if err != nil || user.Role != "admin" {
+		return nil, ErrVenuePermissionDenied
+	}
+	return venue, nil
+}
+
+// Função para atualizar um local
+func (s *VenueService) UpdateVenue(id uuid.UUID, input VenueInput, userID uuid.UUID) (*database.Venue, error) {
+	venue, err := s.managedVenue(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	input.apply(venue)
+	if err := s.venues.Update(venue); err != nil {
+		return nil, venueError(err)
+	}
+	return venue, nil
+}
+
+// Função para remover um local; os eventos dele ficam sem local
+func (s *VenueService) DeleteVenue(id, userID uuid.UUID) error {
+	if _, err := s.managedVenue(id, userID); err != nil {
+		return err
+	}
+
+	deleted, err := s.venues.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrVenueNotFound
+	}
+	return nil
+}
+
+// Converte os erros do repositório nos erros da API
+func venueError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrVenueNotFound
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// Marca de ordem de bytes do UTF-8, para o Excel não estragar os acentos
+const utf8BOM = "\uFEFF"
+
+// Planilha CSV
+type CSV struct {
+	out     io.Writer
+	writer  *csv.Writer
+	started bool
+}
+
+// Função para criar a planilha CSV
+func NewCSV(out io.Writer) *CSV {
+	return &CSV{out: out, writer: csv.NewWriter(out)}
+}
+
+func (c *CSV) WriteRow(cells []string) error {
+	if !c.started {
+		c.started = true
+		if _, err := io.WriteString(c.out, utf8BOM); err != nil {
+			return err
+		}
+	}
+
+	safe := make([]string, len(cells))
+	for i, cell := range cells {
+		safe[i] = neutralizeFormula(cell)
+	}
+	return c.writer.Write(safe)
+}
+
+func (c *CSV) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *CSV) Close() error {
+	return c.Flush()
+}
+
+// Função para impedir que o texto seja lido como fórmula pelo Excel ou pelo
+// LibreOffice (injeção de fórmulas): o apóstrofo na frente o mantém como texto
+func neutralizeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,12 @@
+package spreadsheet
+
+// Planilha escrita linha a linha direto na saída (ex: a resposta HTTP), sem
+// guardar as linhas em memória
+type Writer interface {
+	// Escreve uma linha de células de texto
+	WriteRow(cells []string) error
+	// Envia para a saída as linhas já escritas
+	Flush() error
+	// Termina o arquivo; nenhuma linha pode ser escrita depois
+	Close() error
+}
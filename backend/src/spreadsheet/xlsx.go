@@ -0,0 +1,130 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Tamanho máximo do nome de uma aba no Excel
+const maxSheetName = 31
+
+// Partes fixas do pacote OOXML com uma única aba
+const (
+	contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	packageRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	sheetStartXML = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetEndXML   = `</sheetData></worksheet>`
+)
+
+// Planilha XLSX (Office Open XML) com uma aba e as células como texto
+type XLSX struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+// Função para criar a planilha XLSX; as partes fixas são escritas logo e a
+// aba vai sendo escrita conforme as linhas chegam
+func NewXLSX(out io.Writer, sheetName string) (*XLSX, error) {
+	archive := zip.NewWriter(out)
+	workbookXML := xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + escapeXML(cleanSheetName(sheetName)) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", packageRelsXML},
+		{"xl/workbook.xml", workbookXML},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+		{"xl/worksheets/sheet1.xml", sheetStartXML},
+	}
+	var sheet io.Writer
+	for _, part := range parts {
+		writer, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(writer, part.content); err != nil {
+			return nil, err
+		}
+		sheet = writer
+	}
+
+	// A aba é a última parte aberta e continua recebendo as linhas
+	return &XLSX{zip: archive, sheet: sheet}, nil
+}
+
+func (x *XLSX) WriteRow(cells []string) error {
+	x.rows++
+	row := strconv.Itoa(x.rows)
+
+	var builder strings.Builder
+	builder.WriteString(`<row r="` + row + `">`)
+	for i, cell := range cells {
+		builder.WriteString(`<c r="` + columnName(i) + row + `" t="inlineStr"><is><t xml:space="preserve">`)
+		builder.WriteString(escapeXML(cell))
+		builder.WriteString(`</t></is></c>`)
+	}
+	builder.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, builder.String())
+	return err
+}
+
+func (x *XLSX) Flush() error {
+	return x.zip.Flush()
+}
+
+func (x *XLSX) Close() error {
+	if _, err := io.WriteString(x.sheet, sheetEndXML); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// Função para converter o índice da coluna no nome usado pelo Excel (0 = A, 26 = AA)
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// Função para escapar o texto da célula; os caracteres que o XML não aceita
+// (como os de controle) são substituídos
+func escapeXML(text string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(text))
+	return builder.String()
+}
+
+// Função para remover do nome da aba os caracteres que o Excel não aceita
+func cleanSheetName(name string) string {
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name))
+	if runes := []rune(name); len(runes) > maxSheetName {
+		name = string(runes[:maxSheetName])
+	}
+	if name == "" {
+		return "Sheet1"
+	}
+	return name
+}
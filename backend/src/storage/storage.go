@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// Arquivo inexistente no armazenamento
+var ErrNotFound = errors.New("file not found")
+
+// Armazenamento dos arquivos enviados (ex: imagens dos eventos). As chaves são
+// caminhos relativos separados por "/", como "events/<id>/<imagem>/thumb.jpg".
+// A implementação local grava no disco; um armazenamento compatível com S3
+// só precisa implementar a mesma interface.
+type Storage interface {
+	// Guarda o conteúdo na chave, substituindo o arquivo existente
+	Put(key string, content io.Reader, contentType string) error
+	// Abre o arquivo para leitura; retorna ErrNotFound se não existir
+	Open(key string) (io.ReadCloser, error)
+	// Remove o arquivo; remover um arquivo inexistente não é erro
+	Delete(key string) error
+	// Endereço público do arquivo, devolvido nas respostas da API
+	URL(key string) string
+}